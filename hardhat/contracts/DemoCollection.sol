@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.20;
+
+import "@openzeppelin/contracts/token/ERC721/ERC721.sol";
+import "@openzeppelin/contracts/utils/Base64.sol";
+import "@openzeppelin/contracts/utils/Strings.sol";
+
+
+contract DemoCollection is ERC721 {
+
+    uint256 public nextTokenId = 1;
+
+    // The deployer receives the first tokens of the collection
+    constructor(uint256 initialTokens) ERC721("DemoCollection", "DC") {
+        for (uint256 i = 0; i < initialTokens; i++) {
+            mint(msg.sender);
+        }
+    }
+
+    function mint(address to) public returns (uint256) {
+        uint256 tokenId = nextTokenId++;
+        _safeMint(to, tokenId);
+        return tokenId;
+    }
+
+    // The metadata is stored on chain as a data URI, so no server is needed to show it
+    function tokenURI(uint256 tokenId) public view override returns (string memory) {
+        _requireOwned(tokenId);
+        string memory id = Strings.toString(tokenId);
+        string memory json = string.concat(
+            '{"name":"Demo #', id, '","description":"Token ', id, ' of the DemoCollection"}'
+        );
+        return string.concat("data:application/json;base64,", Base64.encode(bytes(json)));
+    }
+}
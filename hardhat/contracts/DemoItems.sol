@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.20;
+
+import "@openzeppelin/contracts/token/ERC1155/ERC1155.sol";
+
+
+contract DemoItems is ERC1155 {
+
+    uint256 public constant GOLD = 1;
+    uint256 public constant SWORD = 2;
+    uint256 public constant SHIELD = 3;
+
+    // Wallets replace {id} with the token ID as 64 hexadecimal digits
+    constructor(string memory uri_) ERC1155(uri_) {
+        uint256[] memory ids = new uint256[](3);
+        uint256[] memory amounts = new uint256[](3);
+        (ids[0], ids[1], ids[2]) = (GOLD, SWORD, SHIELD);
+        (amounts[0], amounts[1], amounts[2]) = (1000, 1, 2);
+        _mintBatch(msg.sender, ids, amounts, "");
+    }
+}
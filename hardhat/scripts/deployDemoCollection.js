@@ -0,0 +1,26 @@
+const hre = require("hardhat");
+
+async function main() {
+  const [owner, account1] = await hre.ethers.getSigners();
+  const demoCollectionFactory = await hre.ethers.getContractFactory("DemoCollection");
+  // The owner receives tokens 1 to 3
+  const demoCollection = await demoCollectionFactory.deploy(3);
+  await demoCollection.waitForDeployment();
+  const address = await demoCollection.getAddress();
+  console.log("DemoCollection deployed to", address);
+
+  // Mint a token to the second account too
+  await demoCollection.mint(account1.address);
+  for (const account of [owner, account1]) {
+    const balance = await demoCollection.balanceOf(account.address);
+    console.log(`${account.address} holds ${balance} DemoCollection tokens`);
+  }
+  console.log("Token 1 metadata:", await demoCollection.tokenURI(1));
+}
+
+main()
+ .then(() => process.exit(0))
+ .catch((error) => {
+   console.error(error);
+   process.exit(1);
+ });
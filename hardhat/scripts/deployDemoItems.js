@@ -0,0 +1,25 @@
+const hre = require("hardhat");
+
+async function main() {
+  const [owner, account1] = await hre.ethers.getSigners();
+  const demoItemsFactory = await hre.ethers.getContractFactory("DemoItems");
+  // The owner receives gold, a sword and two shields
+  const demoItems = await demoItemsFactory.deploy("https://example.com/items/{id}.json");
+  await demoItems.waitForDeployment();
+  console.log("DemoItems deployed to", await demoItems.getAddress());
+
+  // Send some gold to the second account
+  await demoItems.safeTransferFrom(owner.address, account1.address, 1, 100, "0x");
+  const balances = await demoItems.balanceOfBatch(
+    [owner.address, owner.address, owner.address, account1.address],
+    [1, 2, 3, 1]
+  );
+  console.log("Balances of gold, sword and shields, and gold of the second account:", balances.join(", "));
+}
+
+main()
+ .then(() => process.exit(0))
+ .catch((error) => {
+   console.error(error);
+   process.exit(1);
+ });
@@ -3,26 +3,56 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"time"
+	"wallet/internal/currencies/eth"
 	"wallet/internal/hdwallet"
+	"wallet/internal/signer"
 	"wallet/internal/utils"
 
 	_ "modernc.org/sqlite"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/labstack/gommon/log"
 	"github.com/tyler-smith/go-bip39"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // App struct.
 type App struct {
-	ctx      context.Context
-	wallet   *hdwallet.Wallet
-	walletDB *hdwallet.WalletStorage
+	ctx        context.Context
+	wallet     *hdwallet.Wallet
+	walletDB   *hdwallet.WalletStorage
+	stopSigner context.CancelFunc
+	// stopWatching stops pushing chain updates to the frontend.
+	stopWatching context.CancelFunc
 }
 
+// recentRecipientsLimit is the number of recent recipients offered as address book suggestions.
+const recentRecipientsLimit = 5
+
+// ReceiveRequest is a request for a payment to an account, to show in the receive view.
+type ReceiveRequest struct {
+	Request *eth.PaymentRequest `json:"request"`
+	// URI is the EIP-681 URI of the request, and QRCode a PNG image of it as a data URL.
+	URI    string `json:"uri"`
+	QRCode string `json:"qrCode"`
+}
+
+// Asset holds the accounts of a token and their balances, as exact decimal strings.
 type Asset struct {
-	Balance  float64        `json:"balance"`
+	Balance  string         `json:"balance"`
 	Accounts map[int]string `json:"accounts"`
+	Balances map[int]string `json:"balances"`
+	// Collectibles are those found by the last collectibles scan, see GetCollectibles.
+	Collectibles []eth.Collectible `json:"collectibles"`
+}
+
+// ArtifactFile is a Hardhat artifact picked by the user to deploy its contract.
+type ArtifactFile struct {
+	Path     string                `json:"path"`
+	Artifact *eth.ContractArtifact `json:"artifact"`
 }
 
 // NewApp creates a new App application struct.
@@ -38,7 +68,7 @@ func (a *App) startup(ctx context.Context) {
 	defer cancel()
 
 	if err != nil {
-		log.Errorf("error creating wallet storage:", err)
+		log.Errorf("error creating wallet storage: %v", err)
 		return
 	}
 
@@ -72,6 +102,7 @@ func (a *App) CreateWallet(tokens []string, password string) (string, error) {
 		return "", fmt.Errorf("error initializing wallet: %w", err)
 	}
 
+	a.watch(tokens)
 	return mnemonic, nil
 }
 
@@ -87,6 +118,7 @@ func (a *App) RestoreWallet(tokens []string, password, mnemonic string) error {
 		return fmt.Errorf("error initializing wallet: %w", err)
 	}
 
+	a.watch(tokens)
 	return nil
 }
 
@@ -102,15 +134,51 @@ func (a *App) RecoverWallet(tokens []string, password string) error {
 		return fmt.Errorf("error initializing wallet: %w", err)
 	}
 
+	a.watch(tokens)
 	return nil
 }
 
+// watch pushes the balances of every account, as "balances" events, and the
+// transactions that got mined, as "transactions" events, to the frontend on every
+// new block. Token transfers to the accounts are pushed as "tokenTransfer" events.
+func (a *App) watch(tokens []string) {
+	if a.stopWatching != nil {
+		a.stopWatching()
+	}
+
+	watchCtx, cancel := context.WithCancel(a.ctx)
+	a.stopWatching = cancel
+	for _, token := range tokens {
+		err := a.wallet.Watch(watchCtx, token, hdwallet.WatchHandlers{
+			OnBalances: func(update hdwallet.BalanceUpdate) {
+				runtime.EventsEmit(a.ctx, "balances", update)
+			},
+			OnTransactions: func(transactions []hdwallet.WalletTransaction) {
+				runtime.EventsEmit(a.ctx, "transactions", transactions)
+			},
+			OnTokenTransfer: func(transfer eth.TokenTransfer) {
+				runtime.EventsEmit(a.ctx, "tokenTransfer", transfer)
+			},
+			OnError: func(err error) {
+				log.Errorf("error watching %s: %v", token, err)
+			},
+		})
+		if err != nil {
+			log.Errorf("error watching %s: %v", token, err)
+		}
+	}
+}
+
+// GetAssets returns the balances of every account of the given tokens, along with
+// the balance of the selected account. Each token costs a single node round trip for
+// the balances. The collectibles section reuses the last GetCollectibles scan instead
+// of scanning the chain again, so it is empty until the first scan.
 func (a *App) GetAssets(tokens map[string]int) (map[string]Asset, error) {
 	var assets = make(map[string]Asset)
 	for token, index := range tokens {
-		balance, err := a.wallet.GetBalance(token, index)
+		balances, err := a.wallet.GetBalances(token)
 		if err != nil {
-			return nil, fmt.Errorf("error getting balance for token %s: %w", token, err)
+			return nil, fmt.Errorf("error getting balances for token %s: %w", token, err)
 		}
 
 		accounts, err := a.wallet.GetAllAccounts(token)
@@ -119,18 +187,93 @@ func (a *App) GetAssets(tokens map[string]int) (map[string]Asset, error) {
 		}
 
 		assets[token] = Asset{
-			Balance:  balance,
-			Accounts: accounts,
+			Balance:      balances[index],
+			Accounts:     accounts,
+			Balances:     balances,
+			Collectibles: a.wallet.LastCollectibles(token),
 		}
 	}
 
 	return assets, nil
 }
 
+// GetProviderStatus reports the health of the RPC endpoints of a token, and which one
+// is in use.
+func (a *App) GetProviderStatus(token string) ([]eth.ProviderStatus, error) {
+	status, err := a.wallet.GetProviderStatus(token)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving provider status: %w", err)
+	}
+
+	return status, nil
+}
+
+func (a *App) SetProviders(token string, urls []string) error {
+	err := a.wallet.SetProviders(token, urls)
+	if err != nil {
+		return fmt.Errorf("error setting providers: %w", err)
+	}
+
+	return nil
+}
+
+// GetNonceGaps reports the accounts whose sent transactions the node lost. Their later
+// transactions stay queued until the gap is filled.
+func (a *App) GetNonceGaps(token string) ([]eth.NonceGap, error) {
+	gaps, err := a.wallet.GetNonceGaps(token)
+	if err != nil {
+		return nil, fmt.Errorf("error checking nonce gaps: %w", err)
+	}
+
+	return gaps, nil
+}
+
+func (a *App) ResetNonceGap(token, address string) error {
+	err := a.wallet.ResetNonceGap(token, address)
+	if err != nil {
+		return fmt.Errorf("error resetting nonce gap: %w", err)
+	}
+
+	return nil
+}
+
 func (a *App) ValidateAddress(address, token string) bool {
 	return utils.ValidateAddress(address, token)
 }
 
+// ResolveRecipient returns the address a recipient typed in the Send view stands for: an
+// address, a contact name or an ENS name such as alice.eth.
+func (a *App) ResolveRecipient(token, recipient string) (string, error) {
+	address, err := a.wallet.ResolveRecipient(token, recipient)
+	if err != nil {
+		return "", fmt.Errorf("error resolving recipient: %w", err)
+	}
+
+	return address, nil
+}
+
+// LookupAddresses returns the ENS names of the addresses that have one, to show them
+// instead of the counterparties of the history.
+func (a *App) LookupAddresses(token string, addresses []string) (map[string]string, error) {
+	names, err := a.wallet.LookupAddresses(token, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up names: %w", err)
+	}
+
+	return names, nil
+}
+
+// SetENSRegistry resolves the ENS names of a token with another registry, such as one
+// deployed on Hardhat.
+func (a *App) SetENSRegistry(token, registry string) error {
+	err := a.wallet.SetENSRegistry(token, registry)
+	if err != nil {
+		return fmt.Errorf("error setting ENS registry: %w", err)
+	}
+
+	return nil
+}
+
 func (a *App) EstimateGas(token, to, value string, accountIndex int) (string, error) {
 	gasPrice, err := a.wallet.EstimateGas(token, to, value, accountIndex)
 	if err != nil {
@@ -140,6 +283,23 @@ func (a *App) EstimateGas(token, to, value string, accountIndex int) (string, er
 	return gasPrice, nil
 }
 
+// ReviewTransaction runs the preflight checks of a transfer, to show them before the user
+// confirms it. Sending is only safe when the review has no errors.
+func (a *App) ReviewTransaction(token, to, value string, accountIndex int) (*eth.TransactionReview, error) {
+	review, err := a.wallet.ReviewTransaction(token, to, value, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing transaction %w", err)
+	}
+
+	return review, nil
+}
+
+// ConfirmRecipient records that the user checked a recipient flagged by ReviewTransaction,
+// so the next transaction to it can be sent.
+func (a *App) ConfirmRecipient(token, to string) {
+	a.wallet.ConfirmRecipient(token, to)
+}
+
 func (a *App) SendTransaction(token, password, to, value string, accountIndex int) (bool, error) {
 	ok, err := a.wallet.SendTransaction(token, password, to, value, accountIndex)
 	if err != nil {
@@ -152,3 +312,699 @@ func (a *App) SendTransaction(token, password, to, value string, accountIndex in
 func (a *App) GetTransactions() ([]hdwallet.WalletTransaction, error) {
 	return a.wallet.GetTransactions()
 }
+
+// SpeedUpTransaction sends a pending transaction again with higher fees and returns the
+// hash of the replacement.
+func (a *App) SpeedUpTransaction(password, hash string) (string, error) {
+	txHash, err := a.wallet.SpeedUpTransaction(password, hash)
+	if err != nil {
+		return "", fmt.Errorf("error speeding up transaction: %w", err)
+	}
+
+	return txHash, nil
+}
+
+// CancelTransaction replaces a pending transaction with an empty transfer to its sender.
+func (a *App) CancelTransaction(password, hash string) (string, error) {
+	txHash, err := a.wallet.CancelTransaction(password, hash)
+	if err != nil {
+		return "", fmt.Errorf("error cancelling transaction: %w", err)
+	}
+
+	return txHash, nil
+}
+
+func (a *App) GetContacts() ([]hdwallet.Contact, error) {
+	contacts, err := a.wallet.GetContacts()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving contacts: %w", err)
+	}
+
+	return contacts, nil
+}
+
+// AddContact saves a new address book entry. The network defaults to the active one.
+func (a *App) AddContact(contact hdwallet.Contact) (*hdwallet.Contact, error) {
+	contact.ID = 0
+	saved, err := a.wallet.SaveContact(contact)
+	if err != nil {
+		return nil, fmt.Errorf("error adding contact: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (a *App) UpdateContact(contact hdwallet.Contact) (*hdwallet.Contact, error) {
+	if contact.ID == 0 {
+		return nil, fmt.Errorf("error updating contact: missing contact ID")
+	}
+
+	saved, err := a.wallet.SaveContact(contact)
+	if err != nil {
+		return nil, fmt.Errorf("error updating contact: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (a *App) RemoveContact(id int64) error {
+	err := a.wallet.RemoveContact(id)
+	if err != nil {
+		return fmt.Errorf("error removing contact: %w", err)
+	}
+
+	return nil
+}
+
+// GetRecentRecipients suggests addresses from the transaction history to add to the
+// address book.
+func (a *App) GetRecentRecipients(token string) ([]hdwallet.RecentRecipient, error) {
+	recipients, err := a.wallet.GetRecentRecipients(token, recentRecipientsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving recent recipients: %w", err)
+	}
+
+	return recipients, nil
+}
+
+// GetReceiveRequest creates a payment request to an account of a token, with an optional
+// amount, as a URI and a QR code to share with the payer.
+func (a *App) GetReceiveRequest(token string, accountIndex int, amount string) (*ReceiveRequest, error) {
+	request, err := a.wallet.GetReceiveRequest(token, accountIndex, amount)
+	if err != nil {
+		return nil, fmt.Errorf("error creating receive request: %w", err)
+	}
+
+	uri := request.URI()
+	qrCode, err := utils.QRCodeDataURL(uri)
+	if err != nil {
+		return nil, fmt.Errorf("error creating receive request: %w", err)
+	}
+
+	return &ReceiveRequest{Request: request, URI: uri, QRCode: qrCode}, nil
+}
+
+// ReadPaymentRequest parses a payment URI, such as one scanned from a QR code, to pre-fill
+// the Send view.
+func (a *App) ReadPaymentRequest(token, uri string) (*eth.PaymentRequest, error) {
+	request, err := a.wallet.ReadPaymentRequest(token, uri)
+	if err != nil {
+		return nil, fmt.Errorf("error reading payment request: %w", err)
+	}
+
+	return request, nil
+}
+
+// ReviewPaymentRequest checks the transaction paying a payment URI, with its fee, before
+// it is confirmed.
+func (a *App) ReviewPaymentRequest(token, uri string, accountIndex int) (*eth.TransactionReview, error) {
+	review, err := a.wallet.ReviewPaymentRequest(token, uri, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing payment request: %w", err)
+	}
+
+	return review, nil
+}
+
+// SendPaymentRequest pays a payment URI from an account. ERC-20 transfer requests are
+// sent this way, since the Send view only sends ether.
+func (a *App) SendPaymentRequest(token, password, uri string, accountIndex int) (string, error) {
+	txHash, err := a.wallet.SendPaymentRequest(token, password, uri, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error paying request: %w", err)
+	}
+
+	return txHash, nil
+}
+
+func (a *App) CreateUnsignedTransaction(token, to, value string, accountIndex int) (string, error) {
+	payload, err := a.wallet.BuildTransaction(token, to, value, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error creating unsigned %s transaction: %w", token, err)
+	}
+
+	return payload, nil
+}
+
+// SignOfflineTransaction shows the decoded transaction in a dialog and only signs it once
+// the user confirms it.
+func (a *App) SignOfflineTransaction(token, password, payload string, accountIndex int) (string, error) {
+	unsignedTx, err := eth.DecodeUnsignedTransaction(payload)
+	if err != nil {
+		return "", err
+	}
+
+	selection, err := runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
+		Type:          runtime.QuestionDialog,
+		Title:         "Sign transaction",
+		Message:       unsignedTx.String(),
+		Buttons:       []string{"Yes", "No"},
+		DefaultButton: "No",
+		CancelButton:  "No",
+	})
+	if err != nil {
+		return "", fmt.Errorf("error showing confirmation dialog: %w", err)
+	}
+
+	if selection != "Yes" {
+		return "", fmt.Errorf("transaction signing cancelled")
+	}
+
+	rawTx, err := a.wallet.SignTransaction(token, password, payload, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error signing %s transaction: %w", token, err)
+	}
+
+	return rawTx, nil
+}
+
+func (a *App) BroadcastTransaction(token, rawTx string) (string, error) {
+	txHash, err := a.wallet.BroadcastTransaction(token, rawTx)
+	if err != nil {
+		return "", fmt.Errorf("error broadcasting %s transaction: %w", token, err)
+	}
+
+	return txHash, nil
+}
+
+// ExportTransactionFile asks the user where to store an unsigned or signed transaction
+// so it can be carried between the online and the offline machine.
+func (a *App) ExportTransactionFile(defaultFilename, contents string) (string, error) {
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		DefaultFilename: defaultFilename,
+		Title:           "Export transaction",
+	})
+	if err != nil {
+		return "", fmt.Errorf("error selecting export file: %w", err)
+	}
+
+	if path == "" {
+		return "", nil
+	}
+
+	err = os.WriteFile(path, []byte(contents), 0o600)
+	if err != nil {
+		return "", fmt.Errorf("error writing transaction file: %w", err)
+	}
+
+	return path, nil
+}
+
+// TransactionQRCode encodes an unsigned or signed transaction as a QR code PNG data URL,
+// to carry it between the online and the offline machine with a camera.
+func (a *App) TransactionQRCode(contents string) (string, error) {
+	qrCode, err := utils.QRCodeDataURL(strings.TrimSpace(contents))
+	if err != nil {
+		return "", fmt.Errorf("error creating transaction QR code: %w", err)
+	}
+
+	return qrCode, nil
+}
+
+func (a *App) ImportTransactionFile() (string, error) {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Import transaction",
+	})
+	if err != nil {
+		return "", fmt.Errorf("error selecting import file: %w", err)
+	}
+
+	if path == "" {
+		return "", nil
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading transaction file: %w", err)
+	}
+
+	return string(contents), nil
+}
+
+func (a *App) SignMessage(token, password string, accountIndex int, message string) (string, error) {
+	signature, err := a.wallet.SignMessage(token, password, accountIndex, []byte(message))
+	if err != nil {
+		return "", fmt.Errorf("error signing %s message: %w", token, err)
+	}
+
+	return signature, nil
+}
+
+// VerifyMessage checks that an EIP-191 signature over message was produced by address.
+func (a *App) VerifyMessage(address, message, signature string) (bool, error) {
+	if !utils.ValidateETHAddress(address) {
+		return false, fmt.Errorf("invalid address: %s", address)
+	}
+
+	ok, err := eth.VerifyPersonalMessage(address, []byte(message), signature)
+	if err != nil {
+		return false, fmt.Errorf("error verifying message signature: %w", err)
+	}
+
+	return ok, nil
+}
+
+// ReviewTypedData decodes an EIP-712 payload so it can be shown before signing it.
+func (a *App) ReviewTypedData(token, typedData string) (*eth.TypedDataReview, error) {
+	review, err := a.wallet.ReviewTypedData(token, []byte(typedData))
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing typed data: %w", err)
+	}
+
+	return review, nil
+}
+
+func (a *App) SignTypedData(token, password string, accountIndex int, typedData string) (string, error) {
+	signature, err := a.wallet.SignTypedData(token, password, accountIndex, []byte(typedData))
+	if err != nil {
+		return "", fmt.Errorf("error signing %s typed data: %w", token, err)
+	}
+
+	return signature, nil
+}
+
+func (a *App) RegisterContract(token, name, address, abiJSON string) (*eth.Contract, error) {
+	contract, err := a.wallet.RegisterContract(token, name, address, []byte(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("error registering contract: %w", err)
+	}
+
+	return contract, nil
+}
+
+// ImportContractABI registers a contract from an ABI or Hardhat artifact file picked by the user.
+func (a *App) ImportContractABI(token, name, address string) (*eth.Contract, error) {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Import contract ABI",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "ABI or artifact (*.json)", Pattern: "*.json"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error selecting ABI file: %w", err)
+	}
+
+	if path == "" {
+		return nil, nil
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading ABI file: %w", err)
+	}
+
+	return a.RegisterContract(token, name, address, string(contents))
+}
+
+func (a *App) GetContracts(token string) ([]*eth.Contract, error) {
+	contracts, err := a.wallet.GetContracts(token)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving contracts: %w", err)
+	}
+
+	return contracts, nil
+}
+
+func (a *App) RemoveContract(token, name string) error {
+	err := a.wallet.RemoveContract(token, name)
+	if err != nil {
+		return fmt.Errorf("error removing contract: %w", err)
+	}
+
+	return nil
+}
+
+func (a *App) CallContract(
+	token, contract, method string,
+	args []string,
+	accountIndex int,
+) ([]eth.ContractParam, error) {
+	results, err := a.wallet.CallContract(token, contract, method, args, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error calling contract: %w", err)
+	}
+
+	return results, nil
+}
+
+func (a *App) EstimateContractGas(
+	token, contract, method string,
+	args []string,
+	value string,
+	accountIndex int,
+) (string, error) {
+	cost, err := a.wallet.EstimateContractGas(token, contract, method, args, value, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error estimating contract call gas: %w", err)
+	}
+
+	return cost, nil
+}
+
+func (a *App) SendContractTransaction(
+	token, password, contract, method string,
+	args []string,
+	value string,
+	accountIndex int,
+) (string, error) {
+	txHash, err := a.wallet.SendContractTransaction(token, password, contract, method, args, value, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error sending contract transaction: %w", err)
+	}
+
+	return txHash, nil
+}
+
+// QueryContractEvents looks up the events of a registered contract between two blocks,
+// filtered by the values of the indexed inputs of event. A toBlock of 0 is the latest block.
+func (a *App) QueryContractEvents(
+	token, contract, event string,
+	args []string,
+	fromBlock, toBlock uint64,
+) ([]eth.EventLog, error) {
+	events, err := a.wallet.QueryContractEvents(token, contract, event, args, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error querying contract events: %w", err)
+	}
+
+	return events, nil
+}
+
+// SyncContractActivity stores the events a registered contract emitted since its last
+// sync and returns them.
+func (a *App) SyncContractActivity(token, contract string) ([]eth.EventLog, error) {
+	events, err := a.wallet.SyncContractEvents(token, contract)
+	if err != nil {
+		return nil, fmt.Errorf("error syncing contract activity: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetContractActivity returns the latest stored events of a registered contract, newest
+// first, optionally only those named event.
+func (a *App) GetContractActivity(token, contract, event string, limit int) ([]eth.EventLog, error) {
+	events, err := a.wallet.GetContractActivity(token, contract, event, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving contract activity: %w", err)
+	}
+
+	return events, nil
+}
+
+// OpenContractArtifact lets the user pick a Hardhat artifact and returns the constructor
+// arguments its deployment takes.
+func (a *App) OpenContractArtifact() (*ArtifactFile, error) {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Open contract artifact",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Hardhat artifact (*.json)", Pattern: "*.json"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error selecting artifact file: %w", err)
+	}
+
+	if path == "" {
+		return nil, nil
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading artifact file: %w", err)
+	}
+
+	artifact, err := eth.ParseContractArtifact(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArtifactFile{Path: path, Artifact: artifact}, nil
+}
+
+func (a *App) EstimateDeploymentGas(
+	token, artifactPath string,
+	args []string,
+	value string,
+	accountIndex int,
+) (string, error) {
+	artifact, err := os.ReadFile(artifactPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading artifact file: %w", err)
+	}
+
+	cost, err := a.wallet.EstimateDeploymentGas(token, artifact, args, value, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error estimating deployment gas: %w", err)
+	}
+
+	return cost, nil
+}
+
+// DeployContract deploys the contract of an artifact and registers it under name, or the
+// name of the artifact when empty, once mined.
+func (a *App) DeployContract(
+	token, password, artifactPath, name string,
+	args []string,
+	value string,
+	accountIndex int,
+) (*eth.Deployment, error) {
+	artifact, err := os.ReadFile(artifactPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading artifact file: %w", err)
+	}
+
+	deployment, err := a.wallet.DeployContract(token, password, name, artifact, args, value, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error deploying contract: %w", err)
+	}
+
+	return deployment, nil
+}
+
+// DecodeCall describes what the data of a transaction to a contract does, given as hex.
+func (a *App) DecodeCall(token, to, data string) (*eth.DecodedCall, error) {
+	callData, err := hexutil.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing call data: %w", err)
+	}
+
+	call, err := a.wallet.DecodeCall(token, to, callData)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding call: %w", err)
+	}
+
+	return call, nil
+}
+
+// LoadSignatureDatabase lets the user pick an offline signature database, used to decode
+// calls to contracts missing from the ABI registry. It returns the number of signatures
+// loaded, or 0 when no file was picked.
+func (a *App) LoadSignatureDatabase(token string) (int, error) {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Open signature database",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Text signatures (*.txt)", Pattern: "*.txt"},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error selecting signature database: %w", err)
+	}
+
+	if path == "" {
+		return 0, nil
+	}
+
+	count, err := a.wallet.LoadSignatureDatabase(token, path)
+	if err != nil {
+		return 0, fmt.Errorf("error loading signature database: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetCollectibles lists the ERC-721 and ERC-1155 tokens held by the accounts of a token.
+// Only the blocks since the last call are scanned for new tokens.
+func (a *App) GetCollectibles(token string) ([]eth.Collectible, error) {
+	collectibles, err := a.wallet.GetCollectibles(token)
+	if err != nil {
+		return nil, fmt.Errorf("error getting collectibles: %w", err)
+	}
+
+	return collectibles, nil
+}
+
+// TransferCollectible sends an ERC-721 token from an account with safeTransferFrom.
+func (a *App) TransferCollectible(
+	token, password, contract, tokenID, recipient string,
+	accountIndex int,
+) (string, error) {
+	txHash, err := a.wallet.TransferCollectible(token, password, contract, tokenID, recipient, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error transferring collectible: %w", err)
+	}
+
+	return txHash, nil
+}
+
+// TransferMultiTokens sends units of ERC-1155 tokens from an account, with
+// safeTransferFrom for a single token ID and safeBatchTransferFrom for several.
+func (a *App) TransferMultiTokens(
+	token, password, contract, recipient string,
+	ids, amounts []string,
+	accountIndex int,
+) (string, error) {
+	txHash, err := a.wallet.TransferMultiTokens(token, password, contract, recipient, ids, amounts, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error transferring tokens: %w", err)
+	}
+
+	return txHash, nil
+}
+
+// GetAllowances lists the ERC-20 allowances granted by the accounts of the wallet, found
+// from their Approval events.
+func (a *App) GetAllowances(token string) ([]eth.Allowance, error) {
+	allowances, err := a.wallet.GetAllowances(token)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving allowances: %w", err)
+	}
+
+	return allowances, nil
+}
+
+// RevokeAllowance sends approve(spender, 0) from an account to an ERC-20 contract.
+func (a *App) RevokeAllowance(token, password, contract, spender string, accountIndex int) (string, error) {
+	txHash, err := a.wallet.RevokeAllowance(token, password, contract, spender, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error revoking allowance: %w", err)
+	}
+
+	return txHash, nil
+}
+
+func (a *App) GetDemoTokenBalance(token, contract string, accountIndex int) (*eth.TokenBalance, error) {
+	balance, err := a.wallet.GetDemoTokenBalance(token, contract, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving DemoToken balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+func (a *App) GetCurrentProposal(token, contract string) (*eth.Proposal, error) {
+	proposal, err := a.wallet.GetCurrentProposal(token, contract)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving proposal: %w", err)
+	}
+
+	return proposal, nil
+}
+
+func (a *App) CreateProposal(
+	token, password, contract, description string,
+	votesToEnd, accountIndex int,
+) (string, error) {
+	if votesToEnd <= 0 {
+		return "", fmt.Errorf("the number of votes to end the proposal must be positive")
+	}
+
+	txHash, err := a.wallet.CreateProposal(token, password, contract, description, uint64(votesToEnd), accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error creating proposal: %w", err)
+	}
+
+	return txHash, nil
+}
+
+// VoteProposal votes "approve", "reject" or "pass" on the current proposal.
+func (a *App) VoteProposal(token, password, contract, choice string, accountIndex int) (string, error) {
+	vote, err := eth.ParseVoteChoice(choice)
+	if err != nil {
+		return "", err
+	}
+
+	txHash, err := a.wallet.VoteProposal(token, password, contract, vote, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error voting on proposal: %w", err)
+	}
+
+	return txHash, nil
+}
+
+func (a *App) GetCounter(token, contract string) (string, error) {
+	counter, err := a.wallet.GetCounter(token, contract)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving counter: %w", err)
+	}
+
+	return counter, nil
+}
+
+func (a *App) IncrementCounter(token, password, contract string, accountIndex int) (string, error) {
+	txHash, err := a.wallet.IncrementCounter(token, password, contract, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error incrementing counter: %w", err)
+	}
+
+	return txHash, nil
+}
+
+// StartSigner exposes the wallet accounts to local dApp tooling on the given loopback
+// address.
+// Requests are approved through a dialog unless a rules file is provided.
+func (a *App) StartSigner(password, address, rulesFile string) error {
+	if a.stopSigner != nil {
+		return fmt.Errorf("signer is already running")
+	}
+
+	approve := a.approveSignerRequest
+	if rulesFile != "" {
+		rules, err := signer.LoadRules(rulesFile)
+		if err != nil {
+			return fmt.Errorf("error loading approval rules: %w", err)
+		}
+		approve = rules.Approve
+	}
+
+	// Bind the address before returning so a port already in use is reported to the caller.
+	listener, err := signer.Listen(a.ctx, address)
+	if err != nil {
+		return fmt.Errorf("error starting signer: %w", err)
+	}
+
+	signerCtx, cancel := context.WithCancel(a.ctx)
+	a.stopSigner = cancel
+	server := signer.NewServer(a.wallet, password, approve)
+	go func() {
+		err := server.Serve(signerCtx, listener)
+		if err != nil {
+			log.Errorf("error running signer: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+func (a *App) StopSigner() {
+	if a.stopSigner != nil {
+		a.stopSigner()
+		a.stopSigner = nil
+	}
+}
+
+func (a *App) approveSignerRequest(_ context.Context, req *signer.ApprovalRequest) (bool, error) {
+	selection, err := runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
+		Type:          runtime.QuestionDialog,
+		Title:         "Signature request",
+		Message:       req.String(),
+		Buttons:       []string{"Yes", "No"},
+		DefaultButton: "No",
+		CancelButton:  "No",
+	})
+	if err != nil {
+		return false, fmt.Errorf("error showing approval dialog: %w", err)
+	}
+
+	return selection == "Yes", nil
+}
@@ -5,11 +5,20 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"wallet/internal/currencies/eth"
 	"wallet/internal/hdwallet"
+	"wallet/internal/signer"
+	"wallet/internal/utils"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 func createWallet(ctx context.Context, password string) (*hdwallet.Wallet, error) {
@@ -41,14 +50,12 @@ func RestoreWallet(ctx context.Context, password, mnemonic string) (*hdwallet.Wa
 }
 
 func GetBalance(wallet *hdwallet.Wallet, token string) (string, error) {
-	balanceFloat, err := wallet.GetBalance(token, 0)
+	balance, err := wallet.GetBalance(token, 0)
 	if err != nil {
 		return "", fmt.Errorf("error getting account: %w", err)
 	}
 
-	balanceStr := strconv.FormatFloat(balanceFloat, 'f', 4, 64)
-
-	return balanceStr, nil
+	return balance, nil
 }
 
 func createWalletCmd(scanner *bufio.Scanner, tokens []string) (*hdwallet.Wallet, error) {
@@ -132,33 +139,1596 @@ func checkBalanceCmd(ctx context.Context, scanner *bufio.Scanner, wallet *hdwall
 	return nil
 }
 
-func main() {
-	scanner := bufio.NewScanner(os.Stdin)
-	tokens := []string{"ETH"}
-	var wallet *hdwallet.Wallet
-	var err error
-	cliCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	client := eth.NewClient("http://localhost:8545")
-	defer cancel()
+func promptInput(scanner *bufio.Scanner, prompt string) (string, error) {
+	fmt.Fprintln(os.Stdout, prompt)
+	if !scanner.Scan() {
+		return "", fmt.Errorf("failed to read input for: %s", prompt)
+	}
 
-	for {
-		fmt.Fprintln(os.Stdout, "Enter a command or (type 'exit' to quit):")
-		scanner.Scan()
-		command := scanner.Text()
-		switch command {
-		case "create-wallet":
-			wallet, err = createWalletCmd(scanner, tokens)
-			if err != nil {
-				break
-			}
-		case "restore-wallet":
-			wallet, err = restoreWalletCmd(scanner, tokens)
-			if err != nil {
-				break
-			}
-		case "get-token-balance":
-			err := checkBalanceCmd(cliCtx, scanner, wallet, client)
+	return strings.TrimSpace(scanner.Text()), nil
+}
+
+func sendCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter recipient address, contact name or ENS name: ",
+		"Enter value: ",
+		"Enter account index: ",
+		"Enter password: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	accountIndex, err := strconv.Atoi(inputs[3])
+	if err != nil {
+		return fmt.Errorf("invalid account index: %w", err)
+	}
+
+	to, err := wallet.ResolveRecipient(inputs[0], inputs[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to find recipient:", err)
+		return err
+	}
+
+	if eth.IsENSName(inputs[1]) {
+		fmt.Fprintf(os.Stdout, "%s resolves to %s\n", inputs[1], to)
+	}
+
+	review, err := wallet.ReviewTransaction(inputs[0], to, inputs[2], accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to review transaction:", err)
+		return err
+	}
+
+	token := inputs[0]
+	fmt.Fprintf(os.Stdout, "Sending %s %s to %s, maximum fee: %s %s\n", review.Value, token, to, review.MaxFee, token)
+	for _, issue := range review.Warnings {
+		fmt.Fprintln(os.Stdout, "WARNING:", issue.Message)
+	}
+
+	if len(review.Errors) > 0 {
+		for _, issue := range review.Errors {
+			fmt.Fprintln(os.Stderr, "ERROR:", issue.Message)
+		}
+		return fmt.Errorf("transaction would fail")
+	}
+
+	if review.ConfirmRecipient {
+		// Typing the address makes the user read all of it, not only its first and last characters.
+		confirmation, err := promptInput(scanner, "Type the full recipient address to confirm it: ")
+		if err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(confirmation, to) {
+			fmt.Fprintln(os.Stdout, "The address does not match, transaction cancelled")
+			return nil
+		}
+	}
+
+	answer, err := promptInput(scanner, "Send this transaction? [y/N]: ")
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+		fmt.Fprintln(os.Stdout, "Transaction cancelled")
+		return nil
+	}
+
+	if review.ConfirmRecipient {
+		wallet.ConfirmRecipient(inputs[0], to)
+	}
+
+	_, err = wallet.SendTransaction(inputs[0], inputs[4], to, inputs[2], accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to send transaction:", err)
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "Transaction sent")
+	return nil
+}
+
+func addContactCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter contact name: ",
+		"Enter address: ",
+		"Enter token name: ",
+		"Enter notes (leave empty for none): ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	contact, err := wallet.SaveContact(hdwallet.Contact{
+		Name:    inputs[0],
+		Address: inputs[1],
+		Token:   inputs[2],
+		Notes:   inputs[3],
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to add contact:", err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Contact %s saved with address %s\n", contact.Name, contact.Address)
+	return nil
+}
+
+func listContactsCmd(wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	contacts, err := wallet.GetContacts()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to list contacts:", err)
+		return err
+	}
+
+	for _, contact := range contacts {
+		fmt.Fprintf(
+			os.Stdout, "%s\t%s\t%s on %s\t%s\n", contact.Name, contact.Address, contact.Token, contact.Network, contact.Notes)
+	}
+
+	return nil
+}
+
+func receiveCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter account index: ",
+		"Enter amount (leave empty for any): ",
+		"Enter QR code file (leave empty for none): ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	accountIndex, err := strconv.Atoi(inputs[1])
+	if err != nil {
+		return fmt.Errorf("invalid account index: %w", err)
+	}
+
+	request, err := wallet.GetReceiveRequest(inputs[0], accountIndex, inputs[2])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to create payment request:", err)
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "Payment request:", request.URI())
+	if inputs[3] == "" {
+		return nil
+	}
+
+	qrCode, err := utils.QRCodePNG(request.URI())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to create QR code:", err)
+		return err
+	}
+
+	err = os.WriteFile(inputs[3], qrCode, 0o644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to write QR code:", err)
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "QR code written to", inputs[3])
+	return nil
+}
+
+func payCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter payment request URI: ",
+		"Enter account index: ",
+		"Enter password: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	accountIndex, err := strconv.Atoi(inputs[2])
+	if err != nil {
+		return fmt.Errorf("invalid account index: %w", err)
+	}
+
+	request, err := wallet.ReadPaymentRequest(inputs[0], inputs[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to read payment request:", err)
+		return err
+	}
+
+	if request.IsTokenTransfer() {
+		fmt.Fprintf(
+			os.Stdout, "Paying %s %s (token %s) to %s\n", request.Amount, request.Symbol, request.To, request.Recipient)
+	} else if request.Amount != "" {
+		fmt.Fprintf(os.Stdout, "Paying %s %s to %s\n", request.Amount, request.Symbol, request.To)
+	} else {
+		fmt.Fprintln(os.Stderr, "The payment request has no amount, use send instead")
+		return fmt.Errorf("missing amount")
+	}
+
+	review, err := wallet.ReviewPaymentRequest(inputs[0], inputs[1], accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to review payment request:", err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Maximum fee: %s %s\n", review.MaxFee, inputs[0])
+	for _, issue := range review.Warnings {
+		fmt.Fprintln(os.Stdout, "WARNING:", issue.Message)
+	}
+
+	if len(review.Errors) > 0 {
+		for _, issue := range review.Errors {
+			fmt.Fprintln(os.Stderr, "ERROR:", issue.Message)
+		}
+		return fmt.Errorf("payment would fail")
+	}
+
+	if review.ConfirmRecipient {
+		confirmation, err := promptInput(scanner, "Type the full payee address to confirm it: ")
+		if err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(confirmation, review.To) {
+			fmt.Fprintln(os.Stdout, "The address does not match, payment cancelled")
+			return nil
+		}
+	}
+
+	answer, err := promptInput(scanner, "Pay this request? [y/N]: ")
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+		fmt.Fprintln(os.Stdout, "Payment cancelled")
+		return nil
+	}
+
+	if review.ConfirmRecipient {
+		wallet.ConfirmRecipient(inputs[0], review.To)
+	}
+
+	txHash, err := wallet.SendPaymentRequest(inputs[0], inputs[3], inputs[1], accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to pay request:", err)
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "Payment sent:", txHash)
+	return nil
+}
+
+func setENSRegistryCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	registry, err := promptInput(scanner, "Enter ENS registry address: ")
+	if err != nil {
+		return err
+	}
+
+	err = wallet.SetENSRegistry("ETH", registry)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to set ENS registry:", err)
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "ENS names are now resolved with registry", registry)
+	return nil
+}
+
+func lookupAddressCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	address, err := promptInput(scanner, "Enter address: ")
+	if err != nil {
+		return err
+	}
+
+	names, err := wallet.LookupAddresses("ETH", []string{address})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to look up address:", err)
+		return err
+	}
+
+	name, ok := names[address]
+	if !ok {
+		fmt.Fprintln(os.Stdout, "No ENS name points to", address)
+		return nil
+	}
+
+	fmt.Fprintln(os.Stdout, "ENS name:", name)
+	return nil
+}
+
+func listAllowancesCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	token, err := promptInput(scanner, "Enter token name: ")
+	if err != nil {
+		return err
+	}
+
+	allowances, err := wallet.GetAllowances(token)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to list allowances:", err)
+		return err
+	}
+
+	if len(allowances) == 0 {
+		fmt.Fprintln(os.Stdout, "No allowances granted")
+		return nil
+	}
+
+	for _, allowance := range allowances {
+		amount := allowance.Amount
+		if allowance.Unlimited {
+			amount = "unlimited"
+		}
+		fmt.Fprintf(os.Stdout, "%s (%s)	account %d	spender %s	%s\n",
+			allowance.Symbol, allowance.Token, allowance.OwnerIndex, allowance.Spender, amount)
+	}
+
+	return nil
+}
+
+func revokeAllowanceCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter token contract: ",
+		"Enter spender address: ",
+		"Enter account index: ",
+		"Enter password: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	accountIndex, err := strconv.Atoi(inputs[3])
+	if err != nil {
+		return fmt.Errorf("invalid account index: %w", err)
+	}
+
+	txHash, err := wallet.RevokeAllowance(inputs[0], inputs[4], inputs[1], inputs[2], accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to revoke allowance:", err)
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "Allowance revoked:", txHash)
+	return nil
+}
+
+func listCollectiblesCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	token, err := promptInput(scanner, "Enter token name: ")
+	if err != nil {
+		return err
+	}
+
+	collectibles, err := wallet.GetCollectibles(token)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to list collectibles:", err)
+		return err
+	}
+
+	if len(collectibles) == 0 {
+		fmt.Fprintln(os.Stdout, "No collectibles held")
+		return nil
+	}
+
+	for _, collectible := range collectibles {
+		name := collectible.URI
+		if collectible.Metadata != nil {
+			name = collectible.Metadata.Name
+		}
+		fmt.Fprintf(os.Stdout, "%s %s #%s x%s (%s)\taccount %d\t%s\n", collectible.Standard, collectible.Symbol,
+			collectible.TokenID, collectible.Balance, collectible.Contract, collectible.OwnerIndex, name)
+	}
+
+	return nil
+}
+
+func transferCollectibleCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter collection contract: ",
+		"Enter token ID: ",
+		"Enter recipient (address, contact name or ENS name): ",
+		"Enter account index: ",
+		"Enter password: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	accountIndex, err := strconv.Atoi(inputs[4])
+	if err != nil {
+		return fmt.Errorf("invalid account index: %w", err)
+	}
+
+	to, ok, err := confirmCollectibleRecipient(scanner, wallet, inputs[0], inputs[3])
+	if err != nil || !ok {
+		return err
+	}
+
+	txHash, err := wallet.TransferCollectible(inputs[0], inputs[5], inputs[1], inputs[2], to, accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to transfer collectible:", err)
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "Collectible transferred:", txHash)
+	return nil
+}
+
+// confirmCollectibleRecipient resolves the recipient of a collectible transfer and, when
+// it is flagged, has the user type its full address like sendCmd does. It returns false
+// when the user cancels.
+func confirmCollectibleRecipient(
+	scanner *bufio.Scanner, wallet *hdwallet.Wallet, token, recipient string,
+) (string, bool, error) {
+	to, err := wallet.ResolveRecipient(token, recipient)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to find recipient:", err)
+		return "", false, err
+	}
+
+	warnings, err := wallet.CheckRecipient(token, to)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to check recipient:", err)
+		return "", false, err
+	}
+
+	if len(warnings) == 0 {
+		return to, true, nil
+	}
+
+	for _, issue := range warnings {
+		fmt.Fprintln(os.Stdout, "WARNING:", issue.Message)
+	}
+
+	confirmation, err := promptInput(scanner, "Type the full recipient address to confirm it: ")
+	if err != nil {
+		return "", false, err
+	}
+
+	if !strings.EqualFold(confirmation, to) {
+		fmt.Fprintln(os.Stdout, "The address does not match, transfer cancelled")
+		return "", false, nil
+	}
+
+	wallet.ConfirmRecipient(token, to)
+	return to, true, nil
+}
+
+func transferMultiTokensCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter ERC-1155 contract: ",
+		"Enter token IDs (comma separated): ",
+		"Enter amounts (comma separated, one per token ID): ",
+		"Enter recipient (address, contact name or ENS name): ",
+		"Enter account index: ",
+		"Enter password: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	accountIndex, err := strconv.Atoi(inputs[5])
+	if err != nil {
+		return fmt.Errorf("invalid account index: %w", err)
+	}
+
+	to, ok, err := confirmCollectibleRecipient(scanner, wallet, inputs[0], inputs[4])
+	if err != nil || !ok {
+		return err
+	}
+
+	ids := strings.Split(inputs[2], ",")
+	amounts := strings.Split(inputs[3], ",")
+	txHash, err := wallet.TransferMultiTokens(inputs[0], inputs[6], inputs[1], to, ids, amounts, accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to transfer tokens:", err)
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "Tokens transferred:", txHash)
+	return nil
+}
+
+func buildTransactionCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter recipient address: ",
+		"Enter value: ",
+		"Enter account index: ",
+		"Enter output file: ",
+		"Enter QR code file (leave empty for none): ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	accountIndex, err := strconv.Atoi(inputs[3])
+	if err != nil {
+		return fmt.Errorf("invalid account index: %w", err)
+	}
+
+	payload, err := wallet.BuildTransaction(inputs[0], inputs[1], inputs[2], accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to build transaction:", err)
+		return err
+	}
+
+	err = os.WriteFile(inputs[4], []byte(payload), 0o600)
+	if err != nil {
+		return fmt.Errorf("error writing unsigned transaction: %w", err)
+	}
+
+	fmt.Fprintf(os.Stdout, "Unsigned transaction written to %s\n", inputs[4])
+	return writeTransactionQRCode(inputs[5], payload)
+}
+
+func signTransactionCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter account index: ",
+		"Enter unsigned transaction file: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	accountIndex, err := strconv.Atoi(inputs[1])
+	if err != nil {
+		return fmt.Errorf("invalid account index: %w", err)
+	}
+
+	payload, err := os.ReadFile(inputs[2])
+	if err != nil {
+		return fmt.Errorf("error reading unsigned transaction: %w", err)
+	}
+
+	unsignedTx, err := eth.DecodeUnsignedTransaction(string(payload))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid unsigned transaction:", err)
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, unsignedTx.String())
+	answer, err := promptInput(scanner, "Sign this transaction? [y/N]: ")
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+		fmt.Fprintln(os.Stdout, "Signing cancelled")
+		return nil
+	}
+
+	prompts = []string{
+		"Enter password: ",
+		"Enter output file: ",
+		"Enter QR code file (leave empty for none): ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	rawTx, err := wallet.SignTransaction(inputs[0], inputs[3], string(payload), accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to sign transaction:", err)
+		return err
+	}
+
+	err = os.WriteFile(inputs[4], []byte(rawTx), 0o600)
+	if err != nil {
+		return fmt.Errorf("error writing signed transaction: %w", err)
+	}
+
+	fmt.Fprintf(os.Stdout, "Signed transaction written to %s\n", inputs[4])
+	return writeTransactionQRCode(inputs[5], rawTx)
+}
+
+// writeTransactionQRCode writes the QR code of an unsigned or signed transaction to path,
+// unless path is empty.
+func writeTransactionQRCode(path, payload string) error {
+	if path == "" {
+		return nil
+	}
+
+	qrCode, err := utils.QRCodePNG(payload)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to create QR code:", err)
+		return err
+	}
+
+	err = os.WriteFile(path, qrCode, 0o644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to write QR code:", err)
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, "QR code written to", path)
+	return nil
+}
+
+func broadcastTransactionCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	token, err := promptInput(scanner, "Enter token name: ")
+	if err != nil {
+		return err
+	}
+
+	path, err := promptInput(scanner, "Enter signed transaction file: ")
+	if err != nil {
+		return err
+	}
+
+	rawTx, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading signed transaction: %w", err)
+	}
+
+	txHash, err := wallet.BroadcastTransaction(token, string(rawTx))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to broadcast transaction:", err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Transaction broadcast: %s\n", txHash)
+	return nil
+}
+
+func signMessageCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter password: ",
+		"Enter account index: ",
+		"Enter message: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	accountIndex, err := strconv.Atoi(inputs[2])
+	if err != nil {
+		return fmt.Errorf("invalid account index: %w", err)
+	}
+
+	signature, err := wallet.SignMessage(inputs[0], inputs[1], accountIndex, []byte(inputs[3]))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to sign message:", err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Signature: %s\n", signature)
+	return nil
+}
+
+func verifyMessageCmd(scanner *bufio.Scanner) error {
+	var inputs []string
+	prompts := []string{
+		"Enter address: ",
+		"Enter message: ",
+		"Enter signature: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	if !common.IsHexAddress(inputs[0]) {
+		fmt.Fprintln(os.Stderr, "Invalid address:", inputs[0])
+		return fmt.Errorf("invalid address: %s", inputs[0])
+	}
+
+	signerAddress, err := eth.RecoverPersonalMessageSigner([]byte(inputs[1]), inputs[2])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to verify signature:", err)
+		return err
+	}
+
+	if !strings.EqualFold(signerAddress.Hex(), inputs[0]) {
+		fmt.Fprintf(os.Stdout, "Signature is NOT valid, it was produced by %s\n", signerAddress.Hex())
+		return nil
+	}
+
+	fmt.Fprintln(os.Stdout, "Signature is valid")
+	return nil
+}
+
+func signTypedDataCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter password: ",
+		"Enter account index: ",
+		"Enter typed data file: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	accountIndex, err := strconv.Atoi(inputs[2])
+	if err != nil {
+		return fmt.Errorf("invalid account index: %w", err)
+	}
+
+	typedData, err := os.ReadFile(inputs[3])
+	if err != nil {
+		return fmt.Errorf("error reading typed data: %w", err)
+	}
+
+	review, err := wallet.ReviewTypedData(inputs[0], typedData)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to decode typed data:", err)
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, review.String())
+	answer, err := promptInput(scanner, "Sign this message? [y/N]: ")
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+		fmt.Fprintln(os.Stdout, "Signature cancelled")
+		return nil
+	}
+
+	signature, err := wallet.SignTypedData(inputs[0], inputs[1], accountIndex, typedData)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to sign typed data:", err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Signature: %s\n", signature)
+	return nil
+}
+
+func registerContractCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter contract name: ",
+		"Enter contract address: ",
+		"Enter ABI or artifact file: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	abiJSON, err := os.ReadFile(inputs[3])
+	if err != nil {
+		return fmt.Errorf("error reading ABI file: %w", err)
+	}
+
+	contract, err := wallet.RegisterContract(inputs[0], inputs[1], inputs[2], abiJSON)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to register contract:", err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Contract %s registered at %s with methods:\n", contract.Name, contract.Address)
+	for _, method := range contract.Methods {
+		fmt.Fprintf(os.Stdout, "  %s\n", method.Signature)
+	}
+	return nil
+}
+
+// promptContractCall asks for the contract, the method and one value per method input.
+func promptContractCall(
+	scanner *bufio.Scanner,
+	wallet *hdwallet.Wallet,
+	token string,
+) (*eth.Contract, *eth.ContractMethod, []string, error) {
+	contractName, err := promptInput(scanner, "Enter contract name or address: ")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	contracts, err := wallet.GetContracts(token)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var contract *eth.Contract
+	for _, registered := range contracts {
+		if registered.Name == contractName || strings.EqualFold(registered.Address, contractName) {
+			contract = registered
+			break
+		}
+	}
+
+	if contract == nil {
+		return nil, nil, nil, fmt.Errorf("contract %s is not registered", contractName)
+	}
+
+	methodName, err := promptInput(scanner, "Enter method name: ")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	method, err := contract.Method(methodName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	args := make([]string, 0, len(method.Inputs))
+	for _, param := range method.Inputs {
+		arg, err := promptInput(scanner, fmt.Sprintf("Enter %s (%s): ", param.Name, param.Type))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		args = append(args, arg)
+	}
+
+	return contract, method, args, nil
+}
+
+func callContractCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	token, err := promptInput(scanner, "Enter token name: ")
+	if err != nil {
+		return err
+	}
+
+	contract, method, args, err := promptContractCall(scanner, wallet, token)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to prepare call:", err)
+		return err
+	}
+
+	results, err := wallet.CallContract(token, contract.Name, method.Name, args, 0)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to call contract:", err)
+		return err
+	}
+
+	for _, result := range results {
+		fmt.Fprintf(os.Stdout, "%s (%s): %s\n", result.Name, result.Type, result.Value)
+	}
+	return nil
+}
+
+func sendContractTransactionCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	token, err := promptInput(scanner, "Enter token name: ")
+	if err != nil {
+		return err
+	}
+
+	contract, method, args, err := promptContractCall(scanner, wallet, token)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to prepare transaction:", err)
+		return err
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter value (leave empty for none): ",
+		"Enter account index: ",
+		"Enter password: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	accountIndex, err := strconv.Atoi(inputs[1])
+	if err != nil {
+		return fmt.Errorf("invalid account index: %w", err)
+	}
+
+	cost, err := wallet.EstimateContractGas(token, contract.Name, method.Name, args, inputs[0], accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to estimate gas:", err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Calling %s on %s, estimated fee: %s %s\n", method.Signature, contract.Address, cost, token)
+	answer, err := promptInput(scanner, "Send this transaction? [y/N]: ")
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+		fmt.Fprintln(os.Stdout, "Transaction cancelled")
+		return nil
+	}
+
+	txHash, err := wallet.SendContractTransaction(
+		token, inputs[2], contract.Name, method.Name, args, inputs[0], accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to send transaction:", err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Transaction hash: %s\n", txHash)
+	return nil
+}
+
+// printEvents writes one event per line followed by its fields.
+func printEvents(events []eth.EventLog) {
+	for _, event := range events {
+		fmt.Fprintf(os.Stdout, "Block %d, %s (transaction %s)\n", event.BlockNumber, event.Signature, event.TxHash)
+		for _, field := range event.Fields {
+			fmt.Fprintf(os.Stdout, "  %s (%s): %s\n", field.Name, field.Type, field.Value)
+		}
+	}
+}
+
+func queryEventsCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter contract name or address: ",
+		"Enter event name (leave empty for all): ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	// The indexed inputs of the event can filter the logs.
+	var args []string
+	if inputs[2] != "" {
+		contracts, err := wallet.GetContracts(inputs[0])
+		if err != nil {
+			return err
+		}
+
+		var contract *eth.Contract
+		for _, registered := range contracts {
+			if registered.Name == inputs[1] || strings.EqualFold(registered.Address, inputs[1]) {
+				contract = registered
+				break
+			}
+		}
+
+		if contract == nil {
+			return fmt.Errorf("contract %s is not registered", inputs[1])
+		}
+
+		event, err := contract.Event(inputs[2])
+		if err != nil {
+			return err
+		}
+
+		for _, param := range event.Inputs {
+			if !param.Indexed {
+				continue
+			}
+
+			arg, err := promptInput(scanner, fmt.Sprintf("Enter %s (%s, leave empty for any): ", param.Name, param.Type))
+			if err != nil {
+				return err
+			}
+			args = append(args, arg)
+		}
+	}
+
+	var blocks []uint64
+	for _, prompt := range []string{"Enter first block: ", "Enter last block (leave empty for latest): "} {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+
+		block := uint64(0)
+		if input != "" {
+			block, err = strconv.ParseUint(input, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid block number: %w", err)
+			}
+		}
+		blocks = append(blocks, block)
+	}
+
+	events, err := wallet.QueryContractEvents(inputs[0], inputs[1], inputs[2], args, blocks[0], blocks[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to query events:", err)
+		return err
+	}
+
+	printEvents(events)
+	fmt.Fprintf(os.Stdout, "%d events found\n", len(events))
+	return nil
+}
+
+func contractActivityCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter contract name or address: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	synced, err := wallet.SyncContractEvents(inputs[0], inputs[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to sync events:", err)
+		return err
+	}
+
+	events, err := wallet.GetContractActivity(inputs[0], inputs[1], "", 20)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to get contract activity:", err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "%d new events, latest first:\n", len(synced))
+	printEvents(events)
+	return nil
+}
+
+func decodeCallCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter contract address: ",
+		"Enter call data (hex): ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	data, err := hexutil.Decode(inputs[2])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid call data:", err)
+		return err
+	}
+
+	call, err := wallet.DecodeCall(inputs[0], inputs[1], data)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to decode call:", err)
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, call.String())
+	return nil
+}
+
+func loadSignaturesCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter token name: ",
+		"Enter signature database path: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	count, err := wallet.LoadSignatureDatabase(inputs[0], inputs[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to load signature database:", err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "%d signatures loaded\n", count)
+	return nil
+}
+
+func deployContractCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	token, err := promptInput(scanner, "Enter token name: ")
+	if err != nil {
+		return err
+	}
+
+	path, err := promptInput(scanner, "Enter artifact file: ")
+	if err != nil {
+		return err
+	}
+
+	artifactJSON, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading artifact file: %w", err)
+	}
+
+	artifact, err := eth.ParseContractArtifact(artifactJSON)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to load artifact:", err)
+		return err
+	}
+
+	args := make([]string, 0, len(artifact.Constructor))
+	for _, param := range artifact.Constructor {
+		arg, err := promptInput(scanner, fmt.Sprintf("Enter %s (%s): ", param.Name, param.Type))
+		if err != nil {
+			return err
+		}
+		args = append(args, arg)
+	}
+
+	var inputs []string
+	prompts := []string{
+		fmt.Sprintf("Enter registry name (leave empty for %s): ", artifact.ContractName),
+		"Enter value (leave empty for none): ",
+		"Enter account index: ",
+		"Enter password: ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	accountIndex, err := strconv.Atoi(inputs[2])
+	if err != nil {
+		return fmt.Errorf("invalid account index: %w", err)
+	}
+
+	cost, err := wallet.EstimateDeploymentGas(token, artifactJSON, args, inputs[1], accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to estimate gas:", err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Deploying %s, estimated fee: %s %s\n", artifact.ContractName, cost, token)
+	answer, err := promptInput(scanner, "Send this transaction? [y/N]: ")
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+		fmt.Fprintln(os.Stdout, "Deployment cancelled")
+		return nil
+	}
+
+	fmt.Fprintln(os.Stdout, "Waiting for the deployment to be mined...")
+	deployment, err := wallet.DeployContract(token, inputs[3], inputs[0], artifactJSON, args, inputs[1], accountIndex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to deploy contract:", err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Contract %s deployed at %s in block %d (transaction %s, %d gas used)\n",
+		deployment.Name, deployment.Address, deployment.BlockNumber, deployment.Hash, deployment.GasUsed)
+	return nil
+}
+
+func promptApproval(scanner *bufio.Scanner) signer.ApprovalFunc {
+	var mu sync.Mutex
+	return func(_ context.Context, req *signer.ApprovalRequest) (bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintln(os.Stdout, req.String())
+		answer, err := promptInput(scanner, "Approve request? [y/N]: ")
+		if err != nil {
+			return false, err
+		}
+
+		return strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes"), nil
+	}
+}
+
+func serveCmd(scanner *bufio.Scanner, wallet *hdwallet.Wallet) error {
+	if wallet == nil {
+		fmt.Fprintln(os.Stderr, "Wallet not found")
+		return fmt.Errorf("wallet not initialized")
+	}
+
+	var inputs []string
+	prompts := []string{
+		"Enter password: ",
+		"Enter HTTP address (default 127.0.0.1:8550): ",
+		"Enter IPC socket path (leave empty to disable): ",
+		"Enter rules file (leave empty to approve requests here): ",
+	}
+	for _, prompt := range prompts {
+		input, err := promptInput(scanner, prompt)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	address := inputs[1]
+	if address == "" {
+		address = "127.0.0.1:8550"
+	}
+
+	approve := promptApproval(scanner)
+	if inputs[3] != "" {
+		rules, err := signer.LoadRules(inputs[3])
+		if err != nil {
+			return fmt.Errorf("error loading approval rules: %w", err)
+		}
+		approve = rules.Approve
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	server := signer.NewServer(wallet, inputs[0], approve)
+	errs := make(chan error, 2)
+	go func() {
+		errs <- server.ListenAndServe(ctx, address)
+	}()
+
+	if inputs[2] != "" {
+		go func() {
+			errs <- server.ServeIPC(ctx, inputs[2])
+		}()
+	}
+
+	fmt.Fprintf(os.Stdout, "Signer listening on http://%s, press Ctrl+C to stop\n", address)
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errs:
+		return err
+	}
+}
+
+func main() {
+	scanner := bufio.NewScanner(os.Stdin)
+	tokens := []string{"ETH"}
+	var wallet *hdwallet.Wallet
+	var err error
+	cliCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	client := eth.NewClient("http://localhost:8545")
+	defer cancel()
+
+	for {
+		fmt.Fprintln(os.Stdout, "Enter a command or (type 'exit' to quit):")
+		scanner.Scan()
+		command := scanner.Text()
+		switch command {
+		case "create-wallet":
+			wallet, err = createWalletCmd(scanner, tokens)
+			if err != nil {
+				break
+			}
+		case "restore-wallet":
+			wallet, err = restoreWalletCmd(scanner, tokens)
+			if err != nil {
+				break
+			}
+		case "get-token-balance":
+			err := checkBalanceCmd(cliCtx, scanner, wallet, client)
+			if err != nil {
+				break
+			}
+		case "send":
+			err := sendCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "add-contact":
+			err := addContactCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "list-contacts":
+			err := listContactsCmd(wallet)
+			if err != nil {
+				break
+			}
+		case "receive":
+			err := receiveCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "pay":
+			err := payCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "set-ens-registry":
+			err := setENSRegistryCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "lookup-address":
+			err := lookupAddressCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "list-allowances":
+			err := listAllowancesCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "revoke-allowance":
+			err := revokeAllowanceCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "list-collectibles":
+			err := listCollectiblesCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "transfer-collectible":
+			err := transferCollectibleCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "transfer-multi-tokens":
+			err := transferMultiTokensCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "build-transaction":
+			err := buildTransactionCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "sign-transaction":
+			err := signTransactionCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "broadcast-transaction":
+			err := broadcastTransactionCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "sign-message":
+			err := signMessageCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "verify-message":
+			err := verifyMessageCmd(scanner)
+			if err != nil {
+				break
+			}
+		case "sign-typed-data":
+			err := signTypedDataCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "register-contract":
+			err := registerContractCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "call-contract":
+			err := callContractCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "send-contract-transaction":
+			err := sendContractTransactionCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "deploy-contract":
+			err := deployContractCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "query-events":
+			err := queryEventsCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "contract-activity":
+			err := contractActivityCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "decode-call":
+			err := decodeCallCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "load-signatures":
+			err := loadSignaturesCmd(scanner, wallet)
+			if err != nil {
+				break
+			}
+		case "serve":
+			err := serveCmd(scanner, wallet)
 			if err != nil {
+				fmt.Fprintln(os.Stderr, "Signer stopped:", err)
 				break
 			}
 		}
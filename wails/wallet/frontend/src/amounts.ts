@@ -0,0 +1,25 @@
+// Amounts come from the backend as exact decimal strings, e.g. "0.1". They are compared and
+// shortened as strings so no precision is lost to floating point numbers.
+
+export function decimalPlaces(amount: string): number {
+  return (amount.split('.')[1] ?? '').length;
+}
+
+function toUnits(amount: string, decimals: number): bigint {
+  const [whole, fraction = ''] = amount.split('.');
+  return BigInt(whole + fraction.padEnd(decimals, '0').slice(0, decimals));
+}
+
+// compareAmounts returns a negative number, zero or a positive number as a is less than, equal
+// to or greater than b.
+export function compareAmounts(a: string, b: string, decimals: number = 18): number {
+  const difference = toUnits(a, decimals) - toUnits(b, decimals);
+  return difference === 0n ? 0 : difference < 0n ? -1 : 1;
+}
+
+// formatAmount truncates an amount to at most places decimal places for display.
+export function formatAmount(amount: string, places: number = 2): string {
+  const [whole, fraction = ''] = amount.split('.');
+  const shown = fraction.slice(0, places).replace(/0+$/, '');
+  return shown === '' ? whole : `${whole}.${shown}`;
+}
@@ -1,5 +1,5 @@
 export type Asset = {
-  balance: number;
+  balance: string;
   symbol: string;
   name: string;
   logoPath: string;
@@ -12,10 +12,154 @@ export type AccountMap = {
 };
 
 export type Transaction = {
+  hash: string;
   sender: string;
   recipient: string;
   status: string;
   value: string;
   token: string;
   createdAt: string;
+  replaces: string;
+  replacedBy: string;
+};
+
+export type BalanceUpdate = {
+  token: string;
+  block: number;
+  balances: { [key: number]: string };
+};
+
+export type ReviewIssue = {
+  code: string;
+  message: string;
+};
+
+export type TransactionReview = {
+  from: string;
+  to: string;
+  value: string;
+  balance: string;
+  gasLimit: number;
+  gasPrice: string;
+  maxFee: string;
+  total: string;
+  toContract: boolean;
+  revertReason?: string;
+  errors: ReviewIssue[] | null;
+  warnings: ReviewIssue[] | null;
+  confirmRecipient: boolean;
+};
+
+export type Contact = {
+  id: number;
+  name: string;
+  address: string;
+  token: string;
+  network: string;
+  notes: string;
+};
+
+export type RecentRecipient = {
+  address: string;
+  token: string;
+  lastSent: string;
+  count: number;
+};
+
+export type PaymentRequest = {
+  to: string;
+  chainId?: number;
+  value?: string;
+  function?: string;
+  recipient?: string;
+  units?: string;
+  gasLimit?: number;
+  gasPrice?: string;
+  amount?: string;
+  symbol?: string;
+};
+
+export type ReceiveRequest = {
+  request: PaymentRequest;
+  uri: string;
+  qrCode: string;
+};
+
+export type Allowance = {
+  token: string;
+  symbol: string;
+  decimals: number;
+  owner: string;
+  ownerIndex: number;
+  spender: string;
+  units: string;
+  amount: string;
+  unlimited: boolean;
+};
+
+export type TokenMetadata = {
+  name: string;
+  description: string;
+  image: string;
+};
+
+export type Collectible = {
+  standard: string;
+  contract: string;
+  name: string;
+  symbol: string;
+  tokenId: string;
+  owner: string;
+  ownerIndex: number;
+  balance: string;
+  uri?: string;
+  metadata?: TokenMetadata;
+};
+
+export type ContractParam = {
+  name: string;
+  type: string;
+  value?: string;
+  indexed?: boolean;
+};
+
+export type ContractEvent = {
+  name: string;
+  signature: string;
+  inputs: ContractParam[];
+};
+
+export type Contract = {
+  name: string;
+  address: string;
+  events: ContractEvent[];
+};
+
+export type EventLog = {
+  contract: string;
+  event: string;
+  signature: string;
+  blockNumber: number;
+  txHash: string;
+  logIndex: number;
+  fields: ContractParam[];
+};
+
+export type ContractArtifact = {
+  contractName: string;
+  constructor: ContractParam[];
+  payable: boolean;
+};
+
+export type ArtifactFile = {
+  path: string;
+  artifact: ContractArtifact;
+};
+
+export type Deployment = {
+  name: string;
+  address: string;
+  hash: string;
+  blockNumber: number;
+  gasUsed: number;
 };
@@ -1,24 +1,141 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
-import {main} from '../models';
 import {hdwallet} from '../models';
+import {eth} from '../models';
+import {main} from '../models';
+
+export function AddContact(arg1:hdwallet.Contact):Promise<hdwallet.Contact>;
+
+export function BroadcastTransaction(arg1:string,arg2:string):Promise<string>;
+
+export function CallContract(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:number):Promise<Array<eth.ContractParam>>;
+
+export function CancelTransaction(arg1:string,arg2:string):Promise<string>;
+
+export function ConfirmRecipient(arg1:string,arg2:string):Promise<void>;
+
+export function CreateProposal(arg1:string,arg2:string,arg3:string,arg4:string,arg5:number,arg6:number):Promise<string>;
+
+export function CreateUnsignedTransaction(arg1:string,arg2:string,arg3:string,arg4:number):Promise<string>;
 
 export function CreateWallet(arg1:Array<string>,arg2:string):Promise<string>;
 
+export function DecodeCall(arg1:string,arg2:string,arg3:string):Promise<eth.DecodedCall>;
+
+export function DeployContract(arg1:string,arg2:string,arg3:string,arg4:string,arg5:Array<string>,arg6:string,arg7:number):Promise<eth.Deployment>;
+
+export function EstimateContractGas(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:string,arg6:number):Promise<string>;
+
+export function EstimateDeploymentGas(arg1:string,arg2:string,arg3:Array<string>,arg4:string,arg5:number):Promise<string>;
+
 export function EstimateGas(arg1:string,arg2:string,arg3:string,arg4:number):Promise<string>;
 
+export function ExportTransactionFile(arg1:string,arg2:string):Promise<string>;
+
+export function GetAllowances(arg1:string):Promise<Array<eth.Allowance>>;
+
 export function GetAssets(arg1:{[key: string]: number}):Promise<{[key: string]: main.Asset}>;
 
+export function GetCollectibles(arg1:string):Promise<Array<eth.Collectible>>;
+
+export function GetContacts():Promise<Array<hdwallet.Contact>>;
+
+export function GetContractActivity(arg1:string,arg2:string,arg3:string,arg4:number):Promise<Array<eth.EventLog>>;
+
+export function GetContracts(arg1:string):Promise<Array<eth.Contract>>;
+
+export function GetCounter(arg1:string,arg2:string):Promise<string>;
+
+export function GetCurrentProposal(arg1:string,arg2:string):Promise<eth.Proposal>;
+
+export function GetDemoTokenBalance(arg1:string,arg2:string,arg3:number):Promise<eth.TokenBalance>;
+
+export function GetNonceGaps(arg1:string):Promise<Array<eth.NonceGap>>;
+
+export function GetProviderStatus(arg1:string):Promise<Array<eth.ProviderStatus>>;
+
+export function GetReceiveRequest(arg1:string,arg2:number,arg3:string):Promise<main.ReceiveRequest>;
+
+export function GetRecentRecipients(arg1:string):Promise<Array<hdwallet.RecentRecipient>>;
+
 export function GetTransactions():Promise<Array<hdwallet.WalletTransaction>>;
 
+export function ImportContractABI(arg1:string,arg2:string,arg3:string):Promise<eth.Contract>;
+
+export function ImportTransactionFile():Promise<string>;
+
+export function IncrementCounter(arg1:string,arg2:string,arg3:string,arg4:number):Promise<string>;
+
+export function LoadSignatureDatabase(arg1:string):Promise<number>;
+
+export function LookupAddresses(arg1:string,arg2:Array<string>):Promise<{[key: string]: string}>;
+
+export function OpenContractArtifact():Promise<main.ArtifactFile>;
+
+export function QueryContractEvents(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:number,arg6:number):Promise<Array<eth.EventLog>>;
+
+export function ReadPaymentRequest(arg1:string,arg2:string):Promise<eth.PaymentRequest>;
+
 export function RecoverWallet(arg1:Array<string>,arg2:string):Promise<void>;
 
+export function RegisterContract(arg1:string,arg2:string,arg3:string,arg4:string):Promise<eth.Contract>;
+
+export function RemoveContact(arg1:number):Promise<void>;
+
+export function RemoveContract(arg1:string,arg2:string):Promise<void>;
+
+export function ResetNonceGap(arg1:string,arg2:string):Promise<void>;
+
+export function ResolveRecipient(arg1:string,arg2:string):Promise<string>;
+
 export function RestoreWallet(arg1:Array<string>,arg2:string,arg3:string):Promise<void>;
 
+export function ReviewPaymentRequest(arg1:string,arg2:string,arg3:number):Promise<eth.TransactionReview>;
+
+export function ReviewTransaction(arg1:string,arg2:string,arg3:string,arg4:number):Promise<eth.TransactionReview>;
+
+export function ReviewTypedData(arg1:string,arg2:string):Promise<eth.TypedDataReview>;
+
+export function RevokeAllowance(arg1:string,arg2:string,arg3:string,arg4:string,arg5:number):Promise<string>;
+
+export function SendContractTransaction(arg1:string,arg2:string,arg3:string,arg4:string,arg5:Array<string>,arg6:string,arg7:number):Promise<string>;
+
+export function SendPaymentRequest(arg1:string,arg2:string,arg3:string,arg4:number):Promise<string>;
+
 export function SendTransaction(arg1:string,arg2:string,arg3:string,arg4:string,arg5:number):Promise<boolean>;
 
+export function SetENSRegistry(arg1:string,arg2:string):Promise<void>;
+
+export function SetProviders(arg1:string,arg2:Array<string>):Promise<void>;
+
+export function SignMessage(arg1:string,arg2:string,arg3:number,arg4:string):Promise<string>;
+
+export function SignOfflineTransaction(arg1:string,arg2:string,arg3:string,arg4:number):Promise<string>;
+
+export function SignTypedData(arg1:string,arg2:string,arg3:number,arg4:string):Promise<string>;
+
+export function SpeedUpTransaction(arg1:string,arg2:string):Promise<string>;
+
+export function StartSigner(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function StopSigner():Promise<void>;
+
+export function SyncContractActivity(arg1:string,arg2:string):Promise<Array<eth.EventLog>>;
+
+export function TransactionQRCode(arg1:string):Promise<string>;
+
+export function TransferCollectible(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string,arg6:number):Promise<string>;
+
+export function TransferMultiTokens(arg1:string,arg2:string,arg3:string,arg4:string,arg5:Array<string>,arg6:Array<string>,arg7:number):Promise<string>;
+
+export function UpdateContact(arg1:hdwallet.Contact):Promise<hdwallet.Contact>;
+
 export function ValidateAddress(arg1:string,arg2:string):Promise<boolean>;
 
 export function ValidateMnemonic(arg1:string):Promise<boolean>;
 
+export function VerifyMessage(arg1:string,arg2:string,arg3:string):Promise<boolean>;
+
+export function VoteProposal(arg1:string,arg2:string,arg3:string,arg4:string,arg5:number):Promise<string>;
+
 export function WalletExists():Promise<boolean>;
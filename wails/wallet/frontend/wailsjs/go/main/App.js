@@ -2,34 +2,258 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddContact(arg1) {
+  return window['go']['main']['App']['AddContact'](arg1);
+}
+
+export function BroadcastTransaction(arg1, arg2) {
+  return window['go']['main']['App']['BroadcastTransaction'](arg1, arg2);
+}
+
+export function CallContract(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['CallContract'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function CancelTransaction(arg1, arg2) {
+  return window['go']['main']['App']['CancelTransaction'](arg1, arg2);
+}
+
+export function ConfirmRecipient(arg1, arg2) {
+  return window['go']['main']['App']['ConfirmRecipient'](arg1, arg2);
+}
+
+export function CreateProposal(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['CreateProposal'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function CreateUnsignedTransaction(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['CreateUnsignedTransaction'](arg1, arg2, arg3, arg4);
+}
+
 export function CreateWallet(arg1, arg2) {
   return window['go']['main']['App']['CreateWallet'](arg1, arg2);
 }
 
+export function DecodeCall(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DecodeCall'](arg1, arg2, arg3);
+}
+
+export function DeployContract(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
+  return window['go']['main']['App']['DeployContract'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
+}
+
+export function EstimateContractGas(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['EstimateContractGas'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function EstimateDeploymentGas(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['EstimateDeploymentGas'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function EstimateGas(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['EstimateGas'](arg1, arg2, arg3, arg4);
 }
 
+export function ExportTransactionFile(arg1, arg2) {
+  return window['go']['main']['App']['ExportTransactionFile'](arg1, arg2);
+}
+
+export function GetAllowances(arg1) {
+  return window['go']['main']['App']['GetAllowances'](arg1);
+}
+
 export function GetAssets(arg1) {
   return window['go']['main']['App']['GetAssets'](arg1);
 }
 
+export function GetCollectibles(arg1) {
+  return window['go']['main']['App']['GetCollectibles'](arg1);
+}
+
+export function GetContacts() {
+  return window['go']['main']['App']['GetContacts']();
+}
+
+export function GetContractActivity(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetContractActivity'](arg1, arg2, arg3, arg4);
+}
+
+export function GetContracts(arg1) {
+  return window['go']['main']['App']['GetContracts'](arg1);
+}
+
+export function GetCounter(arg1, arg2) {
+  return window['go']['main']['App']['GetCounter'](arg1, arg2);
+}
+
+export function GetCurrentProposal(arg1, arg2) {
+  return window['go']['main']['App']['GetCurrentProposal'](arg1, arg2);
+}
+
+export function GetDemoTokenBalance(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetDemoTokenBalance'](arg1, arg2, arg3);
+}
+
+export function GetNonceGaps(arg1) {
+  return window['go']['main']['App']['GetNonceGaps'](arg1);
+}
+
+export function GetProviderStatus(arg1) {
+  return window['go']['main']['App']['GetProviderStatus'](arg1);
+}
+
+export function GetReceiveRequest(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetReceiveRequest'](arg1, arg2, arg3);
+}
+
+export function GetRecentRecipients(arg1) {
+  return window['go']['main']['App']['GetRecentRecipients'](arg1);
+}
+
 export function GetTransactions() {
   return window['go']['main']['App']['GetTransactions']();
 }
 
+export function ImportContractABI(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ImportContractABI'](arg1, arg2, arg3);
+}
+
+export function ImportTransactionFile() {
+  return window['go']['main']['App']['ImportTransactionFile']();
+}
+
+export function IncrementCounter(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['IncrementCounter'](arg1, arg2, arg3, arg4);
+}
+
+export function LoadSignatureDatabase(arg1) {
+  return window['go']['main']['App']['LoadSignatureDatabase'](arg1);
+}
+
+export function LookupAddresses(arg1, arg2) {
+  return window['go']['main']['App']['LookupAddresses'](arg1, arg2);
+}
+
+export function OpenContractArtifact() {
+  return window['go']['main']['App']['OpenContractArtifact']();
+}
+
+export function QueryContractEvents(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['QueryContractEvents'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function ReadPaymentRequest(arg1, arg2) {
+  return window['go']['main']['App']['ReadPaymentRequest'](arg1, arg2);
+}
+
 export function RecoverWallet(arg1, arg2) {
   return window['go']['main']['App']['RecoverWallet'](arg1, arg2);
 }
 
+export function RegisterContract(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['RegisterContract'](arg1, arg2, arg3, arg4);
+}
+
+export function RemoveContact(arg1) {
+  return window['go']['main']['App']['RemoveContact'](arg1);
+}
+
+export function RemoveContract(arg1, arg2) {
+  return window['go']['main']['App']['RemoveContract'](arg1, arg2);
+}
+
+export function ResetNonceGap(arg1, arg2) {
+  return window['go']['main']['App']['ResetNonceGap'](arg1, arg2);
+}
+
+export function ResolveRecipient(arg1, arg2) {
+  return window['go']['main']['App']['ResolveRecipient'](arg1, arg2);
+}
+
 export function RestoreWallet(arg1, arg2, arg3) {
   return window['go']['main']['App']['RestoreWallet'](arg1, arg2, arg3);
 }
 
+export function ReviewPaymentRequest(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ReviewPaymentRequest'](arg1, arg2, arg3);
+}
+
+export function ReviewTransaction(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ReviewTransaction'](arg1, arg2, arg3, arg4);
+}
+
+export function ReviewTypedData(arg1, arg2) {
+  return window['go']['main']['App']['ReviewTypedData'](arg1, arg2);
+}
+
+export function RevokeAllowance(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['RevokeAllowance'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function SendContractTransaction(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
+  return window['go']['main']['App']['SendContractTransaction'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
+}
+
+export function SendPaymentRequest(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SendPaymentRequest'](arg1, arg2, arg3, arg4);
+}
+
 export function SendTransaction(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['main']['App']['SendTransaction'](arg1, arg2, arg3, arg4, arg5);
 }
 
+export function SetENSRegistry(arg1, arg2) {
+  return window['go']['main']['App']['SetENSRegistry'](arg1, arg2);
+}
+
+export function SetProviders(arg1, arg2) {
+  return window['go']['main']['App']['SetProviders'](arg1, arg2);
+}
+
+export function SignMessage(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SignMessage'](arg1, arg2, arg3, arg4);
+}
+
+export function SignOfflineTransaction(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SignOfflineTransaction'](arg1, arg2, arg3, arg4);
+}
+
+export function SignTypedData(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SignTypedData'](arg1, arg2, arg3, arg4);
+}
+
+export function SpeedUpTransaction(arg1, arg2) {
+  return window['go']['main']['App']['SpeedUpTransaction'](arg1, arg2);
+}
+
+export function StartSigner(arg1, arg2, arg3) {
+  return window['go']['main']['App']['StartSigner'](arg1, arg2, arg3);
+}
+
+export function StopSigner() {
+  return window['go']['main']['App']['StopSigner']();
+}
+
+export function SyncContractActivity(arg1, arg2) {
+  return window['go']['main']['App']['SyncContractActivity'](arg1, arg2);
+}
+
+export function TransactionQRCode(arg1) {
+  return window['go']['main']['App']['TransactionQRCode'](arg1);
+}
+
+export function TransferCollectible(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['TransferCollectible'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function TransferMultiTokens(arg1, arg2, arg3, arg4, arg5, arg6, arg7) {
+  return window['go']['main']['App']['TransferMultiTokens'](arg1, arg2, arg3, arg4, arg5, arg6, arg7);
+}
+
+export function UpdateContact(arg1) {
+  return window['go']['main']['App']['UpdateContact'](arg1);
+}
+
 export function ValidateAddress(arg1, arg2) {
   return window['go']['main']['App']['ValidateAddress'](arg1, arg2);
 }
@@ -38,6 +262,14 @@ export function ValidateMnemonic(arg1) {
   return window['go']['main']['App']['ValidateMnemonic'](arg1);
 }
 
+export function VerifyMessage(arg1, arg2, arg3) {
+  return window['go']['main']['App']['VerifyMessage'](arg1, arg2, arg3);
+}
+
+export function VoteProposal(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['VoteProposal'](arg1, arg2, arg3, arg4, arg5);
+}
+
 export function WalletExists() {
   return window['go']['main']['App']['WalletExists']();
 }
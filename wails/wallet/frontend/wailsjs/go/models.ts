@@ -1,12 +1,718 @@
+export namespace eth {
+	
+	export class Allowance {
+	    token: string;
+	    symbol: string;
+	    decimals: number;
+	    owner: string;
+	    ownerIndex: number;
+	    spender: string;
+	    units: string;
+	    amount: string;
+	    unlimited: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Allowance(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.token = source["token"];
+	        this.symbol = source["symbol"];
+	        this.decimals = source["decimals"];
+	        this.owner = source["owner"];
+	        this.ownerIndex = source["ownerIndex"];
+	        this.spender = source["spender"];
+	        this.units = source["units"];
+	        this.amount = source["amount"];
+	        this.unlimited = source["unlimited"];
+	    }
+	}
+	export class TokenMetadata {
+	    name: string;
+	    description: string;
+	    image: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TokenMetadata(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.image = source["image"];
+	    }
+	}
+	export class Collectible {
+	    standard: string;
+	    contract: string;
+	    name: string;
+	    symbol: string;
+	    tokenId: string;
+	    owner: string;
+	    ownerIndex: number;
+	    balance: string;
+	    uri?: string;
+	    metadata?: TokenMetadata;
+	
+	    static createFrom(source: any = {}) {
+	        return new Collectible(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.standard = source["standard"];
+	        this.contract = source["contract"];
+	        this.name = source["name"];
+	        this.symbol = source["symbol"];
+	        this.tokenId = source["tokenId"];
+	        this.owner = source["owner"];
+	        this.ownerIndex = source["ownerIndex"];
+	        this.balance = source["balance"];
+	        this.uri = source["uri"];
+	        this.metadata = this.convertValues(source["metadata"], TokenMetadata);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ContractEvent {
+	    name: string;
+	    signature: string;
+	    inputs: ContractParam[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ContractEvent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.signature = source["signature"];
+	        this.inputs = this.convertValues(source["inputs"], ContractParam);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ContractParam {
+	    name: string;
+	    type: string;
+	    value?: string;
+	    indexed?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContractParam(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.value = source["value"];
+	        this.indexed = source["indexed"];
+	    }
+	}
+	export class ContractMethod {
+	    name: string;
+	    signature: string;
+	    inputs: ContractParam[];
+	    outputs: ContractParam[];
+	    readOnly: boolean;
+	    payable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContractMethod(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.signature = source["signature"];
+	        this.inputs = this.convertValues(source["inputs"], ContractParam);
+	        this.outputs = this.convertValues(source["outputs"], ContractParam);
+	        this.readOnly = source["readOnly"];
+	        this.payable = source["payable"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Contract {
+	    name: string;
+	    address: string;
+	    methods: ContractMethod[];
+	    events: ContractEvent[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Contract(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.address = source["address"];
+	        this.methods = this.convertValues(source["methods"], ContractMethod);
+	        this.events = this.convertValues(source["events"], ContractEvent);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ContractArtifact {
+	    contractName: string;
+	    constructor: ContractParam[];
+	    payable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContractArtifact(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.contractName = source["contractName"];
+	        this.constructor = this.convertValues(source["constructor"], ContractParam);
+	        this.payable = source["payable"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	export class ReviewIssue {
+	    code: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReviewIssue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.message = source["message"];
+	    }
+	}
+	export class DecodedCall {
+	    selector: string;
+	    function?: string;
+	    signature?: string;
+	    source?: string;
+	    contract?: string;
+	    args: ContractParam[];
+	    words?: string[];
+	    summary: string;
+	    risks: ReviewIssue[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DecodedCall(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.selector = source["selector"];
+	        this.function = source["function"];
+	        this.signature = source["signature"];
+	        this.source = source["source"];
+	        this.contract = source["contract"];
+	        this.args = this.convertValues(source["args"], ContractParam);
+	        this.words = source["words"];
+	        this.summary = source["summary"];
+	        this.risks = this.convertValues(source["risks"], ReviewIssue);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Deployment {
+	    name: string;
+	    address: string;
+	    hash: string;
+	    blockNumber: number;
+	    gasUsed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Deployment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.address = source["address"];
+	        this.hash = source["hash"];
+	        this.blockNumber = source["blockNumber"];
+	        this.gasUsed = source["gasUsed"];
+	    }
+	}
+	export class EventLog {
+	    contract: string;
+	    event: string;
+	    signature: string;
+	    blockNumber: number;
+	    txHash: string;
+	    logIndex: number;
+	    fields: ContractParam[];
+	
+	    static createFrom(source: any = {}) {
+	        return new EventLog(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.contract = source["contract"];
+	        this.event = source["event"];
+	        this.signature = source["signature"];
+	        this.blockNumber = source["blockNumber"];
+	        this.txHash = source["txHash"];
+	        this.logIndex = source["logIndex"];
+	        this.fields = this.convertValues(source["fields"], ContractParam);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NonceGap {
+	    address: string;
+	    next: number;
+	    hashes: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NonceGap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = source["address"];
+	        this.next = source["next"];
+	        this.hashes = source["hashes"];
+	    }
+	}
+	export class PaymentRequest {
+	    to: string;
+	    chainId?: number;
+	    value?: string;
+	    function?: string;
+	    recipient?: string;
+	    units?: string;
+	    gasLimit?: number;
+	    gasPrice?: string;
+	    amount?: string;
+	    symbol?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaymentRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.to = source["to"];
+	        this.chainId = source["chainId"];
+	        this.value = source["value"];
+	        this.function = source["function"];
+	        this.recipient = source["recipient"];
+	        this.units = source["units"];
+	        this.gasLimit = source["gasLimit"];
+	        this.gasPrice = source["gasPrice"];
+	        this.amount = source["amount"];
+	        this.symbol = source["symbol"];
+	    }
+	}
+	export class Proposal {
+	    description: string;
+	    approve: string;
+	    reject: string;
+	    pass: string;
+	    totalVoteToEnd: string;
+	    currentState: boolean;
+	    isActive: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Proposal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.description = source["description"];
+	        this.approve = source["approve"];
+	        this.reject = source["reject"];
+	        this.pass = source["pass"];
+	        this.totalVoteToEnd = source["totalVoteToEnd"];
+	        this.currentState = source["currentState"];
+	        this.isActive = source["isActive"];
+	    }
+	}
+	export class ProviderStatus {
+	    url: string;
+	    active: boolean;
+	    healthy: boolean;
+	    listening: boolean;
+	    blockNumber: number;
+	    blockLag: number;
+	    latencyMs: number;
+	    failures: number;
+	    lastError: string;
+	    // Go type: time
+	    lastChecked: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProviderStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.url = source["url"];
+	        this.active = source["active"];
+	        this.healthy = source["healthy"];
+	        this.listening = source["listening"];
+	        this.blockNumber = source["blockNumber"];
+	        this.blockLag = source["blockLag"];
+	        this.latencyMs = source["latencyMs"];
+	        this.failures = source["failures"];
+	        this.lastError = source["lastError"];
+	        this.lastChecked = this.convertValues(source["lastChecked"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TokenBalance {
+	    name: string;
+	    symbol: string;
+	    decimals: number;
+	    balance: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TokenBalance(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.symbol = source["symbol"];
+	        this.decimals = source["decimals"];
+	        this.balance = source["balance"];
+	    }
+	}
+	
+	export class TransactionReview {
+	    from: string;
+	    to: string;
+	    value: string;
+	    balance: string;
+	    gasLimit: number;
+	    gasPrice: string;
+	    maxFee: string;
+	    total: string;
+	    toContract: boolean;
+	    revertReason?: string;
+	    errors: ReviewIssue[];
+	    warnings: ReviewIssue[];
+	    confirmRecipient: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TransactionReview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.value = source["value"];
+	        this.balance = source["balance"];
+	        this.gasLimit = source["gasLimit"];
+	        this.gasPrice = source["gasPrice"];
+	        this.maxFee = source["maxFee"];
+	        this.total = source["total"];
+	        this.toContract = source["toContract"];
+	        this.revertReason = source["revertReason"];
+	        this.errors = this.convertValues(source["errors"], ReviewIssue);
+	        this.warnings = this.convertValues(source["warnings"], ReviewIssue);
+	        this.confirmRecipient = source["confirmRecipient"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TypedDataField {
+	    name: string;
+	    type: string;
+	    value?: string;
+	    fields?: TypedDataField[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TypedDataField(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.value = source["value"];
+	        this.fields = this.convertValues(source["fields"], TypedDataField);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TypedDataReview {
+	    primaryType: string;
+	    domainName: string;
+	    domainVersion: string;
+	    verifyingContract: string;
+	    chainId: string;
+	    networkChainId: string;
+	    chainIdMismatch: boolean;
+	    domainSeparator: string;
+	    structHash: string;
+	    digest: string;
+	    message: TypedDataField[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TypedDataReview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.primaryType = source["primaryType"];
+	        this.domainName = source["domainName"];
+	        this.domainVersion = source["domainVersion"];
+	        this.verifyingContract = source["verifyingContract"];
+	        this.chainId = source["chainId"];
+	        this.networkChainId = source["networkChainId"];
+	        this.chainIdMismatch = source["chainIdMismatch"];
+	        this.domainSeparator = source["domainSeparator"];
+	        this.structHash = source["structHash"];
+	        this.digest = source["digest"];
+	        this.message = this.convertValues(source["message"], TypedDataField);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace hdwallet {
 	
+	export class Contact {
+	    id: number;
+	    name: string;
+	    address: string;
+	    token: string;
+	    network: string;
+	    notes: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Contact(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.address = source["address"];
+	        this.token = source["token"];
+	        this.network = source["network"];
+	        this.notes = source["notes"];
+	    }
+	}
+	export class RecentRecipient {
+	    address: string;
+	    token: string;
+	    lastSent: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecentRecipient(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.address = source["address"];
+	        this.token = source["token"];
+	        this.lastSent = source["lastSent"];
+	        this.count = source["count"];
+	    }
+	}
 	export class WalletTransaction {
+	    hash: string;
 	    sender: string;
 	    recipient: string;
 	    status: string;
 	    value: string;
 	    token: string;
 	    createdAt: string;
+	    replaces: string;
+	    replacedBy: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new WalletTransaction(source);
@@ -14,13 +720,87 @@ export namespace hdwallet {
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
 	        this.sender = source["sender"];
 	        this.recipient = source["recipient"];
 	        this.status = source["status"];
 	        this.value = source["value"];
 	        this.token = source["token"];
 	        this.createdAt = source["createdAt"];
+	        this.replaces = source["replaces"];
+	        this.replacedBy = source["replacedBy"];
+	    }
+	}
+
+}
+
+export namespace main {
+	
+	export class ArtifactFile {
+	    path: string;
+	    artifact?: eth.ContractArtifact;
+	
+	    static createFrom(source: any = {}) {
+	        return new ArtifactFile(source);
 	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.artifact = this.convertValues(source["artifact"], eth.ContractArtifact);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ReceiveRequest {
+	    request?: eth.PaymentRequest;
+	    uri: string;
+	    qrCode: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReceiveRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.request = this.convertValues(source["request"], eth.PaymentRequest);
+	        this.uri = source["uri"];
+	        this.qrCode = source["qrCode"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 
 }
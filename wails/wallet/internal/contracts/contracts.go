@@ -0,0 +1,6 @@
+// Package contracts holds Go bindings for the contracts shipped under hardhat/contracts.
+// The bindings are generated from the compiled Hardhat artifacts, so recompile the
+// contracts with `npx hardhat compile` and run `go generate` after changing them.
+package contracts
+
+//go:generate go run ./gen -artifacts ../../../../hardhat/artifacts/contracts -out .
@@ -0,0 +1,69 @@
+// Command gen writes Go bindings for the Hardhat artifacts of the bundled contracts.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+var contractNames = []string{"DemoToken", "Increment", "Rocket", "VotingProposal"}
+
+type artifact struct {
+	ABI      json.RawMessage `json:"abi"`
+	Bytecode string          `json:"bytecode"`
+}
+
+func generateBinding(artifactsDir, outDir, name string) error {
+	data, err := os.ReadFile(filepath.Join(artifactsDir, name+".sol", name+".json"))
+	if err != nil {
+		return fmt.Errorf("error reading %s artifact: %w", name, err)
+	}
+
+	var contract artifact
+	err = json.Unmarshal(data, &contract)
+	if err != nil {
+		return fmt.Errorf("error parsing %s artifact: %w", name, err)
+	}
+
+	code, err := bind.Bind(
+		[]string{name},
+		[]string{string(contract.ABI)},
+		[]string{contract.Bytecode},
+		nil,
+		"contracts",
+		bind.LangGo,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("error generating %s binding: %w", name, err)
+	}
+
+	outPath := filepath.Join(outDir, strings.ToLower(name)+".go")
+	err = os.WriteFile(outPath, []byte(code), 0o644)
+	if err != nil {
+		return fmt.Errorf("error writing %s binding: %w", name, err)
+	}
+
+	return nil
+}
+
+func main() {
+	artifactsDir := flag.String("artifacts", "", "directory holding the Hardhat contract artifacts")
+	outDir := flag.String("out", ".", "directory the bindings are written to")
+	flag.Parse()
+
+	for _, name := range contractNames {
+		err := generateBinding(*artifactsDir, *outDir, name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// IncrementMetaData contains all meta data concerning the Increment contract.
+var IncrementMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"initial_value\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"description\",\"type\":\"string\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"decrement\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getCounter\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"increment\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561001057600080fd5b506040516109333803806109338339818101604052810190610032919061025e565b336000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555060405180604001604052808381526020018281525060016000820151816000015560208201518160010190816100a891906104d1565b5090505050506105a3565b6000604051905090565b600080fd5b600080fd5b6000819050919050565b6100da816100c7565b81146100e557600080fd5b50565b6000815190506100f7816100d1565b92915050565b600080fd5b600080fd5b6000601f19601f8301169050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b61015082610107565b810181811067ffffffffffffffff8211171561016f5761016e610118565b5b80604052505050565b60006101826100b3565b905061018e8282610147565b919050565b600067ffffffffffffffff8211156101ae576101ad610118565b5b6101b782610107565b9050602081019050919050565b60005b838110156101e25780820151818401526020810190506101c7565b60008484015250505050565b60006102016101fc84610193565b610178565b90508281526020810184848401111561021d5761021c610102565b5b6102288482856101c4565b509392505050565b600082601f830112610245576102446100fd565b5b81516102558482602086016101ee565b91505092915050565b60008060408385031215610275576102746100bd565b5b6000610283858286016100e8565b925050602083015167ffffffffffffffff8111156102a4576102a36100c2565b5b6102b085828601610230565b9150509250929050565b600081519050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b6000600282049050600182168061030c57607f821691505b60208210810361031f5761031e6102c5565b5b50919050565b60008190508160005260206000209050919050565b60006020601f8301049050919050565b600082821b905092915050565b6000600883026103877fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8261034a565b610391868361034a565b95508019841693508086168417925050509392505050565b6000819050919050565b60006103ce6103c96103c4846100c7565b6103a9565b6100c7565b9050919050565b6000819050919050565b6103e8836103b3565b6103fc6103f4826103d5565b848454610357565b825550505050565b600090565b610411610404565b61041c8184846103df565b505050565b5b8181101561044057610435600082610409565b600181019050610422565b5050565b601f8211156104855761045681610325565b61045f8461033a565b8101602085101561046e578190505b61048261047a8561033a565b830182610421565b50505b505050565b600082821c905092915050565b60006104a86000198460080261048a565b1980831691505092915050565b60006104c18383610497565b9150826002028217905092915050565b6104da826102ba565b67ffffffffffffffff8111156104f3576104f2610118565b5b6104fd82546102f4565b610508828285610444565b600060209050601f83116001811461053b5760008415610529578287015190505b61053385826104b5565b86555061059b565b601f19841661054986610325565b60005b828110156105715784890151825560018201915060208501945060208101905061054c565b8683101561058e578489015161058a601f891682610497565b8355505b6001600288020188555050505b505050505050565b610381806105b26000396000f3fe608060405234801561001057600080fd5b50600436106100415760003560e01c80632baeceb7146100465780638ada066e14610050578063d09de08a1461006e575b600080fd5b61004e610078565b005b610058610124565b60405161006591906101f6565b60405180910390f35b610076610131565b005b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614610106576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016100fd90610294565b60405180910390fd5b600180600001600082825461011b91906102e3565b92505081905550565b6000600160000154905090565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16146101bf576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016101b690610294565b60405180910390fd5b60018060000160008282546101d49190610317565b92505081905550565b6000819050919050565b6101f0816101dd565b82525050565b600060208201905061020b60008301846101e7565b92915050565b600082825260208201905092915050565b7f4f6e6c79206f776e65722063616e2063616c6c20746869732066756e6374696f60008201527f6e00000000000000000000000000000000000000000000000000000000000000602082015250565b600061027e602183610211565b915061028982610222565b604082019050919050565b600060208201905081810360008301526102ad81610271565b9050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b60006102ee826101dd565b91506102f9836101dd565b9250828203905081811115610311576103106102b4565b5b92915050565b6000610322826101dd565b915061032d836101dd565b9250828201905080821115610345576103446102b4565b5b9291505056fea2646970667358221220c4ef17a870c10dc0915d1087b21ad4f405e6381d05f5fad50915cf4a41b47ab464736f6c634300081c0033",
+}
+
+// IncrementABI is the input ABI used to generate the binding from.
+// Deprecated: Use IncrementMetaData.ABI instead.
+var IncrementABI = IncrementMetaData.ABI
+
+// IncrementBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use IncrementMetaData.Bin instead.
+var IncrementBin = IncrementMetaData.Bin
+
+// DeployIncrement deploys a new Ethereum contract, binding an instance of Increment to it.
+func DeployIncrement(auth *bind.TransactOpts, backend bind.ContractBackend, initial_value *big.Int, description string) (common.Address, *types.Transaction, *Increment, error) {
+	parsed, err := IncrementMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(IncrementBin), backend, initial_value, description)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Increment{IncrementCaller: IncrementCaller{contract: contract}, IncrementTransactor: IncrementTransactor{contract: contract}, IncrementFilterer: IncrementFilterer{contract: contract}}, nil
+}
+
+// Increment is an auto generated Go binding around an Ethereum contract.
+type Increment struct {
+	IncrementCaller     // Read-only binding to the contract
+	IncrementTransactor // Write-only binding to the contract
+	IncrementFilterer   // Log filterer for contract events
+}
+
+// IncrementCaller is an auto generated read-only Go binding around an Ethereum contract.
+type IncrementCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IncrementTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type IncrementTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IncrementFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type IncrementFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IncrementSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type IncrementSession struct {
+	Contract     *Increment        // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// IncrementCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type IncrementCallerSession struct {
+	Contract *IncrementCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts    // Call options to use throughout this session
+}
+
+// IncrementTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type IncrementTransactorSession struct {
+	Contract     *IncrementTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts    // Transaction auth options to use throughout this session
+}
+
+// IncrementRaw is an auto generated low-level Go binding around an Ethereum contract.
+type IncrementRaw struct {
+	Contract *Increment // Generic contract binding to access the raw methods on
+}
+
+// IncrementCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type IncrementCallerRaw struct {
+	Contract *IncrementCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// IncrementTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type IncrementTransactorRaw struct {
+	Contract *IncrementTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewIncrement creates a new instance of Increment, bound to a specific deployed contract.
+func NewIncrement(address common.Address, backend bind.ContractBackend) (*Increment, error) {
+	contract, err := bindIncrement(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Increment{IncrementCaller: IncrementCaller{contract: contract}, IncrementTransactor: IncrementTransactor{contract: contract}, IncrementFilterer: IncrementFilterer{contract: contract}}, nil
+}
+
+// NewIncrementCaller creates a new read-only instance of Increment, bound to a specific deployed contract.
+func NewIncrementCaller(address common.Address, caller bind.ContractCaller) (*IncrementCaller, error) {
+	contract, err := bindIncrement(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IncrementCaller{contract: contract}, nil
+}
+
+// NewIncrementTransactor creates a new write-only instance of Increment, bound to a specific deployed contract.
+func NewIncrementTransactor(address common.Address, transactor bind.ContractTransactor) (*IncrementTransactor, error) {
+	contract, err := bindIncrement(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IncrementTransactor{contract: contract}, nil
+}
+
+// NewIncrementFilterer creates a new log filterer instance of Increment, bound to a specific deployed contract.
+func NewIncrementFilterer(address common.Address, filterer bind.ContractFilterer) (*IncrementFilterer, error) {
+	contract, err := bindIncrement(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &IncrementFilterer{contract: contract}, nil
+}
+
+// bindIncrement binds a generic wrapper to an already deployed contract.
+func bindIncrement(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := IncrementMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Increment *IncrementRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Increment.Contract.IncrementCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Increment *IncrementRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Increment.Contract.IncrementTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Increment *IncrementRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Increment.Contract.IncrementTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Increment *IncrementCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Increment.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Increment *IncrementTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Increment.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Increment *IncrementTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Increment.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetCounter is a free data retrieval call binding the contract method 0x8ada066e.
+//
+// Solidity: function getCounter() view returns(uint256)
+func (_Increment *IncrementCaller) GetCounter(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Increment.contract.Call(opts, &out, "getCounter")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetCounter is a free data retrieval call binding the contract method 0x8ada066e.
+//
+// Solidity: function getCounter() view returns(uint256)
+func (_Increment *IncrementSession) GetCounter() (*big.Int, error) {
+	return _Increment.Contract.GetCounter(&_Increment.CallOpts)
+}
+
+// GetCounter is a free data retrieval call binding the contract method 0x8ada066e.
+//
+// Solidity: function getCounter() view returns(uint256)
+func (_Increment *IncrementCallerSession) GetCounter() (*big.Int, error) {
+	return _Increment.Contract.GetCounter(&_Increment.CallOpts)
+}
+
+// Decrement is a paid mutator transaction binding the contract method 0x2baeceb7.
+//
+// Solidity: function decrement() returns()
+func (_Increment *IncrementTransactor) Decrement(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Increment.contract.Transact(opts, "decrement")
+}
+
+// Decrement is a paid mutator transaction binding the contract method 0x2baeceb7.
+//
+// Solidity: function decrement() returns()
+func (_Increment *IncrementSession) Decrement() (*types.Transaction, error) {
+	return _Increment.Contract.Decrement(&_Increment.TransactOpts)
+}
+
+// Decrement is a paid mutator transaction binding the contract method 0x2baeceb7.
+//
+// Solidity: function decrement() returns()
+func (_Increment *IncrementTransactorSession) Decrement() (*types.Transaction, error) {
+	return _Increment.Contract.Decrement(&_Increment.TransactOpts)
+}
+
+// Increment is a paid mutator transaction binding the contract method 0xd09de08a.
+//
+// Solidity: function increment() returns()
+func (_Increment *IncrementTransactor) Increment(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Increment.contract.Transact(opts, "increment")
+}
+
+// Increment is a paid mutator transaction binding the contract method 0xd09de08a.
+//
+// Solidity: function increment() returns()
+func (_Increment *IncrementSession) Increment() (*types.Transaction, error) {
+	return _Increment.Contract.Increment(&_Increment.TransactOpts)
+}
+
+// Increment is a paid mutator transaction binding the contract method 0xd09de08a.
+//
+// Solidity: function increment() returns()
+func (_Increment *IncrementTransactorSession) Increment() (*types.Transaction, error) {
+	return _Increment.Contract.Increment(&_Increment.TransactOpts)
+}
@@ -0,0 +1,286 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// RocketMetaData contains all meta data concerning the Rocket contract.
+var RocketMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_name\",\"type\":\"string\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"launch\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"status\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561001057600080fd5b50604051610b3b380380610b3b83398181016040528101906100329190610202565b8060009081610041919061046c565b506040518060400160405280600881526020017f69676e6974696f6e00000000000000000000000000000000000000000000000081525060019081610086919061046c565b505061053e565b6000604051905090565b600080fd5b600080fd5b600080fd5b600080fd5b6000601f19601f8301169050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b6100f4826100ab565b810181811067ffffffffffffffff82111715610113576101126100bc565b5b80604052505050565b600061012661008d565b905061013282826100eb565b919050565b600067ffffffffffffffff821115610152576101516100bc565b5b61015b826100ab565b9050602081019050919050565b60005b8381101561018657808201518184015260208101905061016b565b60008484015250505050565b60006101a56101a084610137565b61011c565b9050828152602081018484840111156101c1576101c06100a6565b5b6101cc848285610168565b509392505050565b600082601f8301126101e9576101e86100a1565b5b81516101f9848260208601610192565b91505092915050565b60006020828403121561021857610217610097565b5b600082015167ffffffffffffffff8111156102365761023561009c565b5b610242848285016101d4565b91505092915050565b600081519050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b6000600282049050600182168061029d57607f821691505b6020821081036102b0576102af610256565b5b50919050565b60008190508160005260206000209050919050565b60006020601f8301049050919050565b600082821b905092915050565b6000600883026103187fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff826102db565b61032286836102db565b95508019841693508086168417925050509392505050565b6000819050919050565b6000819050919050565b600061036961036461035f8461033a565b610344565b61033a565b9050919050565b6000819050919050565b6103838361034e565b61039761038f82610370565b8484546102e8565b825550505050565b600090565b6103ac61039f565b6103b781848461037a565b505050565b5b818110156103db576103d06000826103a4565b6001810190506103bd565b5050565b601f821115610420576103f1816102b6565b6103fa846102cb565b81016020851015610409578190505b61041d610415856102cb565b8301826103bc565b50505b505050565b600082821c905092915050565b600061044360001984600802610425565b1980831691505092915050565b600061045c8383610432565b9150826002028217905092915050565b6104758261024b565b67ffffffffffffffff81111561048e5761048d6100bc565b5b6104988254610285565b6104a38282856103df565b600060209050601f8311600181146104d657600084156104c4578287015190505b6104ce8582610450565b865550610536565b601f1984166104e4866102b6565b60005b8281101561050c578489015182556001820191506020850194506020810190506104e7565b868310156105295784890151610525601f891682610432565b8355505b6001600288020188555050505b505050505050565b6105ee8061054d6000396000f3fe608060405234801561001057600080fd5b50600436106100415760003560e01c806301339c211461004657806306fdde0314610050578063200d2ed21461006e575b600080fd5b61004e61008c565b005b6100586100d3565b604051610065919061027f565b60405180910390f35b610076610161565b604051610083919061027f565b60405180910390f35b6040518060400160405280600881526020017f6c6966742d6f6666000000000000000000000000000000000000000000000000815250600190816100d091906104e6565b50565b600080546100e0906102ff565b80601f016020809104026020016040519081016040528092919081815260200182805461010c906102ff565b80156101595780601f1061012e57610100808354040283529160200191610159565b820191906000526020600020905b81548152906001019060200180831161013c57829003601f168201915b505050505081565b6001805461016e906102ff565b80601f016020809104026020016040519081016040528092919081815260200182805461019a906102ff565b80156101e75780601f106101bc576101008083540402835291602001916101e7565b820191906000526020600020905b8154815290600101906020018083116101ca57829003601f168201915b505050505081565b600081519050919050565b600082825260208201905092915050565b60005b8381101561022957808201518184015260208101905061020e565b60008484015250505050565b6000601f19601f8301169050919050565b6000610251826101ef565b61025b81856101fa565b935061026b81856020860161020b565b61027481610235565b840191505092915050565b600060208201905081810360008301526102998184610246565b905092915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b6000600282049050600182168061031757607f821691505b60208210810361032a576103296102d0565b5b50919050565b60008190508160005260206000209050919050565b60006020601f8301049050919050565b600082821b905092915050565b6000600883026103927fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff82610355565b61039c8683610355565b95508019841693508086168417925050509392505050565b6000819050919050565b6000819050919050565b60006103e36103de6103d9846103b4565b6103be565b6103b4565b9050919050565b6000819050919050565b6103fd836103c8565b610411610409826103ea565b848454610362565b825550505050565b600090565b610426610419565b6104318184846103f4565b505050565b5b818110156104555761044a60008261041e565b600181019050610437565b5050565b601f82111561049a5761046b81610330565b61047484610345565b81016020851015610483578190505b61049761048f85610345565b830182610436565b50505b505050565b600082821c905092915050565b60006104bd6000198460080261049f565b1980831691505092915050565b60006104d683836104ac565b9150826002028217905092915050565b6104ef826101ef565b67ffffffffffffffff811115610508576105076102a1565b5b61051282546102ff565b61051d828285610459565b600060209050601f831160018114610550576000841561053e578287015190505b61054885826104ca565b8655506105b0565b601f19841661055e86610330565b60005b8281101561058657848901518255600182019150602085019450602081019050610561565b868310156105a3578489015161059f601f8916826104ac565b8355505b6001600288020188555050505b50505050505056fea26469706673582212203ff05ded10e809f1630cbb2d41a21831212741d4dfb020d7d8ef63b9118565cb64736f6c634300081c0033",
+}
+
+// RocketABI is the input ABI used to generate the binding from.
+// Deprecated: Use RocketMetaData.ABI instead.
+var RocketABI = RocketMetaData.ABI
+
+// RocketBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use RocketMetaData.Bin instead.
+var RocketBin = RocketMetaData.Bin
+
+// DeployRocket deploys a new Ethereum contract, binding an instance of Rocket to it.
+func DeployRocket(auth *bind.TransactOpts, backend bind.ContractBackend, _name string) (common.Address, *types.Transaction, *Rocket, error) {
+	parsed, err := RocketMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(RocketBin), backend, _name)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Rocket{RocketCaller: RocketCaller{contract: contract}, RocketTransactor: RocketTransactor{contract: contract}, RocketFilterer: RocketFilterer{contract: contract}}, nil
+}
+
+// Rocket is an auto generated Go binding around an Ethereum contract.
+type Rocket struct {
+	RocketCaller     // Read-only binding to the contract
+	RocketTransactor // Write-only binding to the contract
+	RocketFilterer   // Log filterer for contract events
+}
+
+// RocketCaller is an auto generated read-only Go binding around an Ethereum contract.
+type RocketCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RocketTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type RocketTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RocketFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type RocketFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RocketSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type RocketSession struct {
+	Contract     *Rocket           // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// RocketCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type RocketCallerSession struct {
+	Contract *RocketCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts // Call options to use throughout this session
+}
+
+// RocketTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type RocketTransactorSession struct {
+	Contract     *RocketTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// RocketRaw is an auto generated low-level Go binding around an Ethereum contract.
+type RocketRaw struct {
+	Contract *Rocket // Generic contract binding to access the raw methods on
+}
+
+// RocketCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type RocketCallerRaw struct {
+	Contract *RocketCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// RocketTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type RocketTransactorRaw struct {
+	Contract *RocketTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewRocket creates a new instance of Rocket, bound to a specific deployed contract.
+func NewRocket(address common.Address, backend bind.ContractBackend) (*Rocket, error) {
+	contract, err := bindRocket(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Rocket{RocketCaller: RocketCaller{contract: contract}, RocketTransactor: RocketTransactor{contract: contract}, RocketFilterer: RocketFilterer{contract: contract}}, nil
+}
+
+// NewRocketCaller creates a new read-only instance of Rocket, bound to a specific deployed contract.
+func NewRocketCaller(address common.Address, caller bind.ContractCaller) (*RocketCaller, error) {
+	contract, err := bindRocket(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RocketCaller{contract: contract}, nil
+}
+
+// NewRocketTransactor creates a new write-only instance of Rocket, bound to a specific deployed contract.
+func NewRocketTransactor(address common.Address, transactor bind.ContractTransactor) (*RocketTransactor, error) {
+	contract, err := bindRocket(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RocketTransactor{contract: contract}, nil
+}
+
+// NewRocketFilterer creates a new log filterer instance of Rocket, bound to a specific deployed contract.
+func NewRocketFilterer(address common.Address, filterer bind.ContractFilterer) (*RocketFilterer, error) {
+	contract, err := bindRocket(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &RocketFilterer{contract: contract}, nil
+}
+
+// bindRocket binds a generic wrapper to an already deployed contract.
+func bindRocket(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := RocketMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Rocket *RocketRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Rocket.Contract.RocketCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Rocket *RocketRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Rocket.Contract.RocketTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Rocket *RocketRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Rocket.Contract.RocketTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Rocket *RocketCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Rocket.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Rocket *RocketTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Rocket.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Rocket *RocketTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Rocket.Contract.contract.Transact(opts, method, params...)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_Rocket *RocketCaller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _Rocket.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_Rocket *RocketSession) Name() (string, error) {
+	return _Rocket.Contract.Name(&_Rocket.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_Rocket *RocketCallerSession) Name() (string, error) {
+	return _Rocket.Contract.Name(&_Rocket.CallOpts)
+}
+
+// Status is a free data retrieval call binding the contract method 0x200d2ed2.
+//
+// Solidity: function status() view returns(string)
+func (_Rocket *RocketCaller) Status(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _Rocket.contract.Call(opts, &out, "status")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Status is a free data retrieval call binding the contract method 0x200d2ed2.
+//
+// Solidity: function status() view returns(string)
+func (_Rocket *RocketSession) Status() (string, error) {
+	return _Rocket.Contract.Status(&_Rocket.CallOpts)
+}
+
+// Status is a free data retrieval call binding the contract method 0x200d2ed2.
+//
+// Solidity: function status() view returns(string)
+func (_Rocket *RocketCallerSession) Status() (string, error) {
+	return _Rocket.Contract.Status(&_Rocket.CallOpts)
+}
+
+// Launch is a paid mutator transaction binding the contract method 0x01339c21.
+//
+// Solidity: function launch() returns()
+func (_Rocket *RocketTransactor) Launch(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Rocket.contract.Transact(opts, "launch")
+}
+
+// Launch is a paid mutator transaction binding the contract method 0x01339c21.
+//
+// Solidity: function launch() returns()
+func (_Rocket *RocketSession) Launch() (*types.Transaction, error) {
+	return _Rocket.Contract.Launch(&_Rocket.TransactOpts)
+}
+
+// Launch is a paid mutator transaction binding the contract method 0x01339c21.
+//
+// Solidity: function launch() returns()
+func (_Rocket *RocketTransactorSession) Launch() (*types.Transaction, error) {
+	return _Rocket.Contract.Launch(&_Rocket.TransactOpts)
+}
@@ -0,0 +1,391 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// VotingProposalProposal is an auto generated low-level Go binding around an user-defined struct.
+type VotingProposalProposal struct {
+	Description    string
+	Approve        *big.Int
+	Reject         *big.Int
+	Pass           *big.Int
+	TotalVoteToEnd *big.Int
+	CurrentState   bool
+	IsActive       bool
+}
+
+// VotingProposalMetaData contains all meta data concerning the VotingProposal contract.
+var VotingProposalMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_description\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"_total_vote_to_end\",\"type\":\"uint256\"}],\"name\":\"create\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getCurrentProposal\",\"outputs\":[{\"components\":[{\"internalType\":\"string\",\"name\":\"description\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"approve\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"reject\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"pass\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"total_vote_to_end\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"current_state\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"is_active\",\"type\":\"bool\"}],\"internalType\":\"structVotingProposal.Proposal\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"number\",\"type\":\"uint256\"}],\"name\":\"getProposal\",\"outputs\":[{\"components\":[{\"internalType\":\"string\",\"name\":\"description\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"approve\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"reject\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"pass\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"total_vote_to_end\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"current_state\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"is_active\",\"type\":\"bool\"}],\"internalType\":\"structVotingProposal.Proposal\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_address\",\"type\":\"address\"}],\"name\":\"hasVoted\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"new_owner\",\"type\":\"address\"}],\"name\":\"setOwner\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"terminateProposal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint8\",\"name\":\"choice\",\"type\":\"uint8\"}],\"name\":\"vote\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x6080604052348015600f57600080fd5b50336000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055506001339080600181540180825580915050600190039060005260206000200160009091909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555061153e806100c26000396000f3fe608060405234801561001057600080fd5b506004361061007d5760003560e01c806346f81a871161005b57806346f81a87146100ec578063b3f98adc14610108578063c7f758a814610124578063dbddc7aa146101545761007d565b806309eef43e1461008257806313af4035146100b257806341ec6870146100ce575b600080fd5b61009c60048036038101906100979190610c5e565b61015e565b6040516100a99190610ca6565b60405180910390f35b6100cc60048036038101906100c79190610c5e565b610206565b005b6100d66102a1565b6040516100e39190610e15565b60405180910390f35b61010660048036038101906101019190610ec8565b6103be565b005b610122600480360381019061011d9190610f61565b61054b565b005b61013e60048036038101906101399190610f8e565b610862565b60405161014b9190610e15565b60405180910390f35b61015c61097f565b005b600080600090505b6001805490508110156101fb578273ffffffffffffffffffffffffffffffffffffffff166001828154811061019e5761019d610fbb565b5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16036101ee576001915050610201565b8080600101915050610166565b50600090505b919050565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461025e57600080fd5b806000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555050565b6102a9610b0e565b6003600060025481526020019081526020016000206040518060e00160405290816000820180546102d990611019565b80601f016020809104026020016040519081016040528092919081815260200182805461030590611019565b80156103525780601f1061032757610100808354040283529160200191610352565b820191906000526020600020905b81548152906001019060200180831161033557829003601f168201915b50505050508152602001600182015481526020016002820154815260200160038201548152602001600482015481526020016005820160009054906101000a900460ff161515151581526020016005820160019054906101000a900460ff161515151581525050905090565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161461041657600080fd5b6001600260008282546104299190611079565b925050819055506040518060e0016040528084848080601f016020809104026020016040519081016040528093929190818152602001838380828437600081840152601f19601f8201169050808301925050505050505081526020016000815260200160008152602001600081526020018281526020016000151581526020016001151581525060036000600254815260200190815260200160002060008201518160000190816104da9190611288565b506020820151816001015560408201518160020155606082015181600301556080820151816004015560a08201518160050160006101000a81548160ff02191690831515021790555060c08201518160050160016101000a81548160ff021916908315150217905550905050505050565b6001151560036000600254815260200190815260200160002060050160019054906101000a900460ff161515146105b7576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016105ae906113b7565b60405180910390fd5b336105c18161015e565b15610601576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016105f890611423565b60405180910390fd5b6000600360006002548152602001908152602001600020905060008160030154826002015483600101546106359190611079565b61063f9190611079565b90506001339080600181540180825580915050600190039060005260206000200160009091909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555060018460ff16036106f35760018260010160008282546106c49190611079565b925050819055506106d3610a76565b8260050160006101000a81548160ff02191690831515021790555061078f565b60028460ff16036107425760018260020160008282546107139190611079565b92505081905550610722610a76565b8260050160006101000a81548160ff02191690831515021790555061078e565b60008460ff160361078d5760018260030160008282546107629190611079565b92505081905550610771610a76565b8260050160006101000a81548160ff0219169083151502179055505b5b5b60018183600401546107a19190611443565b1480156107cd575060018460ff1614806107be575060028460ff16145b806107cc575060008460ff16145b5b1561085c5760008260050160016101000a81548160ff021916908315150217905550604051806020016040528060008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815250600190600161085a929190610b4f565b505b50505050565b61086a610b0e565b600360008381526020019081526020016000206040518060e001604052908160008201805461089890611019565b80601f01602080910402602001604051908101604052809291908181526020018280546108c490611019565b80156109115780601f106108e657610100808354040283529160200191610911565b820191906000526020600020905b8154815290600101906020018083116108f457829003601f168201915b50505050508152602001600182015481526020016002820154815260200160038201548152602001600482015481526020016005820160009054906101000a900460ff161515151581526020016005820160019054906101000a900460ff1615151515815250509050919050565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16146109d757600080fd5b6001151560036000600254815260200190815260200160002060050160019054906101000a900460ff16151514610a43576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610a3a906113b7565b60405180910390fd5b600060036000600254815260200190815260200160002060050160016101000a81548160ff021916908315150217905550565b6000806003600060025481526020019081526020016000209050600081600101549050600082600201549050600083600301549050600160028560030154610abe91906114a6565b03610ad357600181610ad09190611079565b90505b600281610ae091906114d7565b90508082610aee9190611079565b831115610b02576001945050505050610b0b565b60009450505050505b90565b6040518060e0016040528060608152602001600081526020016000815260200160008152602001600081526020016000151581526020016000151581525090565b828054828255906000526020600020908101928215610bc8579160200282015b82811115610bc75782518260006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555091602001919060010190610b6f565b5b509050610bd59190610bd9565b5090565b5b80821115610bf2576000816000905550600101610bda565b5090565b600080fd5b600080fd5b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b6000610c2b82610c00565b9050919050565b610c3b81610c20565b8114610c4657600080fd5b50565b600081359050610c5881610c32565b92915050565b600060208284031215610c7457610c73610bf6565b5b6000610c8284828501610c49565b91505092915050565b60008115159050919050565b610ca081610c8b565b82525050565b6000602082019050610cbb6000830184610c97565b92915050565b600081519050919050565b600082825260208201905092915050565b60005b83811015610cfb578082015181840152602081019050610ce0565b60008484015250505050565b6000601f19601f8301169050919050565b6000610d2382610cc1565b610d2d8185610ccc565b9350610d3d818560208601610cdd565b610d4681610d07565b840191505092915050565b6000819050919050565b610d6481610d51565b82525050565b610d7381610c8b565b82525050565b600060e0830160008301518482036000860152610d968282610d18565b9150506020830151610dab6020860182610d5b565b506040830151610dbe6040860182610d5b565b506060830151610dd16060860182610d5b565b506080830151610de46080860182610d5b565b5060a0830151610df760a0860182610d6a565b5060c0830151610e0a60c0860182610d6a565b508091505092915050565b60006020820190508181036000830152610e2f8184610d79565b905092915050565b600080fd5b600080fd5b600080fd5b60008083601f840112610e5c57610e5b610e37565b5b8235905067ffffffffffffffff811115610e7957610e78610e3c565b5b602083019150836001820283011115610e9557610e94610e41565b5b9250929050565b610ea581610d51565b8114610eb057600080fd5b50565b600081359050610ec281610e9c565b92915050565b600080600060408486031215610ee157610ee0610bf6565b5b600084013567ffffffffffffffff811115610eff57610efe610bfb565b5b610f0b86828701610e46565b93509350506020610f1e86828701610eb3565b9150509250925092565b600060ff82169050919050565b610f3e81610f28565b8114610f4957600080fd5b50565b600081359050610f5b81610f35565b92915050565b600060208284031215610f7757610f76610bf6565b5b6000610f8584828501610f4c565b91505092915050565b600060208284031215610fa457610fa3610bf6565b5b6000610fb284828501610eb3565b91505092915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b6000600282049050600182168061103157607f821691505b60208210810361104457611043610fea565b5b50919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b600061108482610d51565b915061108f83610d51565b92508282019050808211156110a7576110a661104a565b5b92915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b60008190508160005260206000209050919050565b60006020601f8301049050919050565b600082821b905092915050565b60006008830261113e7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff82611101565b6111488683611101565b95508019841693508086168417925050509392505050565b6000819050919050565b600061118561118061117b84610d51565b611160565b610d51565b9050919050565b6000819050919050565b61119f8361116a565b6111b36111ab8261118c565b84845461110e565b825550505050565b600090565b6111c86111bb565b6111d3818484611196565b505050565b5b818110156111f7576111ec6000826111c0565b6001810190506111d9565b5050565b601f82111561123c5761120d816110dc565b611216846110f1565b81016020851015611225578190505b611239611231856110f1565b8301826111d8565b50505b505050565b600082821c905092915050565b600061125f60001984600802611241565b1980831691505092915050565b6000611278838361124e565b9150826002028217905092915050565b61129182610cc1565b67ffffffffffffffff8111156112aa576112a96110ad565b5b6112b48254611019565b6112bf8282856111fb565b600060209050601f8311600181146112f257600084156112e0578287015190505b6112ea858261126c565b865550611352565b601f198416611300866110dc565b60005b8281101561132857848901518255600182019150602085019450602081019050611303565b868310156113455784890151611341601f89168261124e565b8355505b6001600288020188555050505b505050505050565b600082825260208201905092915050565b7f7468652070726f706f73616c206973206e6f7420616374697665000000000000600082015250565b60006113a1601a8361135a565b91506113ac8261136b565b602082019050919050565b600060208201905081810360008301526113d081611394565b9050919050565b7f616464726573732068617320616c726561647920766f74656400000000000000600082015250565b600061140d60198361135a565b9150611418826113d7565b602082019050919050565b6000602082019050818103600083015261143c81611400565b9050919050565b600061144e82610d51565b915061145983610d51565b92508282039050818111156114715761147061104a565b5b92915050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601260045260246000fd5b60006114b182610d51565b91506114bc83610d51565b9250826114cc576114cb611477565b5b828206905092915050565b60006114e282610d51565b91506114ed83610d51565b9250826114fd576114fc611477565b5b82820490509291505056fea264697066735822122095330ab9516aac5cb65d2761c9c14fbf241c1a7f44dde50f86d921d571fa656564736f6c634300081c0033",
+}
+
+// VotingProposalABI is the input ABI used to generate the binding from.
+// Deprecated: Use VotingProposalMetaData.ABI instead.
+var VotingProposalABI = VotingProposalMetaData.ABI
+
+// VotingProposalBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use VotingProposalMetaData.Bin instead.
+var VotingProposalBin = VotingProposalMetaData.Bin
+
+// DeployVotingProposal deploys a new Ethereum contract, binding an instance of VotingProposal to it.
+func DeployVotingProposal(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *VotingProposal, error) {
+	parsed, err := VotingProposalMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(VotingProposalBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &VotingProposal{VotingProposalCaller: VotingProposalCaller{contract: contract}, VotingProposalTransactor: VotingProposalTransactor{contract: contract}, VotingProposalFilterer: VotingProposalFilterer{contract: contract}}, nil
+}
+
+// VotingProposal is an auto generated Go binding around an Ethereum contract.
+type VotingProposal struct {
+	VotingProposalCaller     // Read-only binding to the contract
+	VotingProposalTransactor // Write-only binding to the contract
+	VotingProposalFilterer   // Log filterer for contract events
+}
+
+// VotingProposalCaller is an auto generated read-only Go binding around an Ethereum contract.
+type VotingProposalCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// VotingProposalTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type VotingProposalTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// VotingProposalFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type VotingProposalFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// VotingProposalSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type VotingProposalSession struct {
+	Contract     *VotingProposal   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// VotingProposalCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type VotingProposalCallerSession struct {
+	Contract *VotingProposalCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// VotingProposalTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type VotingProposalTransactorSession struct {
+	Contract     *VotingProposalTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// VotingProposalRaw is an auto generated low-level Go binding around an Ethereum contract.
+type VotingProposalRaw struct {
+	Contract *VotingProposal // Generic contract binding to access the raw methods on
+}
+
+// VotingProposalCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type VotingProposalCallerRaw struct {
+	Contract *VotingProposalCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// VotingProposalTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type VotingProposalTransactorRaw struct {
+	Contract *VotingProposalTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewVotingProposal creates a new instance of VotingProposal, bound to a specific deployed contract.
+func NewVotingProposal(address common.Address, backend bind.ContractBackend) (*VotingProposal, error) {
+	contract, err := bindVotingProposal(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingProposal{VotingProposalCaller: VotingProposalCaller{contract: contract}, VotingProposalTransactor: VotingProposalTransactor{contract: contract}, VotingProposalFilterer: VotingProposalFilterer{contract: contract}}, nil
+}
+
+// NewVotingProposalCaller creates a new read-only instance of VotingProposal, bound to a specific deployed contract.
+func NewVotingProposalCaller(address common.Address, caller bind.ContractCaller) (*VotingProposalCaller, error) {
+	contract, err := bindVotingProposal(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingProposalCaller{contract: contract}, nil
+}
+
+// NewVotingProposalTransactor creates a new write-only instance of VotingProposal, bound to a specific deployed contract.
+func NewVotingProposalTransactor(address common.Address, transactor bind.ContractTransactor) (*VotingProposalTransactor, error) {
+	contract, err := bindVotingProposal(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingProposalTransactor{contract: contract}, nil
+}
+
+// NewVotingProposalFilterer creates a new log filterer instance of VotingProposal, bound to a specific deployed contract.
+func NewVotingProposalFilterer(address common.Address, filterer bind.ContractFilterer) (*VotingProposalFilterer, error) {
+	contract, err := bindVotingProposal(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &VotingProposalFilterer{contract: contract}, nil
+}
+
+// bindVotingProposal binds a generic wrapper to an already deployed contract.
+func bindVotingProposal(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := VotingProposalMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_VotingProposal *VotingProposalRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _VotingProposal.Contract.VotingProposalCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_VotingProposal *VotingProposalRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _VotingProposal.Contract.VotingProposalTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_VotingProposal *VotingProposalRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _VotingProposal.Contract.VotingProposalTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_VotingProposal *VotingProposalCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _VotingProposal.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_VotingProposal *VotingProposalTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _VotingProposal.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_VotingProposal *VotingProposalTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _VotingProposal.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetCurrentProposal is a free data retrieval call binding the contract method 0x41ec6870.
+//
+// Solidity: function getCurrentProposal() view returns((string,uint256,uint256,uint256,uint256,bool,bool))
+func (_VotingProposal *VotingProposalCaller) GetCurrentProposal(opts *bind.CallOpts) (VotingProposalProposal, error) {
+	var out []interface{}
+	err := _VotingProposal.contract.Call(opts, &out, "getCurrentProposal")
+
+	if err != nil {
+		return *new(VotingProposalProposal), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(VotingProposalProposal)).(*VotingProposalProposal)
+
+	return out0, err
+
+}
+
+// GetCurrentProposal is a free data retrieval call binding the contract method 0x41ec6870.
+//
+// Solidity: function getCurrentProposal() view returns((string,uint256,uint256,uint256,uint256,bool,bool))
+func (_VotingProposal *VotingProposalSession) GetCurrentProposal() (VotingProposalProposal, error) {
+	return _VotingProposal.Contract.GetCurrentProposal(&_VotingProposal.CallOpts)
+}
+
+// GetCurrentProposal is a free data retrieval call binding the contract method 0x41ec6870.
+//
+// Solidity: function getCurrentProposal() view returns((string,uint256,uint256,uint256,uint256,bool,bool))
+func (_VotingProposal *VotingProposalCallerSession) GetCurrentProposal() (VotingProposalProposal, error) {
+	return _VotingProposal.Contract.GetCurrentProposal(&_VotingProposal.CallOpts)
+}
+
+// GetProposal is a free data retrieval call binding the contract method 0xc7f758a8.
+//
+// Solidity: function getProposal(uint256 number) view returns((string,uint256,uint256,uint256,uint256,bool,bool))
+func (_VotingProposal *VotingProposalCaller) GetProposal(opts *bind.CallOpts, number *big.Int) (VotingProposalProposal, error) {
+	var out []interface{}
+	err := _VotingProposal.contract.Call(opts, &out, "getProposal", number)
+
+	if err != nil {
+		return *new(VotingProposalProposal), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(VotingProposalProposal)).(*VotingProposalProposal)
+
+	return out0, err
+
+}
+
+// GetProposal is a free data retrieval call binding the contract method 0xc7f758a8.
+//
+// Solidity: function getProposal(uint256 number) view returns((string,uint256,uint256,uint256,uint256,bool,bool))
+func (_VotingProposal *VotingProposalSession) GetProposal(number *big.Int) (VotingProposalProposal, error) {
+	return _VotingProposal.Contract.GetProposal(&_VotingProposal.CallOpts, number)
+}
+
+// GetProposal is a free data retrieval call binding the contract method 0xc7f758a8.
+//
+// Solidity: function getProposal(uint256 number) view returns((string,uint256,uint256,uint256,uint256,bool,bool))
+func (_VotingProposal *VotingProposalCallerSession) GetProposal(number *big.Int) (VotingProposalProposal, error) {
+	return _VotingProposal.Contract.GetProposal(&_VotingProposal.CallOpts, number)
+}
+
+// HasVoted is a free data retrieval call binding the contract method 0x09eef43e.
+//
+// Solidity: function hasVoted(address _address) view returns(bool)
+func (_VotingProposal *VotingProposalCaller) HasVoted(opts *bind.CallOpts, _address common.Address) (bool, error) {
+	var out []interface{}
+	err := _VotingProposal.contract.Call(opts, &out, "hasVoted", _address)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// HasVoted is a free data retrieval call binding the contract method 0x09eef43e.
+//
+// Solidity: function hasVoted(address _address) view returns(bool)
+func (_VotingProposal *VotingProposalSession) HasVoted(_address common.Address) (bool, error) {
+	return _VotingProposal.Contract.HasVoted(&_VotingProposal.CallOpts, _address)
+}
+
+// HasVoted is a free data retrieval call binding the contract method 0x09eef43e.
+//
+// Solidity: function hasVoted(address _address) view returns(bool)
+func (_VotingProposal *VotingProposalCallerSession) HasVoted(_address common.Address) (bool, error) {
+	return _VotingProposal.Contract.HasVoted(&_VotingProposal.CallOpts, _address)
+}
+
+// Create is a paid mutator transaction binding the contract method 0x46f81a87.
+//
+// Solidity: function create(string _description, uint256 _total_vote_to_end) returns()
+func (_VotingProposal *VotingProposalTransactor) Create(opts *bind.TransactOpts, _description string, _total_vote_to_end *big.Int) (*types.Transaction, error) {
+	return _VotingProposal.contract.Transact(opts, "create", _description, _total_vote_to_end)
+}
+
+// Create is a paid mutator transaction binding the contract method 0x46f81a87.
+//
+// Solidity: function create(string _description, uint256 _total_vote_to_end) returns()
+func (_VotingProposal *VotingProposalSession) Create(_description string, _total_vote_to_end *big.Int) (*types.Transaction, error) {
+	return _VotingProposal.Contract.Create(&_VotingProposal.TransactOpts, _description, _total_vote_to_end)
+}
+
+// Create is a paid mutator transaction binding the contract method 0x46f81a87.
+//
+// Solidity: function create(string _description, uint256 _total_vote_to_end) returns()
+func (_VotingProposal *VotingProposalTransactorSession) Create(_description string, _total_vote_to_end *big.Int) (*types.Transaction, error) {
+	return _VotingProposal.Contract.Create(&_VotingProposal.TransactOpts, _description, _total_vote_to_end)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x13af4035.
+//
+// Solidity: function setOwner(address new_owner) returns()
+func (_VotingProposal *VotingProposalTransactor) SetOwner(opts *bind.TransactOpts, new_owner common.Address) (*types.Transaction, error) {
+	return _VotingProposal.contract.Transact(opts, "setOwner", new_owner)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x13af4035.
+//
+// Solidity: function setOwner(address new_owner) returns()
+func (_VotingProposal *VotingProposalSession) SetOwner(new_owner common.Address) (*types.Transaction, error) {
+	return _VotingProposal.Contract.SetOwner(&_VotingProposal.TransactOpts, new_owner)
+}
+
+// SetOwner is a paid mutator transaction binding the contract method 0x13af4035.
+//
+// Solidity: function setOwner(address new_owner) returns()
+func (_VotingProposal *VotingProposalTransactorSession) SetOwner(new_owner common.Address) (*types.Transaction, error) {
+	return _VotingProposal.Contract.SetOwner(&_VotingProposal.TransactOpts, new_owner)
+}
+
+// TerminateProposal is a paid mutator transaction binding the contract method 0xdbddc7aa.
+//
+// Solidity: function terminateProposal() returns()
+func (_VotingProposal *VotingProposalTransactor) TerminateProposal(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _VotingProposal.contract.Transact(opts, "terminateProposal")
+}
+
+// TerminateProposal is a paid mutator transaction binding the contract method 0xdbddc7aa.
+//
+// Solidity: function terminateProposal() returns()
+func (_VotingProposal *VotingProposalSession) TerminateProposal() (*types.Transaction, error) {
+	return _VotingProposal.Contract.TerminateProposal(&_VotingProposal.TransactOpts)
+}
+
+// TerminateProposal is a paid mutator transaction binding the contract method 0xdbddc7aa.
+//
+// Solidity: function terminateProposal() returns()
+func (_VotingProposal *VotingProposalTransactorSession) TerminateProposal() (*types.Transaction, error) {
+	return _VotingProposal.Contract.TerminateProposal(&_VotingProposal.TransactOpts)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0xb3f98adc.
+//
+// Solidity: function vote(uint8 choice) returns()
+func (_VotingProposal *VotingProposalTransactor) Vote(opts *bind.TransactOpts, choice uint8) (*types.Transaction, error) {
+	return _VotingProposal.contract.Transact(opts, "vote", choice)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0xb3f98adc.
+//
+// Solidity: function vote(uint8 choice) returns()
+func (_VotingProposal *VotingProposalSession) Vote(choice uint8) (*types.Transaction, error) {
+	return _VotingProposal.Contract.Vote(&_VotingProposal.TransactOpts, choice)
+}
+
+// Vote is a paid mutator transaction binding the contract method 0xb3f98adc.
+//
+// Solidity: function vote(uint8 choice) returns()
+func (_VotingProposal *VotingProposalTransactorSession) Vote(choice uint8) (*types.Transaction, error) {
+	return _VotingProposal.Contract.Vote(&_VotingProposal.TransactOpts, choice)
+}
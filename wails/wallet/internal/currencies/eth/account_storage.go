@@ -3,7 +3,10 @@ package eth
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 type AccountStorage struct {
@@ -63,8 +66,28 @@ func (a *AccountStorage) GetAccountAddress(ctx context.Context, accountIndex int
 	return address, nil
 }
 
+// GetAccountIndex returns the index of the account with the given address, and false when
+// no account of the wallet has it. Addresses are stored checksummed.
+func (a *AccountStorage) GetAccountIndex(ctx context.Context, address string) (int, bool, error) {
+	var accountIndex int
+	err := a.db.QueryRowContext(
+		ctx,
+		"SELECT accountIndex FROM ethAccounts WHERE address = ?",
+		common.HexToAddress(address).Hex(),
+	).Scan(&accountIndex)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, fmt.Errorf("error retrieving ETH account %s from DB: %w", address, err)
+	}
+
+	return accountIndex, true, nil
+}
+
 func (a *AccountStorage) GetAllAccounts(ctx context.Context) (map[int]string, error) {
-	rows, err := a.db.QueryContext(ctx, "SELECT address, accountIndex FROM ethAccounts LIMIT 10")
+	rows, err := a.db.QueryContext(ctx, "SELECT address, accountIndex FROM ethAccounts ORDER BY accountIndex")
 	accounts := make(map[int]string)
 	if err != nil {
 		return nil, fmt.Errorf("error querying ethAccounts: %w", err)
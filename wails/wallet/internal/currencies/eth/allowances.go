@@ -0,0 +1,307 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+	"wallet/internal/contracts"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// unlimitedAllowance is the allowance from which approvals are shown as unlimited. dApps
+// asking for unlimited approvals use the largest uint256, or at least an amount beyond
+// any real supply.
+var unlimitedAllowance = new(big.Int).Lsh(big.NewInt(1), 128)
+
+// Allowance is the amount of an ERC-20 token a spender can still transfer from an owner.
+type Allowance struct {
+	Token      string `json:"token"`
+	Symbol     string `json:"symbol"`
+	Decimals   uint8  `json:"decimals"`
+	Owner      string `json:"owner"`
+	OwnerIndex int    `json:"ownerIndex"`
+	Spender    string `json:"spender"`
+	// Units is the allowance in the smallest unit of the token, Amount in the token.
+	Units     string `json:"units"`
+	Amount    string `json:"amount"`
+	Unlimited bool   `json:"unlimited"`
+}
+
+type allowanceKey struct {
+	token, owner, spender common.Address
+}
+
+// ScanAllowances finds the ERC-20 Approval events of the owners since fromBlock, and
+// returns the allowances they still grant, see readAllowances.
+func (c *Client) ScanAllowances(ctx context.Context, owners []common.Address, fromBlock *big.Int) ([]Allowance, error) {
+	if len(owners) == 0 {
+		return nil, nil
+	}
+
+	topics, err := approvalTopics(owners)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := NewContractBackend(c).FilterLogs(ctx, ethereum.FilterQuery{FromBlock: fromBlock, Topics: topics})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan approvals: %w", err)
+	}
+
+	return c.readAllowances(ctx, approvalKeys(logs))
+}
+
+// approvalTopics builds the eth_getLogs topics matching the Approval events of the owners.
+func approvalTopics(owners []common.Address) ([][]common.Hash, error) {
+	tokenABI, err := contracts.DemoTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ERC-20 ABI: %w", err)
+	}
+
+	ownerTopics := make([]common.Hash, len(owners))
+	for i, owner := range owners {
+		ownerTopics[i] = common.BytesToHash(owner.Bytes())
+	}
+
+	return [][]common.Hash{{tokenABI.Events["Approval"].ID}, ownerTopics}, nil
+}
+
+// approvalKeys returns the token, owner and spender of each ERC-20 Approval log, once.
+func approvalKeys(logs []types.Log) []allowanceKey {
+	var keys []allowanceKey
+	seen := make(map[allowanceKey]bool)
+	for _, log := range logs {
+		// ERC-721 approvals also index the token ID.
+		if len(log.Topics) != 3 || log.Removed {
+			continue
+		}
+
+		key := allowanceKey{
+			token:   log.Address,
+			owner:   common.BytesToAddress(log.Topics[1].Bytes()),
+			spender: common.BytesToAddress(log.Topics[2].Bytes()),
+		}
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// readAllowances returns the allowances of keys that are not zero. The current allowances,
+// decimals and symbols are read in a single batch. Contracts that do not answer as ERC-20
+// tokens, such as ERC-721 collections that emit an Approval event of the same signature,
+// are skipped.
+func (c *Client) readAllowances(ctx context.Context, keys []allowanceKey) ([]Allowance, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	tokenABI, err := contracts.DemoTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ERC-20 ABI: %w", err)
+	}
+
+	tokens := make(map[common.Address]bool)
+	for _, key := range keys {
+		tokens[key.token] = true
+	}
+
+	// The allowances come first in the batch, then the decimals and symbol of each token.
+	batch := make([]BatchElem, 0, len(keys)+2*len(tokens))
+	results := make([]hexutil.Bytes, len(keys)+2*len(tokens))
+	for _, key := range keys {
+		batch = append(batch, tokenCall(tokenABI, key.token, &results[len(batch)], "allowance", key.owner, key.spender))
+	}
+
+	metadata := make(map[common.Address]int, len(tokens))
+	for token := range tokens {
+		metadata[token] = len(batch)
+		batch = append(batch,
+			tokenCall(tokenABI, token, &results[len(batch)], "decimals"),
+			tokenCall(tokenABI, token, &results[len(batch)+1], "symbol"),
+		)
+	}
+
+	err = c.BatchCall(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allowances: %w", err)
+	}
+
+	var allowances []Allowance
+	for i, key := range keys {
+		m := metadata[key.token]
+		if batch[i].Error != nil || batch[m].Error != nil || batch[m+1].Error != nil {
+			continue
+		}
+
+		amount, ok := unpackTokenValue(tokenABI, "allowance", results[i]).(*big.Int)
+		if !ok || amount.Sign() == 0 {
+			continue
+		}
+
+		decimals, ok := unpackTokenValue(tokenABI, "decimals", results[m]).(uint8)
+		if !ok {
+			continue
+		}
+
+		symbol, ok := unpackTokenValue(tokenABI, "symbol", results[m+1]).(string)
+		if !ok {
+			continue
+		}
+
+		allowances = append(allowances, Allowance{
+			Token:     key.token.Hex(),
+			Symbol:    symbol,
+			Decimals:  decimals,
+			Owner:     key.owner.Hex(),
+			Spender:   key.spender.Hex(),
+			Units:     amount.String(),
+			Amount:    NewAmount(amount, decimals).String(),
+			Unlimited: amount.Cmp(unlimitedAllowance) >= 0,
+		})
+	}
+
+	sort.SliceStable(allowances, func(i, j int) bool {
+		if allowances[i].Token != allowances[j].Token {
+			return allowances[i].Token < allowances[j].Token
+		}
+		if allowances[i].Owner != allowances[j].Owner {
+			return allowances[i].Owner < allowances[j].Owner
+		}
+		return allowances[i].Spender < allowances[j].Spender
+	})
+
+	return allowances, nil
+}
+
+// tokenCall builds the eth_call of a token method for a batch.
+func tokenCall(
+	tokenABI *abi.ABI,
+	token common.Address,
+	result *hexutil.Bytes,
+	method string,
+	args ...interface{},
+) BatchElem {
+	data, _ := tokenABI.Pack(method, args...)
+	callObject := map[string]interface{}{
+		"to":   token.Hex(),
+		"data": hexutil.Encode(data),
+	}
+
+	return BatchElem{Method: "eth_call", Params: []interface{}{callObject, "latest"}, Result: result}
+}
+
+// unpackTokenValue decodes the single value returned by a token method, or returns nil
+// when the contract answered something else.
+func unpackTokenValue(tokenABI *abi.ABI, method string, data []byte) interface{} {
+	values, err := tokenABI.Unpack(method, data)
+	if err != nil || len(values) != 1 {
+		return nil
+	}
+
+	return values[0]
+}
+
+// ScanAllowances lists the ERC-20 allowances granted by the accounts of the wallet. The
+// Approval events are read eventSyncRange blocks at a time, from the block the accounts
+// were last scanned up to, and the approved spenders are stored so the next scan only
+// reads the new blocks.
+func (a *MasterAccount) ScanAllowances() ([]Allowance, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, eventSyncTimeout)
+	defer cancel()
+
+	owners, indexes, err := a.accountAddresses(cliCtx)
+	if err != nil || len(owners) == 0 {
+		return nil, err
+	}
+
+	topics, err := approvalTopics(owners)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := a.eventDB.scanStart(cliCtx, approvalScan, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := a.client.BlockNumber(cliCtx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving block number: %w", err)
+	}
+
+	err = a.filterContractLogs(cliCtx, nil, topics, from, head, func(logs []types.Log, lastBlock uint64) error {
+		return a.eventDB.saveApprovals(cliCtx, owners, approvalKeys(logs), lastBlock)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning approvals: %w", err)
+	}
+
+	keys, err := a.eventDB.approvals(cliCtx, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	allowances, err := a.client.readAllowances(cliCtx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range allowances {
+		allowances[i].OwnerIndex = indexes[common.HexToAddress(allowances[i].Owner)]
+	}
+
+	return allowances, nil
+}
+
+// RevokeAllowanceRequest builds the approve(spender, 0) transaction revoking the
+// allowance an account granted to spender on an ERC-20 token.
+func (a *MasterAccount) RevokeAllowanceRequest(
+	contract, spender string,
+	accountIndex int,
+) (*TransactionRequest, error) {
+	if !common.IsHexAddress(spender) {
+		return nil, fmt.Errorf("invalid spender address: %s", spender)
+	}
+
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	token, err := a.contractAddress(cliCtx, strings.TrimSpace(contract))
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := a.accountDB.GetAccountAddress(cliCtx, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account address from DB: %w", err)
+	}
+
+	tokenABI, err := contracts.DemoTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ERC-20 ABI: %w", err)
+	}
+
+	data, err := tokenABI.Pack("approve", common.HexToAddress(spender), new(big.Int))
+	if err != nil {
+		return nil, fmt.Errorf("error encoding approval: %w", err)
+	}
+
+	return &TransactionRequest{
+		From:  common.HexToAddress(owner),
+		To:    &token,
+		Value: (*hexutil.Big)(new(big.Int)),
+		Data:  data,
+	}, nil
+}
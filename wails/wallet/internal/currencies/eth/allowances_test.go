@@ -0,0 +1,109 @@
+package eth_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"wallet/internal/contracts"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethmock"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+func TestScanAllowances(t *testing.T) {
+	tokenABI, err := contracts.DemoTokenMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("Failed to parse ABI: %v", err)
+	}
+
+	const (
+		spender1 = "0x00000000000000000000000000000000000A1100"
+		spender2 = "0x00000000000000000000000000000000000A1200"
+		// brokenToken emits Approval events but does not answer decimals().
+		brokenToken = "0x0000000000000000000000000000000000B20000"
+		collection  = "0x0000000000000000000000000000000000C30000"
+	)
+
+	approval := tokenABI.Events["Approval"].ID
+	topic := func(address string) common.Hash {
+		return common.BytesToHash(common.HexToAddress(address).Bytes())
+	}
+	approvalLog := func(contract, spender string, extra ...common.Hash) map[string]interface{} {
+		return map[string]interface{}{
+			"address":         contract,
+			"topics":          append([]common.Hash{approval, topic(hardhatAccount0), topic(spender)}, extra...),
+			"data":            "0x",
+			"transactionHash": common.Hash{}.Hex(),
+		}
+	}
+
+	node := newMockNode(t)
+	node.OnResult("eth_getLogs", []interface{}{
+		approvalLog(contractAddress, spender1),
+		approvalLog(contractAddress, spender2),
+		approvalLog(contractAddress, spender1),
+		approvalLog(brokenToken, spender1),
+		// An ERC-721 approval of token ID 1.
+		approvalLog(collection, spender1, common.BigToHash(big.NewInt(1))),
+	})
+
+	allowances := map[common.Address]*big.Int{
+		common.HexToAddress(spender1): math.MaxBig256,
+		common.HexToAddress(spender2): new(big.Int),
+	}
+	node.Handle("eth_call", func(req ethmock.Request) ethmock.Response {
+		var call struct {
+			To   common.Address `json:"to"`
+			Data hexutil.Bytes  `json:"data"`
+		}
+		err := json.Unmarshal(req.Params[0], &call)
+		if err != nil {
+			return ethmock.Response{Error: &ethmock.Error{Code: -32602, Message: "invalid call"}}
+		}
+
+		method, err := tokenABI.MethodById(call.Data)
+		if err != nil || call.To != common.HexToAddress(contractAddress) && method.Name != "allowance" {
+			return ethmock.Response{Error: &ethmock.Error{Code: 3, Message: "execution reverted"}}
+		}
+
+		var result []byte
+		switch method.Name {
+		case "allowance":
+			spender := common.BytesToAddress(call.Data[36:68])
+			result, _ = method.Outputs.Pack(allowances[spender])
+		case "decimals":
+			result, _ = method.Outputs.Pack(uint8(6))
+		case "symbol":
+			result, _ = method.Outputs.Pack("USDC")
+		}
+
+		return ethmock.Response{Result: hexutil.Encode(result)}
+	})
+
+	client := eth.NewClient(node.URL)
+	owners := []common.Address{common.HexToAddress(hardhatAccount0)}
+	got, err := client.ScanAllowances(context.Background(), owners, big.NewInt(0))
+	if err != nil {
+		t.Fatalf("Failed to scan allowances: %v", err)
+	}
+
+	// The revoked allowance of spender 2, the broken token and the collection are left out.
+	assertCorrectValue(t, got, []eth.Allowance{{
+		Token:     contractAddress,
+		Symbol:    "USDC",
+		Decimals:  6,
+		Owner:     hardhatAccount0,
+		Spender:   common.HexToAddress(spender1).Hex(),
+		Units:     math.MaxBig256.String(),
+		Amount:    eth.NewAmount(math.MaxBig256, 6).String(),
+		Unlimited: true,
+	}})
+
+	// The logs, then every allowance, decimals and symbol in a single batch.
+	assertCorrectValue(t, node.RoundTrips(), 2)
+	assertCorrectValue(t, len(node.Requests("eth_call")), 7)
+}
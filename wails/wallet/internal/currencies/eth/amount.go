@@ -0,0 +1,103 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Decimals of the ether units, as counted from wei.
+const (
+	WeiDecimals   = 0
+	GweiDecimals  = 9
+	EtherDecimals = 18
+)
+
+// Amount is an exact quantity of a token: an integer number of its smallest unit, e.g. wei,
+// and the number of decimals the token is displayed with.
+type Amount struct {
+	units    *big.Int
+	decimals uint8
+}
+
+// NewAmount returns the amount of units of the smallest unit of a token with decimals.
+func NewAmount(units *big.Int, decimals uint8) Amount {
+	return Amount{units: new(big.Int).Set(units), decimals: decimals}
+}
+
+// Ether returns the amount of wei in ether.
+func Ether(wei *big.Int) Amount {
+	return NewAmount(wei, EtherDecimals)
+}
+
+// ParseAmount parses a decimal amount, e.g. "0.1", of a token with decimals. Signs,
+// exponents and more decimal places than the token has are rejected rather than rounded.
+func ParseAmount(s string, decimals uint8) (Amount, error) {
+	whole, fraction, hasPoint := strings.Cut(strings.TrimSpace(s), ".")
+	if whole == "" || (hasPoint && fraction == "") || !isDigits(whole) || !isDigits(fraction) {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+
+	if len(fraction) > int(decimals) {
+		return Amount{}, fmt.Errorf("amount %q has more than %d decimal places", s, decimals)
+	}
+
+	units, ok := new(big.Int).SetString(whole+fraction+strings.Repeat("0", int(decimals)-len(fraction)), 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+
+	return Amount{units: units, decimals: decimals}, nil
+}
+
+// ParseEther parses an amount of ether, e.g. "0.1".
+func ParseEther(s string) (Amount, error) {
+	return ParseAmount(s, EtherDecimals)
+}
+
+// ParseGwei parses an amount of gwei, e.g. a gas price of "1.5". Its units are wei.
+func ParseGwei(s string) (Amount, error) {
+	return ParseAmount(s, GweiDecimals)
+}
+
+// Units returns the amount in the smallest unit of the token.
+func (a Amount) Units() *big.Int {
+	if a.units == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(a.units)
+}
+
+func (a Amount) Decimals() uint8 {
+	return a.decimals
+}
+
+// String formats the amount in decimal notation without trailing zeros, e.g. "0.1".
+func (a Amount) String() string {
+	units := a.Units()
+	sign := ""
+	if units.Sign() < 0 {
+		sign = "-"
+		units.Neg(units)
+	}
+
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(a.decimals)), nil)
+	whole, fraction := new(big.Int).QuoRem(units, unit, new(big.Int))
+	if fraction.Sign() == 0 {
+		return sign + whole.String()
+	}
+
+	fractionDigits := fmt.Sprintf("%0*s", int(a.decimals), fraction.String())
+	return sign + whole.String() + "." + strings.TrimRight(fractionDigits, "0")
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
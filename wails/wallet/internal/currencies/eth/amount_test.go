@@ -0,0 +1,79 @@
+package eth_test
+
+import (
+	"math/big"
+	"testing"
+	"wallet/internal/currencies/eth"
+)
+
+func TestParseAmount(t *testing.T) {
+	cases := []struct {
+		name      string
+		input     string
+		decimals  uint8
+		wantUnits string
+		wantErr   string
+	}{
+		{name: "Whole ether", input: "100", decimals: eth.EtherDecimals, wantUnits: "100000000000000000000"},
+		{name: "Tenth of an ether is exact", input: "0.1", decimals: eth.EtherDecimals, wantUnits: "100000000000000000"},
+		{name: "Smallest ether amount", input: "0.000000000000000001", decimals: eth.EtherDecimals, wantUnits: "1"},
+		{name: "Gwei", input: "1.5", decimals: eth.GweiDecimals, wantUnits: "1500000000"},
+		{name: "Token decimals", input: "12.345678", decimals: 6, wantUnits: "12345678"},
+		{name: "Surrounding spaces", input: " 2.5 ", decimals: eth.EtherDecimals, wantUnits: "2500000000000000000"},
+		{name: "Too many decimal places", input: "0.1234567", decimals: 6, wantErr: "more than 6 decimal places"},
+		{name: "Decimals of wei", input: "1.5", decimals: eth.WeiDecimals, wantErr: "more than 0 decimal places"},
+		{name: "Exponent", input: "1e18", decimals: eth.EtherDecimals, wantErr: "invalid amount"},
+		{name: "Negative", input: "-1", decimals: eth.EtherDecimals, wantErr: "invalid amount"},
+		{name: "Missing whole part", input: ".5", decimals: eth.EtherDecimals, wantErr: "invalid amount"},
+		{name: "Missing fraction", input: "1.", decimals: eth.EtherDecimals, wantErr: "invalid amount"},
+		{name: "Empty", input: "", decimals: eth.EtherDecimals, wantErr: "invalid amount"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			amount, err := eth.ParseAmount(tc.input, tc.decimals)
+			if tc.wantErr != "" {
+				assertError(t, err, tc.wantErr)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to parse %q: %v", tc.input, err)
+			}
+			assertCorrectValue(t, amount.Units().String(), tc.wantUnits)
+			assertCorrectValue(t, amount.Decimals(), tc.decimals)
+		})
+	}
+}
+
+func TestAmountString(t *testing.T) {
+	cases := []struct {
+		units    string
+		decimals uint8
+		want     string
+	}{
+		{units: "0", decimals: eth.EtherDecimals, want: "0"},
+		{units: "100000000000000000", decimals: eth.EtherDecimals, want: "0.1"},
+		{units: "1", decimals: eth.EtherDecimals, want: "0.000000000000000001"},
+		// big.Float formatted this in exponent notation.
+		{units: "1234567890123000000000000000000", decimals: eth.EtherDecimals, want: "1234567890123"},
+		{units: "21000000000000", decimals: eth.EtherDecimals, want: "0.000021"},
+		{units: "1500000000", decimals: eth.GweiDecimals, want: "1.5"},
+		{units: "-2500", decimals: 3, want: "-2.5"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.want, func(t *testing.T) {
+			units, _ := new(big.Int).SetString(tc.units, 10)
+			assertCorrectValue(t, eth.NewAmount(units, tc.decimals).String(), tc.want)
+		})
+	}
+
+	t.Run("Parsing the formatted amount round trips", func(t *testing.T) {
+		amount, err := eth.ParseEther("3.000000000000000007")
+		if err != nil {
+			t.Fatalf("Failed to parse amount: %v", err)
+		}
+		assertCorrectValue(t, amount.String(), "3.000000000000000007")
+	})
+}
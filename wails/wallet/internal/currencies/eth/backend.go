@@ -0,0 +1,55 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Backend carries encoded JSON-RPC requests to a node and returns the raw responses.
+// Client talks to nodes over HTTP by default; tests plug in an in-process chain instead.
+type Backend interface {
+	Send(ctx context.Context, request []byte) ([]byte, error)
+}
+
+var defaultHTTPClient = &http.Client{Timeout: defaultRequestTimeout}
+
+// HTTPBackend posts requests to the JSON-RPC endpoint of a node.
+type HTTPBackend struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+func (b *HTTPBackend) Send(ctx context.Context, request []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.URL, bytes.NewBuffer(request))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	httpClient := b.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("node returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
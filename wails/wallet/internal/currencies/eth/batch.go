@@ -0,0 +1,127 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// BatchElem is a request sent as part of a JSON-RPC batch. When the node answers it,
+// the result is decoded into Result, otherwise Error is set.
+type BatchElem struct {
+	Method string
+	Params []interface{}
+	Result interface{}
+	Error  error
+}
+
+type batchResponse struct {
+	ID     interface{}            `json:"id"`
+	Result json.RawMessage        `json:"result"`
+	Error  map[string]interface{} `json:"error"`
+}
+
+// BatchCall sends every request to the node in a single round trip. The returned error
+// is only set when the batch fails as a whole; errors of single requests are set on
+// their element.
+func (c *Client) BatchCall(ctx context.Context, batch []BatchElem) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	payloads := make([]RPCPayload, len(batch))
+	for i, elem := range batch {
+		params := elem.Params
+		if params == nil {
+			params = []interface{}{}
+		}
+
+		payloads[i] = RPCPayload{
+			Jsonrpc: "2.0",
+			Method:  elem.Method,
+			Params:  params,
+			ID:      strconv.Itoa(i),
+		}
+	}
+
+	data, err := json.Marshal(payloads)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	body, err := c.backend.Send(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	var responses []batchResponse
+	err = json.Unmarshal(body, &responses)
+	if err != nil {
+		// Nodes reject a batch as a whole, e.g. when it is too large, with a single error.
+		var response batchResponse
+		if json.Unmarshal(body, &response) == nil && response.Error != nil {
+			return newRPCError(response.Error)
+		}
+
+		return fmt.Errorf("failed to unmarshal batch response body: %w", err)
+	}
+
+	// Responses can come in any order and are matched to their request by ID.
+	answered := make([]bool, len(batch))
+	for _, response := range responses {
+		idx, err := strconv.Atoi(fmt.Sprint(response.ID))
+		if err != nil || idx < 0 || idx >= len(batch) || answered[idx] {
+			continue
+		}
+
+		answered[idx] = true
+		elem := &batch[idx]
+		switch {
+		case response.Error != nil:
+			elem.Error = newRPCError(response.Error)
+		case len(response.Result) == 0:
+			elem.Error = errors.New("missing result")
+		case elem.Result != nil:
+			err = json.Unmarshal(response.Result, elem.Result)
+			if err != nil {
+				elem.Error = fmt.Errorf("unexpected result type: %w", err)
+			}
+		}
+	}
+
+	for i, ok := range answered {
+		if !ok {
+			batch[i].Error = fmt.Errorf("no response to %s", batch[i].Method)
+		}
+	}
+
+	return nil
+}
+
+// GetBalances returns the balances of addresses, in the same order, with one round trip.
+func (c *Client) GetBalances(ctx context.Context, addresses []string) ([]string, error) {
+	balances := make([]string, len(addresses))
+	batch := make([]BatchElem, len(addresses))
+	for i, address := range addresses {
+		batch[i] = BatchElem{
+			Method: "eth_getBalance",
+			Params: []interface{}{address, "latest"},
+			Result: &balances[i],
+		}
+	}
+
+	err := c.BatchCall(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balances: %w", err)
+	}
+
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to get balance of %s: %w", addresses[i], elem.Error)
+		}
+	}
+
+	return balances, nil
+}
@@ -0,0 +1,140 @@
+package eth_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethmock"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// backendFunc answers the requests of a client with canned bodies.
+type backendFunc func(ctx context.Context, request []byte) ([]byte, error)
+
+func (f backendFunc) Send(ctx context.Context, request []byte) ([]byte, error) {
+	return f(ctx, request)
+}
+
+func TestGetBalancesInOneRoundTrip(t *testing.T) {
+	node := newMockNode(t)
+	node.Handle("eth_getBalance", func(req ethmock.Request) ethmock.Response {
+		// Every address holds as many wei as its last byte.
+		var address hexutil.Bytes
+		err := address.UnmarshalJSON(req.Params[0])
+		if err != nil {
+			return ethmock.Response{Error: &ethmock.Error{Code: -32602, Message: "invalid address"}}
+		}
+
+		return ethmock.Response{Result: hexutil.EncodeUint64(uint64(address[len(address)-1]))}
+	})
+
+	addresses := make([]string, 25)
+	for i := range addresses {
+		addresses[i] = fmt.Sprintf("0x%040x", i+1)
+	}
+
+	balances, err := eth.NewClient(node.URL).GetBalances(context.Background(), addresses)
+	if err != nil {
+		t.Fatalf("Failed to get balances: %v", err)
+	}
+
+	assertCorrectValue(t, node.RoundTrips(), 1)
+	assertCorrectValue(t, len(balances), len(addresses))
+	for i, balance := range balances {
+		assertCorrectValue(t, balance, hexutil.EncodeUint64(uint64(i+1)))
+	}
+}
+
+func TestBatchCall(t *testing.T) {
+	cases := []struct {
+		name     string
+		response string
+		wantErr  string
+		// wantElems holds the expected result, or error, of each element.
+		wantElems []string
+	}{
+		{
+			name:      "Responses out of order",
+			response:  `[{"id":"1","result":"0x2"},{"id":"0","result":"0x1"}]`,
+			wantElems: []string{"0x1", "0x2"},
+		},
+		{
+			name:      "Numeric IDs",
+			response:  `[{"id":0,"result":"0x1"},{"id":1,"result":"0x2"}]`,
+			wantElems: []string{"0x1", "0x2"},
+		},
+		{
+			name:      "Error for a single request",
+			response:  `[{"id":"0","result":"0x1"},{"id":"1","error":{"code":-32000,"message":"header not found"}}]`,
+			wantElems: []string{"0x1", "rpc error -32000: header not found"},
+		},
+		{
+			name:      "Missing response",
+			response:  `[{"id":"0","result":"0x1"}]`,
+			wantElems: []string{"0x1", "no response to eth_blockNumber"},
+		},
+		{
+			name:      "Missing result",
+			response:  `[{"id":"0"},{"id":"1","result":2}]`,
+			wantElems: []string{"missing result", "unexpected result type"},
+		},
+		{
+			name:     "Batch rejected as a whole",
+			response: `{"id":null,"error":{"code":-32600,"message":"batch too large"}}`,
+			wantErr:  "rpc error -32600: batch too large",
+		},
+		{
+			name:     "Malformed body",
+			response: `[{"id":"0"`,
+			wantErr:  "failed to unmarshal batch response body",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := eth.NewClientWithBackend(backendFunc(func(context.Context, []byte) ([]byte, error) {
+				return []byte(tc.response), nil
+			}))
+
+			results := make([]string, 2)
+			batch := []eth.BatchElem{
+				{Method: "eth_chainId", Result: &results[0]},
+				{Method: "eth_blockNumber", Result: &results[1]},
+			}
+			err := client.BatchCall(context.Background(), batch)
+			assertError(t, err, tc.wantErr)
+
+			for i, want := range tc.wantElems {
+				if batch[i].Error != nil {
+					assertError(t, batch[i].Error, want)
+				} else {
+					assertCorrectValue(t, results[i], want)
+				}
+			}
+		})
+	}
+
+	t.Run("HTTP errors fail the whole batch", func(t *testing.T) {
+		node := newMockNode(t)
+		node.OnResult("eth_chainId", "0x1")
+		node.On("eth_blockNumber", ethmock.Response{Status: http.StatusTooManyRequests, Body: "slow down"})
+
+		batch := []eth.BatchElem{{Method: "eth_chainId"}, {Method: "eth_blockNumber"}}
+		err := eth.NewClient(node.URL).BatchCall(context.Background(), batch)
+		assertError(t, err, "node returned HTTP 429: slow down")
+	})
+
+	t.Run("Batches with a raw transaction are not retried", func(t *testing.T) {
+		node := newMockNode(t)
+		node.On("eth_sendRawTransaction", ethmock.Response{Status: http.StatusBadGateway})
+
+		backend := newFailoverBackend(t, node.URL)
+		batch := []eth.BatchElem{{Method: "eth_chainId"}, {Method: "eth_sendRawTransaction", Params: []interface{}{"0x01"}}}
+		err := eth.NewClientWithBackend(backend).BatchCall(context.Background(), batch)
+		assertError(t, err, "HTTP 502")
+		assertCorrectValue(t, node.RoundTrips(), 1)
+	})
+}
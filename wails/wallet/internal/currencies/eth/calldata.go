@@ -0,0 +1,509 @@
+package eth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+	"wallet/internal/contracts"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Sources of the signature a call was decoded with.
+const (
+	CallSourceRegistry   = "registry"
+	CallSourceKnown      = "known"
+	CallSourceSignatures = "signatures"
+)
+
+// Codes of the risks a decoded call is annotated with.
+const (
+	RiskUnlimitedApproval      = "unlimited_approval"
+	RiskApprovalForAll         = "approval_for_all"
+	RiskTokenContractRecipient = "token_contract_recipient"
+	RiskZeroAddressRecipient   = "zero_address_recipient"
+	RiskSelectorOnly           = "selector_only"
+	RiskUnknownCall            = "unknown_call"
+)
+
+// knownMethodsABIJSON holds the token methods dApps most often ask to sign, so that they
+// are decoded even when the contract is not in the ABI registry. ERC-20 and ERC-721
+// transferFrom share a selector, the last argument is then an amount or a token ID.
+const knownMethodsABIJSON = `[
+	{"type": "function", "name": "transfer", "stateMutability": "nonpayable", "inputs": [
+		{"name": "to", "type": "address"},
+		{"name": "amount", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "approve", "stateMutability": "nonpayable", "inputs": [
+		{"name": "spender", "type": "address"},
+		{"name": "amount", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "increaseAllowance", "stateMutability": "nonpayable", "inputs": [
+		{"name": "spender", "type": "address"},
+		{"name": "addedValue", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "transferFrom", "stateMutability": "nonpayable", "inputs": [
+		{"name": "from", "type": "address"},
+		{"name": "to", "type": "address"},
+		{"name": "amount", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "setApprovalForAll", "stateMutability": "nonpayable", "inputs": [
+		{"name": "operator", "type": "address"},
+		{"name": "approved", "type": "bool"}], "outputs": []},
+	{"type": "function", "name": "safeTransferFrom", "stateMutability": "nonpayable", "inputs": [
+		{"name": "from", "type": "address"},
+		{"name": "to", "type": "address"},
+		{"name": "tokenId", "type": "uint256"}], "outputs": []},
+	{"type": "function", "name": "safeTransferFrom", "stateMutability": "nonpayable", "inputs": [
+		{"name": "from", "type": "address"},
+		{"name": "to", "type": "address"},
+		{"name": "tokenId", "type": "uint256"},
+		{"name": "data", "type": "bytes"}], "outputs": []},
+	{"type": "function", "name": "safeTransferFrom", "stateMutability": "nonpayable", "inputs": [
+		{"name": "from", "type": "address"},
+		{"name": "to", "type": "address"},
+		{"name": "id", "type": "uint256"},
+		{"name": "value", "type": "uint256"},
+		{"name": "data", "type": "bytes"}], "outputs": []},
+	{"type": "function", "name": "safeBatchTransferFrom", "stateMutability": "nonpayable", "inputs": [
+		{"name": "from", "type": "address"},
+		{"name": "to", "type": "address"},
+		{"name": "ids", "type": "uint256[]"},
+		{"name": "values", "type": "uint256[]"},
+		{"name": "data", "type": "bytes"}], "outputs": []}
+]`
+
+var knownMethodsABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(knownMethodsABIJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid known methods ABI: %v", err))
+	}
+
+	return parsed
+}()
+
+// DecodedCall is a human readable view of the data of a transaction calling a contract,
+// shown to the user before signing it.
+type DecodedCall struct {
+	Selector string `json:"selector"`
+	// Function and Signature are empty when no signature matches the selector, the data
+	// is then only split into Words.
+	Function  string `json:"function,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	// Contract is the registry entry whose ABI decoded the call. It is deployed at another
+	// address when the recipient is not registered but shares the method.
+	Source   string          `json:"source,omitempty"`
+	Contract string          `json:"contract,omitempty"`
+	Args     []ContractParam `json:"args"`
+	Words    []string        `json:"words,omitempty"`
+	// Summary tells what the call does in a sentence, amounts being in the token called
+	// when it answers decimals() and symbol().
+	Summary string        `json:"summary"`
+	Risks   []ReviewIssue `json:"risks"`
+}
+
+func (c *DecodedCall) addRisk(code, message string) {
+	c.Risks = append(c.Risks, ReviewIssue{Code: code, Message: message})
+}
+
+// HasRisk tells whether the call was annotated with the risk code.
+func (c *DecodedCall) HasRisk(code string) bool {
+	for _, risk := range c.Risks {
+		if risk.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *DecodedCall) String() string {
+	var summary strings.Builder
+	if c.Function == "" {
+		fmt.Fprintf(&summary, "unknown function %s", c.Selector)
+		for i, word := range c.Words {
+			fmt.Fprintf(&summary, "\n  word %d: %s", i, word)
+		}
+	} else {
+		fmt.Fprintf(&summary, "%s (%s", c.Signature, c.Source)
+		if c.Contract != "" {
+			fmt.Fprintf(&summary, " %s", c.Contract)
+		}
+		summary.WriteString(")")
+		for i, arg := range c.Args {
+			name := arg.Name
+			if name == "" {
+				name = fmt.Sprintf("arg %d", i)
+			}
+			fmt.Fprintf(&summary, "\n  %s (%s): %s", name, arg.Type, arg.Value)
+		}
+	}
+
+	fmt.Fprintf(&summary, "\n%s", c.Summary)
+	for _, risk := range c.Risks {
+		fmt.Fprintf(&summary, "\nwarning: %s", risk.Message)
+	}
+
+	return summary.String()
+}
+
+// SignatureDatabase maps 4-byte selectors to the text signatures of functions, such as an
+// offline export of a public signature directory. Selectors are not unique, so one can
+// match several signatures.
+type SignatureDatabase struct {
+	methods map[[4]byte][]abi.Method
+	count   int
+}
+
+// ParseSignatureDatabase reads one signature per line, such as "transfer(address,uint256)",
+// optionally preceded by its selector. Blank lines and lines starting with # are skipped.
+func ParseSignatureDatabase(r io.Reader) (*SignatureDatabase, error) {
+	db := &SignatureDatabase{methods: make(map[[4]byte][]abi.Method)}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		signature := fields[len(fields)-1]
+		method, err := methodFromSignature(signature)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if len(fields) > 2 || len(fields) == 2 && !strings.EqualFold(fields[0], hexutil.Encode(method.ID)) {
+			return nil, fmt.Errorf("line %d: selector %s does not match %s", line, fields[0], signature)
+		}
+
+		selector := [4]byte(method.ID)
+		db.methods[selector] = append(db.methods[selector], method)
+		db.count++
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error reading signature database: %w", err)
+	}
+
+	return db, nil
+}
+
+func LoadSignatureDatabase(path string) (*SignatureDatabase, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening signature database: %w", err)
+	}
+	defer file.Close()
+
+	return ParseSignatureDatabase(file)
+}
+
+// Len returns the number of signatures in the database.
+func (d *SignatureDatabase) Len() int {
+	return d.count
+}
+
+// methodFromSignature builds an ABI method from its text signature. Text signatures have
+// no argument names, so the arguments are left unnamed.
+func methodFromSignature(signature string) (abi.Method, error) {
+	selector, err := abi.ParseSelector(signature)
+	if err != nil {
+		return abi.Method{}, fmt.Errorf("invalid signature %q: %w", signature, err)
+	}
+
+	inputs := make(abi.Arguments, 0, len(selector.Inputs))
+	for _, input := range selector.Inputs {
+		t, err := abi.NewType(input.Type, "", input.Components)
+		if err != nil {
+			return abi.Method{}, fmt.Errorf("invalid signature %q: %w", signature, err)
+		}
+		inputs = append(inputs, abi.Argument{Type: t})
+	}
+
+	return abi.NewMethod(selector.Name, selector.Name, abi.Function, "nonpayable", false, false, inputs, nil), nil
+}
+
+// callToken is the ERC-20 token a call is sent to, used to show amounts in it.
+type callToken struct {
+	symbol   string
+	decimals uint8
+}
+
+func (t *callToken) format(units *big.Int) string {
+	if t == nil {
+		return units.String() + " units"
+	}
+
+	return NewAmount(units, t.decimals).String() + " " + t.symbol
+}
+
+// DecodeCall decodes the data of a transaction sent to a contract. Its selector is looked
+// up in the registry, the contract deployed at to first, then in the well-known token
+// methods and last in the signature database, which may be nil. Calls that match nothing
+// are split into 32-byte words.
+func (c *Client) DecodeCall(
+	ctx context.Context,
+	to common.Address,
+	data []byte,
+	registry []*Contract,
+	signatures *SignatureDatabase,
+) *DecodedCall {
+	if len(data) < 4 {
+		call := &DecodedCall{Selector: hexutil.Encode(data), Summary: "Send data that does not call any function"}
+		call.addRisk(RiskUnknownCall, "The data is too short to hold a function selector.")
+		return call
+	}
+
+	selector := [4]byte(data[:4])
+	call := &DecodedCall{Selector: hexutil.Encode(selector[:])}
+	method, values, ok := decodeRegistryCall(call, to, data, registry)
+	if !ok {
+		method, values, ok = decodeWithABI(&knownMethodsABI, data)
+		call.Source = CallSourceKnown
+	}
+
+	if !ok && signatures != nil {
+		method, values, ok = decodeWithSignatures(signatures.methods[selector], data)
+		call.Source = CallSourceSignatures
+		if ok {
+			call.addRisk(
+				RiskSelectorOnly,
+				"The function was only matched by its selector, which other functions can share.",
+			)
+		}
+	}
+
+	if !ok {
+		call.Source = ""
+		call.Words = splitWords(data[4:])
+		call.Summary = fmt.Sprintf("Call an unknown function of %s", to.Hex())
+		call.addRisk(
+			RiskUnknownCall,
+			"The data does not match any known function, only sign it if you trust the dApp.",
+		)
+		return call
+	}
+
+	call.Function = method.RawName
+	call.Signature = method.Sig
+	call.Args = describeArguments(method.Inputs)
+	for i, value := range values {
+		call.Args[i].Value = formatABIValue(value)
+	}
+
+	var token *callToken
+	if movesTokenAmount(method.Sig) {
+		token = c.lookupCallToken(ctx, to)
+	}
+	annotateCall(call, method.Sig, values, to, token)
+
+	return call
+}
+
+// decodeRegistryCall decodes the call with the ABI of the registry contract deployed at to,
+// then with those of the other contracts.
+func decodeRegistryCall(call *DecodedCall, to common.Address, data []byte, registry []*Contract) (
+	*abi.Method,
+	[]interface{},
+	bool,
+) {
+	ordered := make([]*Contract, 0, len(registry))
+	for _, contract := range registry {
+		if common.HexToAddress(contract.Address) == to {
+			ordered = append([]*Contract{contract}, ordered...)
+			continue
+		}
+		ordered = append(ordered, contract)
+	}
+
+	for _, contract := range ordered {
+		method, values, ok := decodeWithABI(&contract.abi, data)
+		if ok {
+			call.Source = CallSourceRegistry
+			call.Contract = contract.Name
+			return method, values, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+func decodeWithABI(contractABI *abi.ABI, data []byte) (*abi.Method, []interface{}, bool) {
+	method, err := contractABI.MethodById(data)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return method, values, true
+}
+
+// decodeWithSignatures returns the first of the methods sharing the selector that encodes
+// the arguments exactly as they are in data, which rules out most collisions.
+func decodeWithSignatures(methods []abi.Method, data []byte) (*abi.Method, []interface{}, bool) {
+	for i := range methods {
+		values, err := methods[i].Inputs.Unpack(data[4:])
+		if err != nil {
+			continue
+		}
+
+		packed, err := methods[i].Inputs.Pack(values...)
+		if err == nil && bytes.Equal(packed, data[4:]) {
+			return &methods[i], values, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+func splitWords(data []byte) []string {
+	words := make([]string, 0, (len(data)+31)/32)
+	for start := 0; start < len(data); start += 32 {
+		words = append(words, hexutil.Encode(data[start:min(start+32, len(data))]))
+	}
+
+	return words
+}
+
+func movesTokenAmount(signature string) bool {
+	switch signature {
+	case "transfer(address,uint256)",
+		"approve(address,uint256)",
+		"increaseAllowance(address,uint256)",
+		"transferFrom(address,address,uint256)":
+		return true
+	}
+
+	return false
+}
+
+// lookupCallToken reads the decimals and symbol of the token at address in one batch, and
+// returns nil when it does not answer them, like ERC-721 collections.
+func (c *Client) lookupCallToken(ctx context.Context, address common.Address) *callToken {
+	tokenABI, err := contracts.DemoTokenMetaData.GetAbi()
+	if err != nil {
+		return nil
+	}
+
+	results := make([]hexutil.Bytes, 2)
+	batch := []BatchElem{
+		tokenCall(tokenABI, address, &results[0], "decimals"),
+		tokenCall(tokenABI, address, &results[1], "symbol"),
+	}
+	err = c.BatchCall(ctx, batch)
+	if err != nil || batch[0].Error != nil || batch[1].Error != nil {
+		return nil
+	}
+
+	decimals, ok := unpackTokenValue(tokenABI, "decimals", results[0]).(uint8)
+	if !ok {
+		return nil
+	}
+
+	symbol, ok := unpackTokenValue(tokenABI, "symbol", results[1]).(string)
+	if !ok {
+		return nil
+	}
+
+	return &callToken{symbol: symbol, decimals: decimals}
+}
+
+// annotateCall writes the summary of a decoded call and flags the risks of the well-known
+// token methods, whatever ABI they were decoded with.
+func annotateCall(call *DecodedCall, signature string, values []interface{}, to common.Address, token *callToken) {
+	switch signature {
+	case "approve(address,uint256)", "increaseAllowance(address,uint256)":
+		spender, amount := values[0].(common.Address), values[1].(*big.Int)
+		switch {
+		case amount.Cmp(unlimitedAllowance) >= 0:
+			call.Summary = fmt.Sprintf("Allow %s to spend an unlimited amount of your tokens", spender.Hex())
+			call.addRisk(
+				RiskUnlimitedApproval,
+				fmt.Sprintf("%s can spend all your tokens of %s, now and later, until you revoke it.", spender.Hex(), to.Hex()),
+			)
+		case amount.Sign() == 0 && call.Function == "approve" && token != nil:
+			call.Summary = fmt.Sprintf("Revoke the allowance of %s", spender.Hex())
+		case amount.Sign() == 0 && call.Function == "approve":
+			// ERC-721 approvals share the selector, and token ID 0 is a valid token.
+			call.Summary = fmt.Sprintf("Approve %s for token ID %s", spender.Hex(), amount.String())
+		default:
+			call.Summary = fmt.Sprintf("Allow %s to spend %s", spender.Hex(), token.format(amount))
+		}
+	case "setApprovalForAll(address,bool)":
+		operator, approved := values[0].(common.Address), values[1].(bool)
+		if !approved {
+			call.Summary = fmt.Sprintf("Revoke the approval of %s over the collection", operator.Hex())
+			return
+		}
+
+		call.Summary = fmt.Sprintf("Allow %s to transfer all your tokens of the collection", operator.Hex())
+		call.addRisk(
+			RiskApprovalForAll,
+			fmt.Sprintf("%s can transfer every token you hold in %s, until you revoke it.", operator.Hex(), to.Hex()),
+		)
+	case "transfer(address,uint256)":
+		recipient, amount := values[0].(common.Address), values[1].(*big.Int)
+		call.Summary = fmt.Sprintf("Send %s to %s", token.format(amount), recipient.Hex())
+		annotateRecipient(call, recipient, to)
+	case "transferFrom(address,address,uint256)":
+		from, recipient, amount := values[0].(common.Address), values[1].(common.Address), values[2].(*big.Int)
+		moved := "token " + amount.String()
+		if token != nil {
+			moved = token.format(amount)
+		}
+		call.Summary = fmt.Sprintf("Move %s from %s to %s", moved, from.Hex(), recipient.Hex())
+		annotateRecipient(call, recipient, to)
+	default:
+		call.Summary = fmt.Sprintf("Call %s on %s", call.Function, to.Hex())
+	}
+}
+
+func annotateRecipient(call *DecodedCall, recipient, token common.Address) {
+	switch recipient {
+	case common.Address{}:
+		call.addRisk(RiskZeroAddressRecipient, "Tokens sent to the zero address are lost for good.")
+	case token:
+		call.addRisk(RiskTokenContractRecipient, "Tokens sent to the token contract itself are usually lost.")
+	}
+}
+
+// DecodeCall decodes the data of a transaction to a contract with the ABI registry, the
+// well-known token methods and the signature database loaded, if any.
+func (a *MasterAccount) DecodeCall(to string, data []byte) (*DecodedCall, error) {
+	if !common.IsHexAddress(to) {
+		return nil, fmt.Errorf("invalid contract address: %s", to)
+	}
+
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	registry, err := a.contractDB.GetContracts(cliCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.client.DecodeCall(cliCtx, common.HexToAddress(to), data, registry, a.signatures.Load()), nil
+}
+
+// LoadSignatureDatabase reads the signature database used to decode calls to contracts
+// missing from the registry, and returns the number of signatures it holds. It is kept
+// until the wallet is closed.
+func (a *MasterAccount) LoadSignatureDatabase(path string) (int, error) {
+	signatures, err := LoadSignatureDatabase(path)
+	if err != nil {
+		return 0, err
+	}
+
+	a.signatures.Store(signatures)
+
+	return signatures.Len(), nil
+}
@@ -0,0 +1,194 @@
+package eth_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"wallet/internal/contracts"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethmock"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+func TestDecodeCall(t *testing.T) {
+	tokenABI, err := contracts.DemoTokenMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("Failed to parse ABI: %v", err)
+	}
+
+	const (
+		unregisteredToken = "0x0000000000000000000000000000000000B20000"
+		operator          = "0x00000000000000000000000000000000000A1100"
+	)
+
+	node := newMockNode(t)
+	node.Handle("eth_call", func(req ethmock.Request) ethmock.Response {
+		var call struct {
+			To   common.Address `json:"to"`
+			Data hexutil.Bytes  `json:"data"`
+		}
+		err := json.Unmarshal(req.Params[0], &call)
+		if err != nil {
+			return ethmock.Response{Error: &ethmock.Error{Code: -32602, Message: "invalid call"}}
+		}
+
+		// Only the registered token answers as an ERC-20 token.
+		method, err := tokenABI.MethodById(call.Data)
+		if err != nil || call.To != common.HexToAddress(contractAddress) {
+			return ethmock.Response{Error: &ethmock.Error{Code: 3, Message: "execution reverted"}}
+		}
+
+		var result []byte
+		switch method.Name {
+		case "decimals":
+			result, _ = method.Outputs.Pack(uint8(18))
+		case "symbol":
+			result, _ = method.Outputs.Pack("DMT")
+		}
+
+		return ethmock.Response{Result: hexutil.Encode(result)}
+	})
+
+	client := eth.NewClient(node.URL)
+	registry := []*eth.Contract{loadArtifactContract(t, "DemoToken")}
+	signatures, err := eth.ParseSignatureDatabase(strings.NewReader(`
+		# Exported from a signature directory.
+		0x0121b93f vote(uint256)
+		castVote(uint256,bool)
+	`))
+	if err != nil {
+		t.Fatalf("Failed to parse signature database: %v", err)
+	}
+	assertCorrectValue(t, signatures.Len(), 2)
+
+	pack := func(signature string, args ...interface{}) []byte {
+		t.Helper()
+		method, err := abi.ParseSelector(signature)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", signature, err)
+		}
+
+		inputs := make(abi.Arguments, 0, len(method.Inputs))
+		for _, input := range method.Inputs {
+			inputs = append(inputs, abi.Argument{Type: mustNewType(t, input.Type)})
+		}
+
+		packed, err := inputs.Pack(args...)
+		if err != nil {
+			t.Fatalf("Failed to pack %s: %v", signature, err)
+		}
+
+		return append(hexutil.MustDecode(selector(signature)), packed...)
+	}
+	decode := func(to string, data []byte) *eth.DecodedCall {
+		return client.DecodeCall(context.Background(), common.HexToAddress(to), data, registry, signatures)
+	}
+	riskCodes := func(call *eth.DecodedCall) []string {
+		codes := []string{}
+		for _, risk := range call.Risks {
+			codes = append(codes, risk.Code)
+		}
+		return codes
+	}
+
+	amount, _ := new(big.Int).SetString("1500000000000000000", 10)
+
+	t.Run("Registry ABI names the arguments and the token gives the amounts", func(t *testing.T) {
+		call := decode(contractAddress, pack("transfer(address,uint256)", common.HexToAddress(hardhatAccount1), amount))
+		assertCorrectValue(t, *call, eth.DecodedCall{
+			Selector:  "0xa9059cbb",
+			Function:  "transfer",
+			Signature: "transfer(address,uint256)",
+			Source:    eth.CallSourceRegistry,
+			Contract:  "DemoToken",
+			Args: []eth.ContractParam{
+				{Name: "to", Type: "address", Value: hardhatAccount1},
+				{Name: "value", Type: "uint256", Value: amount.String()},
+			},
+			Summary: "Send 1.5 DMT to " + hardhatAccount1,
+		})
+	})
+
+	t.Run("Unlimited approvals of unregistered tokens are flagged", func(t *testing.T) {
+		call := decode(unregisteredToken, pack("approve(address,uint256)", common.HexToAddress(operator), math.MaxBig256))
+		assertCorrectValue(t, call.Source, eth.CallSourceRegistry)
+		assertCorrectValue(t, call.Summary, "Allow "+common.HexToAddress(operator).Hex()+
+			" to spend an unlimited amount of your tokens")
+		assertCorrectValue(t, riskCodes(call), []string{eth.RiskUnlimitedApproval})
+
+		call = decode(unregisteredToken, pack("approve(address,uint256)", common.HexToAddress(operator), big.NewInt(5)))
+		assertCorrectValue(t, call.Summary, "Allow "+common.HexToAddress(operator).Hex()+" to spend 5 units")
+		assertCorrectValue(t, riskCodes(call), []string{})
+
+		call = decode(unregisteredToken, pack("approve(address,uint256)", common.HexToAddress(operator), new(big.Int)))
+		assertCorrectValue(t, call.Summary, "Approve "+common.HexToAddress(operator).Hex()+" for token ID 0")
+	})
+
+	t.Run("Zero approvals of known tokens revoke the allowance", func(t *testing.T) {
+		call := decode(contractAddress, pack("approve(address,uint256)", common.HexToAddress(operator), new(big.Int)))
+		assertCorrectValue(t, call.Summary, "Revoke the allowance of "+common.HexToAddress(operator).Hex())
+		assertCorrectValue(t, riskCodes(call), []string{})
+	})
+
+	t.Run("Collection approvals fall back to the well-known methods", func(t *testing.T) {
+		call := decode(collectionAddress, pack("setApprovalForAll(address,bool)", common.HexToAddress(operator), true))
+		assertCorrectValue(t, call.Source, eth.CallSourceKnown)
+		assertCorrectValue(t, call.Args, []eth.ContractParam{
+			{Name: "operator", Type: "address", Value: common.HexToAddress(operator).Hex()},
+			{Name: "approved", Type: "bool", Value: "true"},
+		})
+		assertCorrectValue(t, riskCodes(call), []string{eth.RiskApprovalForAll})
+
+		call = decode(collectionAddress, pack("setApprovalForAll(address,bool)", common.HexToAddress(operator), false))
+		assertCorrectValue(t, riskCodes(call), []string{})
+	})
+
+	t.Run("Transfers to the token contract or the zero address are flagged", func(t *testing.T) {
+		call := decode(contractAddress, pack("transfer(address,uint256)", common.HexToAddress(contractAddress), amount))
+		assertCorrectValue(t, riskCodes(call), []string{eth.RiskTokenContractRecipient})
+
+		call = decode(contractAddress, pack("transfer(address,uint256)", common.Address{}, amount))
+		assertCorrectValue(t, riskCodes(call), []string{eth.RiskZeroAddressRecipient})
+	})
+
+	t.Run("Signature database matches are unnamed and flagged", func(t *testing.T) {
+		call := decode(unregisteredToken, pack("castVote(uint256,bool)", big.NewInt(3), true))
+		assertCorrectValue(t, call.Source, eth.CallSourceSignatures)
+		assertCorrectValue(t, call.Signature, "castVote(uint256,bool)")
+		assertCorrectValue(t, call.Args, []eth.ContractParam{
+			{Type: "uint256", Value: "3"},
+			{Type: "bool", Value: "true"},
+		})
+		assertCorrectValue(t, riskCodes(call), []string{eth.RiskSelectorOnly})
+	})
+
+	t.Run("Unknown calls are split into words", func(t *testing.T) {
+		data := append(hexutil.MustDecode("0xdeadbeef"), common.BigToHash(big.NewInt(7)).Bytes()...)
+		data = append(data, 0x01)
+		call := decode(unregisteredToken, data)
+		assertCorrectValue(t, call.Function, "")
+		assertCorrectValue(t, call.Selector, "0xdeadbeef")
+		assertCorrectValue(t, call.Words, []string{common.BigToHash(big.NewInt(7)).Hex(), "0x01"})
+		assertCorrectValue(t, riskCodes(call), []string{eth.RiskUnknownCall})
+
+		// Arguments that do not re-encode to the data rule out the database signature.
+		call = decode(unregisteredToken, append(pack("vote(uint256)", big.NewInt(1)), 0x01))
+		assertCorrectValue(t, riskCodes(call), []string{eth.RiskUnknownCall})
+	})
+
+	t.Run("Invalid signature databases are rejected", func(t *testing.T) {
+		_, err := eth.ParseSignatureDatabase(strings.NewReader("0xa9059cbb approve(address,uint256)"))
+		assertError(t, err, "line 1: selector 0xa9059cbb does not match approve(address,uint256)")
+
+		_, err = eth.ParseSignatureDatabase(strings.NewReader("\ntransfer(address,"))
+		if err == nil || !strings.HasPrefix(err.Error(), `line 2: invalid signature "transfer(address,"`) {
+			t.Fatalf("Expected an invalid signature error, got %v", err)
+		}
+	})
+}
@@ -1,18 +1,20 @@
 package eth
 
 import (
-	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"math"
 	"math/big"
-	"net/http"
+	"strings"
+	"sync"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/google/uuid"
@@ -20,6 +22,14 @@ import (
 
 type Client struct {
 	ProviderURL string
+	backend     Backend
+
+	wsMu  sync.Mutex
+	wsURL string
+	ws    *RPCBackend
+
+	// ensRegistry replaces DefaultENSRegistry when set.
+	ensRegistry common.Address
 }
 
 type Transaction struct {
@@ -34,6 +44,13 @@ type Transaction struct {
 	S        *big.Int
 }
 
+// RPCError is an error object returned by the node, such as a reverted call.
+type RPCError struct {
+	Code    int
+	Message string
+	Data    interface{}
+}
+
 type RPCPayload struct {
 	Jsonrpc string        `json:"jsonrpc"`
 	Method  string        `json:"method"`
@@ -44,36 +61,67 @@ type RPCPayload struct {
 func NewClient(provider string) *Client {
 	return &Client{
 		ProviderURL: provider,
+		backend:     &HTTPBackend{URL: provider},
+	}
+}
+
+// NewClientWithBackend creates a client that sends its requests through backend,
+// such as an in-process chain used by tests.
+func NewClientWithBackend(backend Backend) *Client {
+	return &Client{backend: backend}
+}
+
+// NewClientWithProviders creates a client that fails over between several endpoints
+// of the same network.
+func NewClientWithProviders(providers []string) (*Client, error) {
+	backend, err := NewFailoverBackend(providers)
+	if err != nil {
+		return nil, err
 	}
+
+	return &Client{ProviderURL: providers[0], backend: backend}, nil
 }
 
 func (c *Client) SetProvider(provider string) {
 	c.ProviderURL = provider
+	c.backend = &HTTPBackend{URL: provider}
 }
 
-func (c *Client) sendRequestToNode(ctx context.Context, payload RPCPayload) (map[string]interface{}, error) {
-	data, err := json.Marshal(payload)
+func (c *Client) SetProviders(providers []string) error {
+	backend, err := NewFailoverBackend(providers)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		return err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.ProviderURL, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	c.ProviderURL = providers[0]
+	c.backend = backend
+	return nil
+}
+
+func (c *Client) SetBackend(backend Backend) {
+	c.ProviderURL = ""
+	c.backend = backend
+}
+
+// ProviderStatus checks the health of the endpoints the client fails over between.
+func (c *Client) ProviderStatus(ctx context.Context) ([]ProviderStatus, error) {
+	backend, ok := c.backend.(*FailoverBackend)
+	if !ok {
+		return nil, errors.New("the client is not connected through a set of providers")
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	respBytes, err := http.DefaultClient.Do(req)
+	return backend.CheckHealth(ctx), nil
+}
 
+func (c *Client) sendRequestToNode(ctx context.Context, payload RPCPayload) (map[string]interface{}, error) {
+	data, err := json.Marshal(payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	defer respBytes.Body.Close()
-
-	body, err := io.ReadAll(respBytes.Body)
+	body, err := c.backend.Send(ctx, data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
 	response := map[string]interface{}{}
@@ -81,9 +129,75 @@ func (c *Client) sendRequestToNode(ctx context.Context, payload RPCPayload) (map
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
 	}
+
+	if rpcErr, ok := response["error"].(map[string]interface{}); ok {
+		return nil, newRPCError(rpcErr)
+	}
+
 	return response, nil
 }
 
+func newRPCError(response map[string]interface{}) *RPCError {
+	rpcErr := &RPCError{Data: response["data"]}
+	if code, ok := response["code"].(float64); ok {
+		rpcErr.Code = int(code)
+	}
+
+	if message, ok := response["message"].(string); ok {
+		rpcErr.Message = message
+	}
+
+	return rpcErr
+}
+
+// Error includes the revert reason when the node only returns it ABI encoded in the error data.
+func (e *RPCError) Error() string {
+	message := fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+	reason, ok := e.revertData()
+	if !ok || strings.Contains(e.Message, reason) {
+		return message
+	}
+
+	return fmt.Sprintf("%s: %s", message, reason)
+}
+
+// Reverted tells whether the error comes from a call that reverted, as opposed to a
+// request the node could not run.
+func (e *RPCError) Reverted() bool {
+	return e.Code == 3 || strings.HasPrefix(e.Message, "execution reverted")
+}
+
+// RevertReason returns the reason given by a reverted call, decoded from the error data
+// or taken from the message. It is empty when the call reverted without a reason.
+func (e *RPCError) RevertReason() string {
+	if reason, ok := e.revertData(); ok {
+		return reason
+	}
+
+	_, reason, _ := strings.Cut(e.Message, "execution reverted: ")
+	return reason
+}
+
+// revertData decodes the Error(string) or Panic(uint256) revert data of the error.
+func (e *RPCError) revertData() (string, bool) {
+	data, ok := e.Data.(string)
+	if !ok {
+		return "", false
+	}
+
+	revertData, err := hexutil.Decode(data)
+	if err != nil {
+		return "", false
+	}
+
+	reason, err := abi.UnpackRevert(revertData)
+	if err != nil {
+		return "", false
+	}
+
+	return reason, true
+}
+
 func (c *Client) NetListening(ctx context.Context) bool {
 	payload := RPCPayload{
 		Jsonrpc: "2.0",
@@ -122,15 +236,29 @@ func (c *Client) GetGasPrice(ctx context.Context) (*big.Int, error) {
 		return nil, fmt.Errorf("unexpected result type: expected string")
 	}
 
-	gasPrice, _ := new(big.Int).SetString(gasPriceHex[2:], 16)
+	gasPrice, err := hexutil.DecodeBig(gasPriceHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price %q: %w", gasPriceHex, err)
+	}
+
 	return gasPrice, nil
 }
 
 func (c *Client) GetNonce(ctx context.Context, address string) (uint64, error) {
+	return c.getTransactionCount(ctx, address, "latest")
+}
+
+// GetPendingNonce returns the next nonce of an account, counting the transactions that
+// are waiting in the node's pool.
+func (c *Client) GetPendingNonce(ctx context.Context, address string) (uint64, error) {
+	return c.getTransactionCount(ctx, address, "pending")
+}
+
+func (c *Client) getTransactionCount(ctx context.Context, address, block string) (uint64, error) {
 	payload := RPCPayload{
 		Jsonrpc: "2.0",
 		Method:  "eth_getTransactionCount",
-		Params:  []interface{}{address, "latest"},
+		Params:  []interface{}{address, block},
 		ID:      uuid.New().String(),
 	}
 
@@ -143,24 +271,29 @@ func (c *Client) GetNonce(ctx context.Context, address string) (uint64, error) {
 		return 0, fmt.Errorf("unexpected result type: expected string")
 	}
 
-	nonce := new(big.Int)
-	nonce.SetString(nonceHex[2:], 16)
-	return nonce.Uint64(), nil
+	nonce, err := hexutil.DecodeUint64(nonceHex)
+	if err != nil {
+		return 0, fmt.Errorf("invalid nonce %q: %w", nonceHex, err)
+	}
+
+	return nonce, nil
 }
 
 func (c *Client) EstimateGas(ctx context.Context, from string, to string, value *big.Int) (uint64, error) {
 	valueHex := fmt.Sprintf("0x%x", value)
+	return c.estimateGas(ctx, map[string]interface{}{
+		"from":  from,
+		"to":    to,
+		"value": valueHex,
+	})
+}
+
+func (c *Client) estimateGas(ctx context.Context, callObject map[string]interface{}) (uint64, error) {
 	payload := RPCPayload{
 		Jsonrpc: "2.0",
 		Method:  "eth_estimateGas",
-		Params: []interface{}{
-			map[string]interface{}{
-				"from":  from,
-				"to":    to,
-				"value": valueHex,
-			},
-		},
-		ID: uuid.New().String(),
+		Params:  []interface{}{callObject},
+		ID:      uuid.New().String(),
 	}
 
 	response, err := c.sendRequestToNode(ctx, payload)
@@ -172,9 +305,12 @@ func (c *Client) EstimateGas(ctx context.Context, from string, to string, value
 		return 0, fmt.Errorf("unexpected result type: expected string")
 	}
 
-	gasLimit := new(big.Int)
-	gasLimit.SetString(gasLimitHex[2:], 16)
-	return gasLimit.Uint64(), nil
+	gasLimit, err := hexutil.DecodeUint64(gasLimitHex)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gas limit %q: %w", gasLimitHex, err)
+	}
+
+	return gasLimit, nil
 }
 
 func (c *Client) GetChainID(ctx context.Context) (int64, error) {
@@ -195,9 +331,12 @@ func (c *Client) GetChainID(ctx context.Context) (int64, error) {
 		return 0, fmt.Errorf("unexpected result type: expected string")
 	}
 
-	// Convert hex to int64
-	chainID, _ := new(big.Int).SetString(chainIDHex[2:], 16)
-	return chainID.Int64(), nil
+	chainID, err := hexutil.DecodeUint64(chainIDHex)
+	if err != nil || chainID > math.MaxInt64 {
+		return 0, fmt.Errorf("invalid chain ID %q", chainIDHex)
+	}
+
+	return int64(chainID), nil
 }
 
 func (c *Client) ProcessTransaction(
@@ -206,54 +345,19 @@ func (c *Client) ProcessTransaction(
 	to string,
 	value *big.Int,
 	privateKey *ecdsa.PrivateKey) (string, error) {
-	toAddress := common.HexToAddress(to)
-	nonce, err := c.GetNonce(ctx, from)
-	if err != nil {
-		return "", fmt.Errorf("failed to retrieve nonce: %w", err)
-	}
-
-	gasPrice, err := c.GetGasPrice(ctx)
+	unsignedTx, err := c.BuildTransaction(ctx, from, to, value)
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve gas price: %w", err)
+		return "", fmt.Errorf("failed to build transaction: %w", err)
 	}
 
-	gasLimit, err := c.EstimateGas(ctx, from, to, value)
-	if err != nil {
-		return "", fmt.Errorf("failed to estimate gas: %w", err)
-	}
-
-	chainID, err := c.GetChainID(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to retrieve chain ID: %w", err)
-	}
-
-	tx := types.NewTransaction(nonce, toAddress, value, gasLimit, gasPrice, nil)
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(big.NewInt(chainID)), privateKey)
+	rawTxHex, err := SignUnsignedTransaction(unsignedTx, privateKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
-	rawTx := types.Transactions{signedTx}
-	rawTxBytes, err := rlp.EncodeToBytes(rawTx[0])
+	txHash, err := c.BroadcastTransaction(ctx, rawTxHex)
 	if err != nil {
-		return "", fmt.Errorf("failed to RLP encode transaction: %w", err)
-	}
-
-	rawTxHex := hex.EncodeToString(rawTxBytes)
-	payload := RPCPayload{
-		Jsonrpc: "2.0",
-		Method:  "eth_sendRawTransaction",
-		Params:  []interface{}{rawTxHex},
-		ID:      uuid.New().String(),
-	}
-
-	response, err := c.sendRequestToNode(ctx, payload)
-	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %w", err)
-	}
-	txHash, ok := response["result"].(string)
-	if !ok {
-		return "", fmt.Errorf("unexpected result type: expected string")
+		return "", fmt.Errorf("failed to broadcast transaction: %w", err)
 	}
 
 	return txHash, nil
@@ -387,38 +491,29 @@ func signTransaction(tx *Transaction, privateKey *ecdsa.PrivateKey, chainID *big
 	return signedEncodedTx, nil
 }
 
+// HexToEther formats a hex amount of wei, e.g. a balance, in ether.
 func HexToEther(hexBalance string) (string, error) {
-	balance, ok := new(big.Int).SetString(hexBalance[2:], 16)
-	if !ok {
-		return "", fmt.Errorf("failed to convert hex to big.Int")
+	balance, err := hexutil.DecodeBig(hexBalance)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert hex to big.Int: %w", err)
 	}
-	ether := new(big.Float).SetInt(balance)
-	// Convert wei to ether
-	ether.Quo(ether, big.NewFloat(1e18))
 
-	return ether.String(), nil
+	return Ether(balance).String(), nil
 }
 
+// EtherToWei parses an amount of ether, with at most 18 decimal places, into wei.
 func EtherToWei(ether string) (*big.Int, error) {
-	etherFloat, _, err := new(big.Float).Parse(ether, 10)
+	amount, err := ParseEther(ether)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse ether: %w", err)
 	}
 
-	wei := new(big.Int)
-	etherInt := new(big.Int)
-	etherFloat.Mul(etherFloat, big.NewFloat(1e18))
-	etherFloat.Int(etherInt)
-	wei.Set(etherInt)
-
-	return wei, nil
+	return amount.Units(), nil
 }
 
 func CalculateTotalGasCostInEther(gasEstimate uint64, gasPrice *big.Int) string {
 	gasEstimateBigInt := new(big.Int).SetUint64(gasEstimate)
 	totalGasCostWei := new(big.Int).Mul(gasEstimateBigInt, gasPrice)
-	weiFloat := new(big.Float).SetInt(totalGasCostWei)
-	etherValue := new(big.Float).Quo(weiFloat, big.NewFloat(1e18))
 
-	return etherValue.Text('f', 18)
+	return Ether(totalGasCostWei).String()
 }
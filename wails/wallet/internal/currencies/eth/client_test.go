@@ -0,0 +1,95 @@
+package eth_test
+
+import (
+	"context"
+	"testing"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethsim"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	hardhatAccount0 = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	hardhatAccount1 = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+)
+
+func TestClientWithSimulatedChain(t *testing.T) {
+	backend := newSimulatedBackend(t, map[string]int64{hardhatAccount0: 100})
+	client := eth.NewClientWithBackend(backend)
+	ctx := context.Background()
+
+	t.Run("Chain ID and genesis balance", func(t *testing.T) {
+		chainID, err := client.GetChainID(ctx)
+		if err != nil {
+			t.Fatalf("Failed to get chain ID: %v", err)
+		}
+		assertCorrectValue(t, chainID, int64(ethsim.ChainID))
+
+		assertBalance(t, client, hardhatAccount0, "100")
+	})
+
+	t.Run("Transfer is mined and moves the value", func(t *testing.T) {
+		privateKey, err := crypto.HexToECDSA(hardhatKey0)
+		if err != nil {
+			t.Fatalf("Failed to parse private key: %v", err)
+		}
+
+		value, err := eth.EtherToWei("2.5")
+		if err != nil {
+			t.Fatalf("Failed to parse value: %v", err)
+		}
+
+		gas, err := client.EstimateGas(ctx, hardhatAccount0, hardhatAccount1, value)
+		if err != nil {
+			t.Fatalf("Failed to estimate gas: %v", err)
+		}
+		assertCorrectValue(t, gas, uint64(21000))
+
+		_, err = client.ProcessTransaction(ctx, hardhatAccount0, hardhatAccount1, value, privateKey)
+		if err != nil {
+			t.Fatalf("Failed to send transaction: %v", err)
+		}
+		backend.Commit()
+
+		assertBalance(t, client, hardhatAccount1, "2.5")
+		nonce, err := client.GetNonce(ctx, hardhatAccount0)
+		if err != nil {
+			t.Fatalf("Failed to get nonce: %v", err)
+		}
+		assertCorrectValue(t, nonce, uint64(1))
+	})
+
+	t.Run("Node errors are returned", func(t *testing.T) {
+		_, err := client.BroadcastTransaction(ctx, "0x1234")
+		if err == nil {
+			t.Errorf("Expected an error broadcasting an invalid transaction")
+		}
+	})
+}
+
+func newSimulatedBackend(t testing.TB, balances map[string]int64) *ethsim.Backend {
+	t.Helper()
+	backend, err := ethsim.NewBackend(balances)
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	t.Cleanup(func() { backend.Close() })
+
+	return backend
+}
+
+func assertBalance(t testing.TB, client *eth.Client, address, want string) {
+	t.Helper()
+	balanceHex, err := client.GetBalance(context.Background(), address)
+	if err != nil {
+		t.Fatalf("Failed to get balance: %v", err)
+	}
+
+	balance, err := eth.HexToEther(balanceHex)
+	if err != nil {
+		t.Fatalf("Failed to convert balance: %v", err)
+	}
+
+	assertCorrectValue(t, balance, want)
+}
@@ -0,0 +1,392 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/google/uuid"
+)
+
+// Contract is an entry of the ABI registry: a deployed contract and the methods its ABI exposes.
+type Contract struct {
+	Name    string           `json:"name"`
+	Address string           `json:"address"`
+	Methods []ContractMethod `json:"methods"`
+	Events  []ContractEvent  `json:"events"`
+	abiJSON []byte
+	abi     abi.ABI
+}
+
+type ContractMethod struct {
+	Name      string          `json:"name"`
+	Signature string          `json:"signature"`
+	Inputs    []ContractParam `json:"inputs"`
+	Outputs   []ContractParam `json:"outputs"`
+	ReadOnly  bool            `json:"readOnly"`
+	Payable   bool            `json:"payable"`
+}
+
+// ContractEvent is an event declared in the ABI of a contract.
+type ContractEvent struct {
+	Name      string          `json:"name"`
+	Signature string          `json:"signature"`
+	Inputs    []ContractParam `json:"inputs"`
+}
+
+// ContractParam describes a method input or output, or an event field. Value is only set
+// on decoded call results and events.
+type ContractParam struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Value   string `json:"value,omitempty"`
+	Indexed bool   `json:"indexed,omitempty"`
+}
+
+// ParseContractABI accepts either a plain JSON ABI or a Hardhat artifact holding it
+// under the "abi" key, and returns the ABI JSON together with its parsed form.
+func ParseContractABI(data []byte) ([]byte, abi.ABI, error) {
+	abiJSON := bytes.TrimSpace(data)
+	if bytes.HasPrefix(abiJSON, []byte("{")) {
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		err := json.Unmarshal(abiJSON, &artifact)
+		if err != nil {
+			return nil, abi.ABI{}, fmt.Errorf("failed to unmarshal contract artifact: %w", err)
+		}
+
+		if len(artifact.ABI) == 0 {
+			return nil, abi.ABI{}, fmt.Errorf("contract artifact does not contain an ABI")
+		}
+		abiJSON = artifact.ABI
+	}
+
+	parsed, err := abi.JSON(bytes.NewReader(abiJSON))
+	if err != nil {
+		return nil, abi.ABI{}, fmt.Errorf("failed to parse contract ABI: %w", err)
+	}
+
+	return abiJSON, parsed, nil
+}
+
+func NewContract(name, address string, abiData []byte) (*Contract, error) {
+	if name == "" {
+		return nil, fmt.Errorf("contract name is required")
+	}
+
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid contract address %q", address)
+	}
+
+	abiJSON, parsed, err := ParseContractABI(abiData)
+	if err != nil {
+		return nil, err
+	}
+
+	methods := make([]ContractMethod, 0, len(parsed.Methods))
+	for key, method := range parsed.Methods {
+		methods = append(methods, ContractMethod{
+			Name:      key,
+			Signature: method.Sig,
+			Inputs:    describeArguments(method.Inputs),
+			Outputs:   describeArguments(method.Outputs),
+			ReadOnly:  method.IsConstant(),
+			Payable:   method.IsPayable(),
+		})
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
+
+	events := make([]ContractEvent, 0, len(parsed.Events))
+	for key, event := range parsed.Events {
+		events = append(events, ContractEvent{
+			Name:      key,
+			Signature: event.Sig,
+			Inputs:    describeArguments(event.Inputs),
+		})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Name < events[j].Name })
+
+	return &Contract{
+		Name:    name,
+		Address: common.HexToAddress(address).Hex(),
+		Methods: methods,
+		Events:  events,
+		abiJSON: abiJSON,
+		abi:     parsed,
+	}, nil
+}
+
+// Method looks up a method by the name it has in the ABI. Overloaded methods are
+// numbered by go-ethereum, so the second "transfer" is "transfer0".
+func (c *Contract) Method(name string) (*ContractMethod, error) {
+	for i := range c.Methods {
+		if c.Methods[i].Name == name {
+			return &c.Methods[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("contract %s has no method %q", c.Name, name)
+}
+
+// Event looks up an event by the name it has in the ABI.
+func (c *Contract) Event(name string) (*ContractEvent, error) {
+	for i := range c.Events {
+		if c.Events[i].Name == name {
+			return &c.Events[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("contract %s has no event %q", c.Name, name)
+}
+
+// EncodeCall packs the method selector and its arguments, given as strings, into calldata.
+func (c *Contract) EncodeCall(name string, args []string) ([]byte, error) {
+	method, ok := c.abi.Methods[name]
+	if !ok {
+		return nil, fmt.Errorf("contract %s has no method %q", c.Name, name)
+	}
+
+	if len(args) != len(method.Inputs) {
+		return nil, fmt.Errorf("method %s expects %d arguments, got %d", method.Sig, len(method.Inputs), len(args))
+	}
+
+	values := make([]interface{}, 0, len(args))
+	for i, input := range method.Inputs {
+		value, err := parseABIArgument(input.Type, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %s (%s): %w", input.Name, input.Type.String(), err)
+		}
+		values = append(values, value)
+	}
+
+	data, err := c.abi.Pack(name, values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s call: %w", method.Sig, err)
+	}
+
+	return data, nil
+}
+
+// DecodeResult unpacks the data returned by eth_call into readable output values.
+func (c *Contract) DecodeResult(name string, data []byte) ([]ContractParam, error) {
+	method, ok := c.abi.Methods[name]
+	if !ok {
+		return nil, fmt.Errorf("contract %s has no method %q", c.Name, name)
+	}
+
+	if len(data) == 0 && len(method.Outputs) > 0 {
+		return nil, fmt.Errorf("empty result calling %s, is the contract deployed at %s?", method.Sig, c.Address)
+	}
+
+	values, err := method.Outputs.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s result: %w", method.Sig, err)
+	}
+
+	results := describeArguments(method.Outputs)
+	for i := range results {
+		results[i].Value = formatABIValue(values[i])
+	}
+
+	return results, nil
+}
+
+// Call executes a read-only contract call against the latest block.
+func (c *Client) Call(ctx context.Context, from, to string, data []byte) ([]byte, error) {
+	callObject := map[string]interface{}{
+		"to":   to,
+		"data": hexutil.Encode(data),
+	}
+	if from != "" {
+		callObject["from"] = from
+	}
+
+	payload := RPCPayload{
+		Jsonrpc: "2.0",
+		Method:  "eth_call",
+		Params:  []interface{}{callObject, "latest"},
+		ID:      uuid.New().String(),
+	}
+
+	response, err := c.sendRequestToNode(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call contract: %w", err)
+	}
+
+	resultHex, ok := response["result"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type: expected string")
+	}
+
+	return hexutil.Decode(resultHex)
+}
+
+func describeArguments(arguments abi.Arguments) []ContractParam {
+	params := make([]ContractParam, 0, len(arguments))
+	for _, argument := range arguments {
+		params = append(params, ContractParam{
+			Name:    argument.Name,
+			Type:    argument.Type.String(),
+			Indexed: argument.Indexed,
+		})
+	}
+
+	return params
+}
+
+// parseABIArgument converts a user supplied string into the Go value the ABI encoder
+// expects for t. Arrays are written as JSON arrays, e.g. ["0x01", "0x02"].
+func parseABIArgument(t abi.Type, value string) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		return parseABIInteger(t, value)
+	case abi.BoolTy:
+		return strconv.ParseBool(value)
+	case abi.StringTy:
+		return value, nil
+	case abi.AddressTy:
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid address %q", value)
+		}
+		return common.HexToAddress(value), nil
+	case abi.BytesTy:
+		return hexutil.Decode(value)
+	case abi.FixedBytesTy:
+		data, err := hexutil.Decode(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(data) != t.Size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", t.Size, len(data))
+		}
+
+		fixed := reflect.New(t.GetType()).Elem()
+		reflect.Copy(fixed, reflect.ValueOf(data))
+		return fixed.Interface(), nil
+	case abi.SliceTy, abi.ArrayTy:
+		return parseABIList(t, value)
+	default:
+		return nil, fmt.Errorf("arguments of type %s are not supported", t.String())
+	}
+}
+
+func parseABIInteger(t abi.Type, value string) (interface{}, error) {
+	number, ok := new(big.Int).SetString(value, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", value)
+	}
+
+	bits := number.BitLen()
+	if t.T == abi.IntTy {
+		if number.Sign() < 0 {
+			bits = new(big.Int).Sub(new(big.Int).Neg(number), big.NewInt(1)).BitLen()
+		}
+		bits++
+	} else if number.Sign() < 0 {
+		return nil, fmt.Errorf("negative value %s for an unsigned integer", value)
+	}
+
+	if bits > t.Size {
+		return nil, fmt.Errorf("value %s overflows %s", value, t.String())
+	}
+
+	goType := t.GetType()
+	if goType == reflect.TypeOf(number) {
+		return number, nil
+	}
+
+	if t.T == abi.IntTy {
+		return reflect.ValueOf(number.Int64()).Convert(goType).Interface(), nil
+	}
+
+	return reflect.ValueOf(number.Uint64()).Convert(goType).Interface(), nil
+}
+
+func parseABIList(t abi.Type, value string) (interface{}, error) {
+	var items []json.RawMessage
+	err := json.Unmarshal([]byte(value), &items)
+	if err != nil {
+		return nil, fmt.Errorf("expected a JSON array: %w", err)
+	}
+
+	if t.T == abi.ArrayTy && len(items) != t.Size {
+		return nil, fmt.Errorf("expected %d items, got %d", t.Size, len(items))
+	}
+
+	list := reflect.New(t.GetType()).Elem()
+	if t.T == abi.SliceTy {
+		list = reflect.MakeSlice(t.GetType(), len(items), len(items))
+	}
+
+	for i, item := range items {
+		// Items can be JSON strings or bare numbers and booleans.
+		text := string(item)
+		var itemString string
+		if json.Unmarshal(item, &itemString) == nil {
+			text = itemString
+		}
+
+		parsed, err := parseABIArgument(*t.Elem, text)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		list.Index(i).Set(reflect.ValueOf(parsed))
+	}
+
+	return list.Interface(), nil
+}
+
+func formatABIValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case *big.Int:
+		return v.String()
+	case []byte:
+		return hexutil.Encode(v)
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(data), rv)
+			return hexutil.Encode(data)
+		}
+
+		items := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			items = append(items, formatABIValue(rv.Index(i).Interface()))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Struct:
+		fields := make([]string, 0, rv.NumField())
+		for i := 0; i < rv.NumField(); i++ {
+			name := rv.Type().Field(i).Tag.Get("json")
+			if name == "" {
+				name = rv.Type().Field(i).Name
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s", name, formatABIValue(rv.Field(i).Interface())))
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	default:
+		return fmt.Sprint(value)
+	}
+}
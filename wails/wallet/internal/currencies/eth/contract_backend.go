@@ -0,0 +1,196 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+)
+
+var _ bind.ContractBackend = (*ContractBackend)(nil)
+
+// ContractBackend lets the generated contract bindings talk to the node through Client.
+type ContractBackend struct {
+	client *Client
+}
+
+func NewContractBackend(client *Client) *ContractBackend {
+	return &ContractBackend{client: client}
+}
+
+func (b *ContractBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return b.code(ctx, contract, blockTag(blockNumber))
+}
+
+func (b *ContractBackend) PendingCodeAt(ctx context.Context, contract common.Address) ([]byte, error) {
+	return b.code(ctx, contract, "pending")
+}
+
+func (b *ContractBackend) code(ctx context.Context, contract common.Address, block string) ([]byte, error) {
+	var code hexutil.Bytes
+	err := b.call(ctx, &code, "eth_getCode", contract.Hex(), block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code: %w", err)
+	}
+
+	return code, nil
+}
+
+func (b *ContractBackend) CallContract(
+	ctx context.Context,
+	call ethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	var result hexutil.Bytes
+	err := b.call(ctx, &result, "eth_call", callArg(call), blockTag(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call contract: %w", err)
+	}
+
+	return result, nil
+}
+
+func (b *ContractBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var header *types.Header
+	err := b.call(ctx, &header, "eth_getBlockByNumber", blockTag(number), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header: %w", err)
+	}
+
+	if header == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return header, nil
+}
+
+func (b *ContractBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var nonce hexutil.Uint64
+	err := b.call(ctx, &nonce, "eth_getTransactionCount", account.Hex(), "pending")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+
+	return uint64(nonce), nil
+}
+
+func (b *ContractBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return b.client.GetGasPrice(ctx)
+}
+
+func (b *ContractBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var tipCap hexutil.Big
+	err := b.call(ctx, &tipCap, "eth_maxPriorityFeePerGas")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas tip cap: %w", err)
+	}
+
+	return tipCap.ToInt(), nil
+}
+
+func (b *ContractBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return b.client.estimateGas(ctx, callArg(call))
+}
+
+func (b *ContractBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	rawTx, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	_, err = b.client.BroadcastTransaction(ctx, hexutil.Encode(rawTx))
+	return err
+}
+
+func (b *ContractBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	filter := map[string]interface{}{
+		"address": query.Addresses,
+		"topics":  query.Topics,
+	}
+	if query.BlockHash != nil {
+		filter["blockHash"] = *query.BlockHash
+	} else {
+		filter["fromBlock"] = blockTag(query.FromBlock)
+		filter["toBlock"] = blockTag(query.ToBlock)
+	}
+
+	var logs []types.Log
+	err := b.call(ctx, &logs, "eth_getLogs", filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+func (b *ContractBackend) SubscribeFilterLogs(
+	_ context.Context,
+	_ ethereum.FilterQuery,
+	_ chan<- types.Log,
+) (ethereum.Subscription, error) {
+	return nil, errors.New("log subscriptions are not supported over HTTP")
+}
+
+// call sends a request through the client and decodes its result into result.
+func (b *ContractBackend) call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	response, err := b.client.sendRequestToNode(ctx, RPCPayload{
+		Jsonrpc: "2.0",
+		Method:  method,
+		Params:  params,
+		ID:      uuid.New().String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(response["result"])
+	if err != nil {
+		return fmt.Errorf("failed to read %s result: %w", method, err)
+	}
+
+	return json.Unmarshal(data, result)
+}
+
+func callArg(call ethereum.CallMsg) map[string]interface{} {
+	arg := map[string]interface{}{
+		"from": call.From,
+		"to":   call.To,
+	}
+	if len(call.Data) > 0 {
+		arg["data"] = hexutil.Bytes(call.Data)
+	}
+
+	if call.Value != nil {
+		arg["value"] = (*hexutil.Big)(call.Value)
+	}
+
+	if call.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(call.Gas)
+	}
+
+	if call.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(call.GasPrice)
+	}
+
+	return arg
+}
+
+func blockTag(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+
+	return hexutil.EncodeBig(number)
+}
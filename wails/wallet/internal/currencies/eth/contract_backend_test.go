@@ -0,0 +1,111 @@
+package eth_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet/internal/contracts"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestContractBackendBindings(t *testing.T) {
+	tokenABI, err := contracts.DemoTokenMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("Failed to parse DemoToken ABI: %v", err)
+	}
+
+	owner := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	balance, _ := new(big.Int).SetString("1000000000000000000000000", 10)
+	results := map[string][]interface{}{
+		"balanceOf": {balance},
+		"decimals":  {uint8(18)},
+	}
+
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     string            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+			return
+		}
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		var call struct {
+			To   common.Address `json:"to"`
+			Data hexutil.Bytes  `json:"data"`
+		}
+		if req.Method != "eth_call" || json.Unmarshal(req.Params[0], &call) != nil {
+			response["error"] = map[string]interface{}{"code": -32601, "message": "unexpected request"}
+		} else if method, err := tokenABI.MethodById(call.Data); err != nil {
+			response["error"] = map[string]interface{}{"code": 3, "message": "execution reverted"}
+		} else {
+			if method.Name == "balanceOf" {
+				args, _ := method.Inputs.Unpack(call.Data[4:])
+				assertCorrectValue(t, args[0].(common.Address), owner)
+			}
+
+			output, _ := method.Outputs.Pack(results[method.Name]...)
+			response["result"] = hexutil.Encode(output)
+		}
+
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer node.Close()
+
+	backend := eth.NewContractBackend(eth.NewClient(node.URL))
+	token, err := contracts.NewDemoTokenCaller(common.HexToAddress(contractAddress), backend)
+	if err != nil {
+		t.Fatalf("Failed to bind DemoToken: %v", err)
+	}
+
+	got, err := token.BalanceOf(&bind.CallOpts{}, owner)
+	if err != nil {
+		t.Fatalf("Failed to read balance: %v", err)
+	}
+	assertCorrectValue(t, got, balance)
+
+	decimals, err := token.Decimals(&bind.CallOpts{})
+	if err != nil {
+		t.Fatalf("Failed to read decimals: %v", err)
+	}
+	assertCorrectValue(t, decimals, uint8(18))
+
+	_, err = token.Name(&bind.CallOpts{})
+	if err == nil {
+		t.Errorf("Expected the reverted call to fail")
+	}
+}
+
+func TestParseVoteChoice(t *testing.T) {
+	cases := []struct {
+		choice string
+		want   uint8
+		valid  bool
+	}{
+		{choice: "approve", want: eth.VoteApprove, valid: true},
+		{choice: " Reject ", want: eth.VoteReject, valid: true},
+		{choice: "pass", want: eth.VotePass, valid: true},
+		{choice: "maybe", valid: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.choice, func(t *testing.T) {
+			got, err := eth.ParseVoteChoice(tc.choice)
+			assertCorrectValue(t, err == nil, tc.valid)
+			assertCorrectValue(t, got, tc.want)
+		})
+	}
+}
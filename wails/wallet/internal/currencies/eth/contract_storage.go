@@ -0,0 +1,109 @@
+package eth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type ContractStorage struct {
+	db *sql.DB
+}
+
+func NewContractStorage(ctx context.Context, db *sql.DB) (*ContractStorage, error) {
+	err := db.Ping()
+	if err != nil {
+		return nil, fmt.Errorf("error pinging database: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS ethContracts (name TEXT PRIMARY KEY, address TEXT, abi TEXT)")
+	if err != nil {
+		return nil, fmt.Errorf("error creating contracts table: %w", err)
+	}
+
+	return &ContractStorage{db: db}, nil
+}
+
+// SaveContract stores a contract, replacing the address and ABI of a contract
+// registered under the same name.
+func (c *ContractStorage) SaveContract(ctx context.Context, contract *Contract) error {
+	_, err := c.db.ExecContext(
+		ctx,
+		`INSERT INTO ethContracts (name, address, abi) VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET address = excluded.address, abi = excluded.abi`,
+		contract.Name, contract.Address, string(contract.abiJSON),
+	)
+	if err != nil {
+		return fmt.Errorf("error saving contract %s: %w", contract.Name, err)
+	}
+
+	return nil
+}
+
+// GetContract finds a contract by its name or address.
+func (c *ContractStorage) GetContract(ctx context.Context, contract string) (*Contract, error) {
+	var name, address, abiJSON string
+	err := c.db.QueryRowContext(
+		ctx,
+		"SELECT name, address, abi FROM ethContracts WHERE name = ? OR lower(address) = lower(?)",
+		contract, contract,
+	).Scan(&name, &address, &abiJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("contract %s is not registered", contract)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving contract %s from DB: %w", contract, err)
+	}
+
+	return NewContract(name, address, []byte(abiJSON))
+}
+
+func (c *ContractStorage) GetContracts(ctx context.Context) ([]*Contract, error) {
+	rows, err := c.db.QueryContext(ctx, "SELECT name, address, abi FROM ethContracts ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("error querying contracts: %w", err)
+	}
+
+	defer rows.Close()
+	var contracts []*Contract
+	for rows.Next() {
+		var name, address, abiJSON string
+		err = rows.Scan(&name, &address, &abiJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+
+		contract, err := NewContract(name, address, []byte(abiJSON))
+		if err != nil {
+			return nil, fmt.Errorf("error loading contract %s: %w", name, err)
+		}
+		contracts = append(contracts, contract)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving contract rows from db: %w", err)
+	}
+
+	return contracts, nil
+}
+
+func (c *ContractStorage) DeleteContract(ctx context.Context, name string) error {
+	result, err := c.db.ExecContext(ctx, "DELETE FROM ethContracts WHERE name = ?", name)
+	if err != nil {
+		return fmt.Errorf("error deleting contract %s: %w", name, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error deleting contract %s: %w", name, err)
+	}
+
+	if deleted == 0 {
+		return fmt.Errorf("contract %s is not registered", name)
+	}
+
+	return nil
+}
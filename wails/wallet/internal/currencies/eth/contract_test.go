@@ -0,0 +1,219 @@
+package eth_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const contractAddress = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+
+func TestContractABI(t *testing.T) {
+	voting := loadArtifactContract(t, "VotingProposal")
+	increment := loadArtifactContract(t, "Increment")
+
+	t.Run("Artifact methods are listed with their mutability", func(t *testing.T) {
+		vote, err := voting.Method("vote")
+		if err != nil {
+			t.Fatalf("Failed to find method: %v", err)
+		}
+
+		assertCorrectValue(t, vote.Signature, "vote(uint8)")
+		assertCorrectValue(t, vote.ReadOnly, false)
+		assertCorrectValue(t, vote.Inputs, []eth.ContractParam{{Name: "choice", Type: "uint8"}})
+
+		getCounter, err := increment.Method("getCounter")
+		if err != nil {
+			t.Fatalf("Failed to find method: %v", err)
+		}
+
+		assertCorrectValue(t, getCounter.ReadOnly, true)
+	})
+
+	t.Run("Call data starts with the method selector", func(t *testing.T) {
+		data, err := voting.EncodeCall("vote", []string{"1"})
+		if err != nil {
+			t.Fatalf("Failed to encode call: %v", err)
+		}
+
+		expected := append(crypto.Keccak256([]byte("vote(uint8)"))[:4], common.LeftPadBytes([]byte{1}, 32)...)
+		assertCorrectValue(t, hexutil.Encode(data), hexutil.Encode(expected))
+	})
+
+	t.Run("Invalid arguments are rejected", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			method string
+			args   []string
+		}{
+			{name: "uint8 overflow", method: "vote", args: []string{"256"}},
+			{name: "negative unsigned", method: "vote", args: []string{"-1"}},
+			{name: "invalid address", method: "setOwner", args: []string{"0x1234"}},
+			{name: "missing argument", method: "create", args: []string{"proposal"}},
+			{name: "unknown method", method: "launch", args: nil},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := voting.EncodeCall(tc.method, tc.args)
+				if err == nil {
+					t.Errorf("Expected an error encoding %s(%v)", tc.method, tc.args)
+				}
+			})
+		}
+	})
+
+	t.Run("Struct results are decoded", func(t *testing.T) {
+		getProposal, err := voting.Method("getCurrentProposal")
+		if err != nil {
+			t.Fatalf("Failed to find method: %v", err)
+		}
+
+		proposalType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+			{Name: "description", Type: "string"},
+			{Name: "approve", Type: "uint256"},
+			{Name: "reject", Type: "uint256"},
+			{Name: "pass", Type: "uint256"},
+			{Name: "total_vote_to_end", Type: "uint256"},
+			{Name: "current_state", Type: "bool"},
+			{Name: "is_active", Type: "bool"},
+		})
+		if err != nil {
+			t.Fatalf("Failed to build tuple type: %v", err)
+		}
+
+		proposal := struct {
+			Description    string
+			Approve        *big.Int
+			Reject         *big.Int
+			Pass           *big.Int
+			TotalVoteToEnd *big.Int
+			CurrentState   bool
+			IsActive       bool
+		}{"Fund the demo", big.NewInt(2), big.NewInt(1), big.NewInt(0), big.NewInt(5), false, true}
+		data, err := abi.Arguments{{Type: proposalType}}.Pack(proposal)
+		if err != nil {
+			t.Fatalf("Failed to pack result: %v", err)
+		}
+
+		results, err := voting.DecodeResult(getProposal.Name, data)
+		if err != nil {
+			t.Fatalf("Failed to decode result: %v", err)
+		}
+
+		assertCorrectValue(t, len(results), 1)
+		assertCorrectValue(
+			t,
+			results[0].Value,
+			"{description: Fund the demo, approve: 2, reject: 1, pass: 0, total_vote_to_end: 5, "+
+				"current_state: false, is_active: true}",
+		)
+	})
+}
+
+func TestContractCall(t *testing.T) {
+	increment := loadArtifactContract(t, "Increment")
+	revertData, err := (abi.Arguments{{Type: mustNewType(t, "string")}}).Pack("Only owner can call this function")
+	if err != nil {
+		t.Fatalf("Failed to pack revert reason: %v", err)
+	}
+	revertData = append(crypto.Keccak256([]byte("Error(string)"))[:4], revertData...)
+
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req eth.RPCPayload
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+			return
+		}
+
+		callObject, _ := req.Params[0].(map[string]interface{})
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch callObject["data"] {
+		case hexutil.Encode(crypto.Keccak256([]byte("getCounter()"))[:4]):
+			response["result"] = hexutil.Encode(common.LeftPadBytes(big.NewInt(42).Bytes(), 32))
+		default:
+			response["error"] = map[string]interface{}{
+				"code":    3,
+				"message": "execution reverted",
+				"data":    hexutil.Encode(revertData),
+			}
+		}
+
+		err = json.NewEncoder(w).Encode(response)
+		if err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	defer node.Close()
+	client := eth.NewClient(node.URL)
+
+	t.Run("View call result is decoded", func(t *testing.T) {
+		data, err := increment.EncodeCall("getCounter", nil)
+		if err != nil {
+			t.Fatalf("Failed to encode call: %v", err)
+		}
+
+		result, err := client.Call(context.Background(), "", increment.Address, data)
+		if err != nil {
+			t.Fatalf("Failed to call contract: %v", err)
+		}
+
+		values, err := increment.DecodeResult("getCounter", result)
+		if err != nil {
+			t.Fatalf("Failed to decode result: %v", err)
+		}
+
+		assertCorrectValue(t, values, []eth.ContractParam{{Name: "", Type: "uint256", Value: "42"}})
+	})
+
+	t.Run("Revert reason is reported", func(t *testing.T) {
+		data, err := increment.EncodeCall("increment", nil)
+		if err != nil {
+			t.Fatalf("Failed to encode call: %v", err)
+		}
+
+		_, err = client.Call(context.Background(), "", increment.Address, data)
+		if err == nil || !strings.Contains(err.Error(), "Only owner can call this function") {
+			t.Errorf("Expected the revert reason in the error, got %v", err)
+		}
+	})
+}
+
+func loadArtifactContract(t testing.TB, name string) *eth.Contract {
+	t.Helper()
+	path := filepath.Join("..", "..", "..", "..", "..", "hardhat", "artifacts", "contracts", name+".sol", name+".json")
+	artifact, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s artifact: %v", name, err)
+	}
+
+	contract, err := eth.NewContract(name, contractAddress, artifact)
+	if err != nil {
+		t.Fatalf("Failed to load %s contract: %v", name, err)
+	}
+
+	return contract
+}
+
+func mustNewType(t testing.TB, typeName string) abi.Type {
+	t.Helper()
+	abiType, err := abi.NewType(typeName, "", nil)
+	if err != nil {
+		t.Fatalf("Failed to build %s type: %v", typeName, err)
+	}
+
+	return abiType
+}
@@ -0,0 +1,327 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"wallet/internal/contracts"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/tyler-smith/go-bip32"
+)
+
+// Choices accepted by VotingProposal.vote.
+const (
+	VotePass    uint8 = 0
+	VoteApprove uint8 = 1
+	VoteReject  uint8 = 2
+)
+
+type TokenBalance struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+	Balance  string `json:"balance"`
+}
+
+// Proposal is the state of a VotingProposal proposal. CurrentState tells whether it
+// passes with the votes cast so far.
+type Proposal struct {
+	Description    string `json:"description"`
+	Approve        string `json:"approve"`
+	Reject         string `json:"reject"`
+	Pass           string `json:"pass"`
+	TotalVoteToEnd string `json:"totalVoteToEnd"`
+	CurrentState   bool   `json:"currentState"`
+	IsActive       bool   `json:"isActive"`
+}
+
+func ParseVoteChoice(choice string) (uint8, error) {
+	switch strings.ToLower(strings.TrimSpace(choice)) {
+	case "pass":
+		return VotePass, nil
+	case "approve":
+		return VoteApprove, nil
+	case "reject":
+		return VoteReject, nil
+	default:
+		return 0, fmt.Errorf("invalid vote %q, expected approve, reject or pass", choice)
+	}
+}
+
+func (a *MasterAccount) GetDemoTokenBalance(contract string, accountIndex int) (*TokenBalance, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	address, err := a.contractAddress(cliCtx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := a.accountDB.GetAccountAddress(cliCtx, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account address from DB: %w", err)
+	}
+
+	token, err := contracts.NewDemoTokenCaller(address, NewContractBackend(a.client))
+	if err != nil {
+		return nil, fmt.Errorf("error binding DemoToken contract: %w", err)
+	}
+
+	opts := &bind.CallOpts{Context: cliCtx}
+	balance, err := token.BalanceOf(opts, common.HexToAddress(owner))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving DemoToken balance: %w", err)
+	}
+
+	decimals, err := token.Decimals(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving DemoToken decimals: %w", err)
+	}
+
+	name, err := token.Name(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving DemoToken name: %w", err)
+	}
+
+	symbol, err := token.Symbol(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving DemoToken symbol: %w", err)
+	}
+
+	return &TokenBalance{
+		Name:     name,
+		Symbol:   symbol,
+		Decimals: decimals,
+		Balance:  NewAmount(balance, decimals).String(),
+	}, nil
+}
+
+func (a *MasterAccount) GetCurrentProposal(contract string) (*Proposal, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	address, err := a.contractAddress(cliCtx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	voting, err := contracts.NewVotingProposalCaller(address, NewContractBackend(a.client))
+	if err != nil {
+		return nil, fmt.Errorf("error binding VotingProposal contract: %w", err)
+	}
+
+	proposal, err := voting.GetCurrentProposal(&bind.CallOpts{Context: cliCtx})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving current proposal: %w", err)
+	}
+
+	return &Proposal{
+		Description:    proposal.Description,
+		Approve:        proposal.Approve.String(),
+		Reject:         proposal.Reject.String(),
+		Pass:           proposal.Pass.String(),
+		TotalVoteToEnd: proposal.TotalVoteToEnd.String(),
+		CurrentState:   proposal.CurrentState,
+		IsActive:       proposal.IsActive,
+	}, nil
+}
+
+// CreateProposal sends the transaction creating a proposal. Only the owner of the
+// contract can create proposals.
+func (a *MasterAccount) CreateProposal(
+	contract, description string,
+	votesToEnd uint64,
+	masterKey *bip32.Key,
+	accountIndex int,
+) (*SentTransaction, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	address, err := a.contractAddress(cliCtx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	voting, err := contracts.NewVotingProposalTransactor(address, NewContractBackend(a.client))
+	if err != nil {
+		return nil, fmt.Errorf("error binding VotingProposal contract: %w", err)
+	}
+
+	transact := func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		tx, err := voting.Create(opts, description, new(big.Int).SetUint64(votesToEnd))
+		if err != nil {
+			return nil, fmt.Errorf("error creating proposal: %w", err)
+		}
+
+		return tx, nil
+	}
+
+	return a.sendContractTransaction(cliCtx, masterKey, accountIndex, transact)
+}
+
+// VoteProposal sends the transaction voting on the current proposal.
+func (a *MasterAccount) VoteProposal(
+	contract string,
+	choice uint8,
+	masterKey *bip32.Key,
+	accountIndex int,
+) (*SentTransaction, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	address, err := a.contractAddress(cliCtx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	voting, err := contracts.NewVotingProposalTransactor(address, NewContractBackend(a.client))
+	if err != nil {
+		return nil, fmt.Errorf("error binding VotingProposal contract: %w", err)
+	}
+
+	transact := func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		tx, err := voting.Vote(opts, choice)
+		if err != nil {
+			return nil, fmt.Errorf("error voting on proposal: %w", err)
+		}
+
+		return tx, nil
+	}
+
+	return a.sendContractTransaction(cliCtx, masterKey, accountIndex, transact)
+}
+
+func (a *MasterAccount) GetCounter(contract string) (string, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	address, err := a.contractAddress(cliCtx, contract)
+	if err != nil {
+		return "", err
+	}
+
+	counter, err := contracts.NewIncrementCaller(address, NewContractBackend(a.client))
+	if err != nil {
+		return "", fmt.Errorf("error binding Increment contract: %w", err)
+	}
+
+	value, err := counter.GetCounter(&bind.CallOpts{Context: cliCtx})
+	if err != nil {
+		return "", fmt.Errorf("error retrieving counter: %w", err)
+	}
+
+	return value.String(), nil
+}
+
+// IncrementCounter sends the transaction incrementing the counter. Only the owner of
+// the contract can increment it.
+func (a *MasterAccount) IncrementCounter(
+	contract string,
+	masterKey *bip32.Key,
+	accountIndex int,
+) (*SentTransaction, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	address, err := a.contractAddress(cliCtx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	counter, err := contracts.NewIncrementTransactor(address, NewContractBackend(a.client))
+	if err != nil {
+		return nil, fmt.Errorf("error binding Increment contract: %w", err)
+	}
+
+	transact := func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		tx, err := counter.Increment(opts)
+		if err != nil {
+			return nil, fmt.Errorf("error incrementing counter: %w", err)
+		}
+
+		return tx, nil
+	}
+
+	return a.sendContractTransaction(cliCtx, masterKey, accountIndex, transact)
+}
+
+// contractAddress accepts a contract address or the name it was registered under.
+func (a *MasterAccount) contractAddress(ctx context.Context, contract string) (common.Address, error) {
+	if common.IsHexAddress(contract) {
+		return common.HexToAddress(contract), nil
+	}
+
+	registered, err := a.contractDB.GetContract(ctx, contract)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return common.HexToAddress(registered.Address), nil
+}
+
+// sendContractTransaction signs the binding transaction built by transact with the
+// account key and the next nonce of the account, and broadcasts it.
+func (a *MasterAccount) sendContractTransaction(
+	ctx context.Context,
+	masterKey *bip32.Key,
+	accountIndex int,
+	transact func(opts *bind.TransactOpts) (*types.Transaction, error),
+) (*SentTransaction, error) {
+	opts, err := a.transactOpts(ctx, masterKey, accountIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.nonces.Send(ctx, opts.From.Hex(), func(nonce uint64) (string, error) {
+		opts.Nonce = new(big.Int).SetUint64(nonce)
+		tx, err := transact(opts)
+		if err != nil {
+			return "", err
+		}
+
+		return encodeSignedTransaction(tx)
+	})
+}
+
+// transactOpts signs binding transactions with the account key without sending them,
+// so they are broadcast and recorded like any other wallet transaction. A gas price
+// is always set to keep producing legacy transactions.
+func (a *MasterAccount) transactOpts(
+	ctx context.Context,
+	masterKey *bip32.Key,
+	accountIndex int,
+) (*bind.TransactOpts, error) {
+	privateKey, err := derivePrivateKey(masterKey, accountIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, err := a.client.GetChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving chain ID: %w", err)
+	}
+
+	gasPrice, err := a.client.GetGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving gas price: %w", err)
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(chainID))
+	if err != nil {
+		return nil, fmt.Errorf("error creating transactor: %w", err)
+	}
+
+	opts.Context = ctx
+	opts.GasPrice = gasPrice
+	opts.NoSend = true
+	return opts, nil
+}
+
+func encodeSignedTransaction(tx *types.Transaction) (string, error) {
+	rawTx, err := tx.MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("error encoding signed transaction: %w", err)
+	}
+
+	return hexutil.Encode(rawTx), nil
+}
@@ -0,0 +1,231 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// deploymentTimeout is how long a deployment is waited for before giving up on its receipt.
+	deploymentTimeout = 2 * time.Minute
+	// receiptPollInterval is how often the receipt of a deployment is looked up.
+	receiptPollInterval = time.Second
+)
+
+// ContractArtifact is a contract compiled by Hardhat: its ABI and the bytecode deploying it.
+type ContractArtifact struct {
+	ContractName string          `json:"contractName"`
+	Constructor  []ContractParam `json:"constructor"`
+	Payable      bool            `json:"payable"`
+	artifactJSON []byte
+	abi          abi.ABI
+	bytecode     []byte
+}
+
+// ParseContractArtifact reads a Hardhat artifact. Abstract contracts and interfaces have no
+// bytecode, and contracts using external libraries have to be linked first.
+func ParseContractArtifact(data []byte) (*ContractArtifact, error) {
+	var artifact struct {
+		ContractName string `json:"contractName"`
+		Bytecode     string `json:"bytecode"`
+	}
+	err := json.Unmarshal(bytes.TrimSpace(data), &artifact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal contract artifact: %w", err)
+	}
+
+	_, parsed, err := ParseContractABI(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(artifact.Bytecode, "__$") {
+		return nil, fmt.Errorf("contract %s needs linked libraries", artifact.ContractName)
+	}
+
+	bytecode, err := hexutil.Decode(artifact.Bytecode)
+	if err != nil || len(bytecode) == 0 {
+		return nil, fmt.Errorf("contract artifact %s does not contain deployable bytecode", artifact.ContractName)
+	}
+
+	return &ContractArtifact{
+		ContractName: artifact.ContractName,
+		Constructor:  describeArguments(parsed.Constructor.Inputs),
+		Payable:      parsed.Constructor.IsPayable(),
+		artifactJSON: bytes.TrimSpace(data),
+		abi:          parsed,
+		bytecode:     bytecode,
+	}, nil
+}
+
+// EncodeDeployment appends the constructor arguments, given as strings, to the bytecode.
+func (a *ContractArtifact) EncodeDeployment(args []string) ([]byte, error) {
+	inputs := a.abi.Constructor.Inputs
+	if len(args) != len(inputs) {
+		return nil, fmt.Errorf("constructor of %s expects %d arguments, got %d", a.ContractName, len(inputs), len(args))
+	}
+
+	values := make([]interface{}, 0, len(args))
+	for i, input := range inputs {
+		value, err := parseABIArgument(input.Type, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %s (%s): %w", input.Name, input.Type.String(), err)
+		}
+		values = append(values, value)
+	}
+
+	encodedArgs, err := a.abi.Pack("", values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode constructor of %s: %w", a.ContractName, err)
+	}
+
+	return append(bytes.Clone(a.bytecode), encodedArgs...), nil
+}
+
+// Deployment is a contract deployed from the wallet and registered in the ABI registry.
+type Deployment struct {
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	Hash        string `json:"hash"`
+	BlockNumber uint64 `json:"blockNumber"`
+	GasUsed     uint64 `json:"gasUsed"`
+}
+
+// DeploymentRequest builds the transaction deploying an artifact from an account. value is
+// the amount of ether sent to a payable constructor.
+func (a *MasterAccount) DeploymentRequest(
+	artifactJSON []byte,
+	args []string,
+	value string,
+	accountIndex int,
+) (*TransactionRequest, error) {
+	artifact, err := ParseContractArtifact(artifactJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	weiValue := new(big.Int)
+	if value != "" {
+		weiValue, err = EtherToWei(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing ether value into wei: %w", err)
+		}
+	}
+
+	if weiValue.Sign() > 0 && !artifact.Payable {
+		return nil, fmt.Errorf("constructor of %s is not payable", artifact.ContractName)
+	}
+
+	data, err := artifact.EncodeDeployment(args)
+	if err != nil {
+		return nil, err
+	}
+
+	dbCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	from, err := a.accountDB.GetAccountAddress(dbCtx, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account address from DB: %w", err)
+	}
+
+	return &TransactionRequest{
+		From:  common.HexToAddress(from),
+		Value: (*hexutil.Big)(weiValue),
+		Data:  data,
+	}, nil
+}
+
+// EstimateDeploymentGas returns the fee of deploying an artifact, in ether.
+func (a *MasterAccount) EstimateDeploymentGas(
+	artifactJSON []byte,
+	args []string,
+	value string,
+	accountIndex int,
+) (string, error) {
+	req, err := a.DeploymentRequest(artifactJSON, args, value, accountIndex)
+	if err != nil {
+		return "", err
+	}
+
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	unsignedTx, err := a.client.FillTransaction(cliCtx, req)
+	if err != nil {
+		return "", fmt.Errorf("error estimating gas: %w", err)
+	}
+
+	return CalculateTotalGasCostInEther(uint64(unsignedTx.GasLimit), unsignedTx.GasPrice.ToInt()), nil
+}
+
+// WaitForDeployment waits for the receipt of a deployment transaction, then registers the
+// contract under name, or the name of the artifact when empty.
+func (a *MasterAccount) WaitForDeployment(name string, artifactJSON []byte, hash string) (*Deployment, error) {
+	artifact, err := ParseContractArtifact(artifactJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = artifact.ContractName
+	}
+
+	cliCtx, cancel := context.WithTimeout(a.ctx, deploymentTimeout)
+	defer cancel()
+	receipt, err := a.client.WaitForReceipt(cliCtx, hash, receiptPollInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	if !receipt.Succeeded() {
+		return nil, fmt.Errorf("deployment of %s reverted in transaction %s", name, hash)
+	}
+
+	if receipt.ContractAddress == nil {
+		return nil, fmt.Errorf("transaction %s did not create a contract", hash)
+	}
+
+	contract, err := a.RegisterContract(name, receipt.ContractAddress.Hex(), artifact.artifactJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Deployment{
+		Name:        contract.Name,
+		Address:     contract.Address,
+		Hash:        hash,
+		BlockNumber: uint64(receipt.BlockNumber),
+		GasUsed:     uint64(receipt.GasUsed),
+	}, nil
+}
+
+// WaitForReceipt looks up the receipt of a transaction every interval until it is mined
+// or ctx is done.
+func (c *Client) WaitForReceipt(ctx context.Context, hash string, interval time.Duration) (*Receipt, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		receipts, err := c.GetTransactionReceipts(ctx, []string{hash})
+		if err != nil {
+			return nil, err
+		}
+
+		if receipts[0] != nil {
+			return receipts[0], nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction %s was not mined: %w", hash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
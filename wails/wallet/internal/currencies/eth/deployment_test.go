@@ -0,0 +1,71 @@
+package eth_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestContractArtifact(t *testing.T) {
+	path := filepath.Join("..", "..", "..", "..", "..", "hardhat", "artifacts", "contracts", "Increment.sol")
+	data, err := os.ReadFile(filepath.Join(path, "Increment.json"))
+	if err != nil {
+		t.Fatalf("Failed to read Increment artifact: %v", err)
+	}
+
+	artifact, err := eth.ParseContractArtifact(data)
+	if err != nil {
+		t.Fatalf("Failed to parse artifact: %v", err)
+	}
+
+	assertCorrectValue(t, artifact.ContractName, "Increment")
+	assertCorrectValue(t, artifact.Payable, false)
+	assertCorrectValue(t, artifact.Constructor, []eth.ContractParam{
+		{Name: "initial_value", Type: "uint256"},
+		{Name: "description", Type: "string"},
+	})
+
+	deployment, err := artifact.EncodeDeployment([]string{"7", "demo"})
+	if err != nil {
+		t.Fatalf("Failed to encode deployment: %v", err)
+	}
+
+	// The bytecode, which starts by setting up the free memory pointer, is followed by the
+	// initial value, the offset of the description, then its length and contents.
+	assertCorrectValue(t, deployment[:3], []byte{0x60, 0x80, 0x60})
+	args := deployment[len(deployment)-4*32:]
+	assertCorrectValue(t, args[:32], common.LeftPadBytes([]byte{7}, 32))
+	assertCorrectValue(t, args[96:100], []byte("demo"))
+
+	_, err = artifact.EncodeDeployment([]string{"-1", "demo"})
+	assertError(t, err, "argument initial_value (uint256): negative value -1 for an unsigned integer")
+
+	invalid := []struct {
+		name, artifact, wantErr string
+	}{
+		{
+			name:     "plain ABI",
+			artifact: `[]`,
+			wantErr:  "failed to unmarshal contract artifact",
+		},
+		{
+			name:     "interface",
+			artifact: `{"contractName": "IERC20", "abi": [], "bytecode": "0x"}`,
+			wantErr:  "contract artifact IERC20 does not contain deployable bytecode",
+		},
+		{
+			name:     "unlinked library",
+			artifact: `{"contractName": "Vault", "abi": [], "bytecode": "0x73__$2a8f0b1c3d$__6000"}`,
+			wantErr:  "contract Vault needs linked libraries",
+		},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := eth.ParseContractArtifact([]byte(tc.artifact))
+			assertError(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultENSRegistry is the address of the ENS registry on mainnet and the public test
+// networks. Development chains need the address of a registry deployed on them.
+const DefaultENSRegistry = "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
+
+// ensABIJSON holds the registry and resolver methods used to resolve names and addresses.
+const ensABIJSON = `[
+	{"type": "function", "name": "resolver", "stateMutability": "view",
+		"inputs": [{"name": "node", "type": "bytes32"}], "outputs": [{"name": "", "type": "address"}]},
+	{"type": "function", "name": "addr", "stateMutability": "view",
+		"inputs": [{"name": "node", "type": "bytes32"}], "outputs": [{"name": "", "type": "address"}]},
+	{"type": "function", "name": "name", "stateMutability": "view",
+		"inputs": [{"name": "node", "type": "bytes32"}], "outputs": [{"name": "", "type": "string"}]}
+]`
+
+var ensABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(ensABIJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid ENS ABI: %v", err))
+	}
+
+	return parsed
+}()
+
+// IsENSName tells whether a recipient is written as an ENS name, such as alice.eth,
+// rather than as an address.
+func IsENSName(name string) bool {
+	labels := strings.Split(strings.TrimSpace(name), ".")
+	if len(labels) < 2 {
+		return false
+	}
+
+	for _, label := range labels {
+		if label == "" || strings.ContainsAny(label, " \t") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NormalizeENSName lowercases a name. Only ASCII letters, digits, hyphens and
+// underscores are accepted: names with other characters need a full UTS-46
+// normalization, and can imitate other names with lookalike characters.
+func NormalizeENSName(name string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if !IsENSName(normalized) {
+		return "", fmt.Errorf("invalid ENS name %q", name)
+	}
+
+	for _, r := range normalized {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' && r != '.' {
+			return "", fmt.Errorf("unsupported character %q in ENS name %q", r, name)
+		}
+	}
+
+	return normalized, nil
+}
+
+// Namehash computes the node identifying a normalized name in the registry.
+func Namehash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = crypto.Keccak256Hash(node[:], crypto.Keccak256([]byte(labels[i])))
+	}
+
+	return node
+}
+
+// reverseNode is the node of the reverse record of an address, <address>.addr.reverse.
+func reverseNode(address common.Address) common.Hash {
+	return Namehash(strings.ToLower(address.Hex()[2:]) + ".addr.reverse")
+}
+
+// SetENSRegistry sets the registry names are resolved with, such as one deployed on a
+// development chain.
+func (c *Client) SetENSRegistry(registry common.Address) {
+	c.ensRegistry = registry
+}
+
+// ENSRegistry returns the registry names are resolved with.
+func (c *Client) ENSRegistry() common.Address {
+	if c.ensRegistry == (common.Address{}) {
+		return common.HexToAddress(DefaultENSRegistry)
+	}
+
+	return c.ensRegistry
+}
+
+// ResolveName returns the address an ENS name points to, as set in the addr record of
+// its resolver.
+func (c *Client) ResolveName(ctx context.Context, name string) (common.Address, error) {
+	normalized, err := NormalizeENSName(name)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	records, err := c.ensRecords(ctx, []common.Hash{Namehash(normalized)}, "addr")
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	address, ok := decodeENSAddress(records[0])
+	if !ok {
+		return common.Address{}, fmt.Errorf(
+			"ENS name %s does not resolve to an address with registry %s", normalized, c.ENSRegistry().Hex())
+	}
+
+	return address, nil
+}
+
+// LookupAddresses returns the primary ENS names of addresses, leaving out the ones with
+// none. Anyone can claim any name in the reverse record of their address, so a name is
+// only returned when it resolves back to the address.
+func (c *Client) LookupAddresses(ctx context.Context, addresses []common.Address) (map[common.Address]string, error) {
+	nodes := make([]common.Hash, len(addresses))
+	for i, address := range addresses {
+		nodes[i] = reverseNode(address)
+	}
+
+	records, err := c.ensRecords(ctx, nodes, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	var claimants []common.Address
+	var claimed []string
+	var claimedNodes []common.Hash
+	for i, record := range records {
+		name, ok := decodeENSName(record)
+		if !ok {
+			continue
+		}
+
+		normalized, err := NormalizeENSName(name)
+		if err != nil || normalized != name {
+			continue
+		}
+
+		claimants = append(claimants, addresses[i])
+		claimed = append(claimed, name)
+		claimedNodes = append(claimedNodes, Namehash(name))
+	}
+
+	records, err = c.ensRecords(ctx, claimedNodes, "addr")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[common.Address]string)
+	for i, record := range records {
+		address, ok := decodeENSAddress(record)
+		if ok && address == claimants[i] {
+			names[address] = claimed[i]
+		}
+	}
+
+	return names, nil
+}
+
+// ensRecords reads a record of each node, such as addr or name, with two batches: the
+// first asks the registry for the resolvers of the nodes, the second asks the
+// resolvers for the records. The record of a node that has no resolver, or whose
+// lookup failed, is empty.
+func (c *Client) ensRecords(ctx context.Context, nodes []common.Hash, method string) ([]hexutil.Bytes, error) {
+	registry := c.ENSRegistry()
+	resolvers := make([]hexutil.Bytes, len(nodes))
+	batch := make([]BatchElem, len(nodes))
+	for i, node := range nodes {
+		batch[i] = ensCall(registry, "resolver", node, &resolvers[i])
+	}
+
+	err := c.BatchCall(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up ENS resolvers: %w", err)
+	}
+
+	records := make([]hexutil.Bytes, len(nodes))
+	batch = batch[:0]
+	for i, node := range nodes {
+		resolver, ok := decodeENSAddress(resolvers[i])
+		if ok {
+			batch = append(batch, ensCall(resolver, method, node, &records[i]))
+		}
+	}
+
+	err = c.BatchCall(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up ENS %s records: %w", method, err)
+	}
+
+	return records, nil
+}
+
+func ensCall(to common.Address, method string, node common.Hash, result *hexutil.Bytes) BatchElem {
+	data := append(slices.Clone(ensABI.Methods[method].ID), node[:]...)
+	callObject := map[string]interface{}{
+		"to":   to.Hex(),
+		"data": hexutil.Encode(data),
+	}
+
+	return BatchElem{Method: "eth_call", Params: []interface{}{callObject, "latest"}, Result: result}
+}
+
+// decodeENSAddress decodes an address returned by a registry or a resolver. The zero
+// address means the record is not set.
+func decodeENSAddress(data []byte) (common.Address, bool) {
+	if len(data) != common.HashLength {
+		return common.Address{}, false
+	}
+
+	address := common.BytesToAddress(data)
+	return address, address != (common.Address{})
+}
+
+func decodeENSName(data []byte) (string, bool) {
+	if len(data) == 0 {
+		return "", false
+	}
+
+	values, err := ensABI.Unpack("name", data)
+	if err != nil {
+		return "", false
+	}
+
+	name, ok := values[0].(string)
+	return name, ok && name != ""
+}
+
+// ResolveName returns the checksummed address an ENS name points to.
+func (a *MasterAccount) ResolveName(name string) (string, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	address, err := a.client.ResolveName(cliCtx, name)
+	if err != nil {
+		return "", err
+	}
+
+	return address.Hex(), nil
+}
+
+// LookupAddresses returns the verified ENS names of addresses, keyed by the addresses
+// as given.
+func (a *MasterAccount) LookupAddresses(addresses []string) (map[string]string, error) {
+	parsed := make([]common.Address, len(addresses))
+	for i, address := range addresses {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid %s address: %s", a.tokenName, address)
+		}
+		parsed[i] = common.HexToAddress(address)
+	}
+
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	names, err := a.client.LookupAddresses(cliCtx, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]string, len(names))
+	for i, address := range addresses {
+		name, ok := names[parsed[i]]
+		if ok {
+			found[address] = name
+		}
+	}
+
+	return found, nil
+}
+
+// SetENSRegistry sets the address of the ENS registry, e.g. one deployed on Hardhat.
+func (a *MasterAccount) SetENSRegistry(registry string) error {
+	if !common.IsHexAddress(registry) {
+		return fmt.Errorf("invalid ENS registry address: %s", registry)
+	}
+
+	a.client.SetENSRegistry(common.HexToAddress(registry))
+	return nil
+}
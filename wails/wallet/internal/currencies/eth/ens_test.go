@@ -0,0 +1,163 @@
+package eth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethmock"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	ensRegistry = "0x0000000000000000000000000000000000E45000"
+	ensResolver = "0x0000000000000000000000000000000000E45001"
+)
+
+// newENSNode scripts a mock node with a registry at ensRegistry and a resolver at
+// ensResolver, holding the addr records of forward and the name records of reverse.
+func newENSNode(t testing.TB, forward, reverse map[string]string) *ethmock.Server {
+	t.Helper()
+	records := map[common.Hash][]byte{}
+	resolverOf := map[common.Hash]bool{}
+	for name, address := range forward {
+		node := eth.Namehash(name)
+		resolverOf[node] = true
+		records[node] = common.LeftPadBytes(common.HexToAddress(address).Bytes(), 32)
+	}
+
+	for address, name := range reverse {
+		node := eth.Namehash(strings.ToLower(address[2:]) + ".addr.reverse")
+		resolverOf[node] = true
+		records[node] = encodeABIString(name)
+	}
+
+	resolverWord := common.LeftPadBytes(common.HexToAddress(ensResolver).Bytes(), 32)
+	resolverSelector := crypto.Keccak256([]byte("resolver(bytes32)"))[:4]
+	node := newMockNode(t)
+	node.Handle("eth_call", func(req ethmock.Request) ethmock.Response {
+		var call struct {
+			To   common.Address `json:"to"`
+			Data hexutil.Bytes  `json:"data"`
+		}
+		err := json.Unmarshal(req.Params[0], &call)
+		if err != nil || len(call.Data) != 36 {
+			return ethmock.Response{Error: &ethmock.Error{Code: -32602, Message: "invalid call"}}
+		}
+
+		hash := common.BytesToHash(call.Data[4:])
+		switch call.To {
+		case common.HexToAddress(ensRegistry):
+			if bytes.Equal(call.Data[:4], resolverSelector) && resolverOf[hash] {
+				return ethmock.Response{Result: hexutil.Encode(resolverWord)}
+			}
+			return ethmock.Response{Result: hexutil.Encode(make([]byte, 32))}
+		case common.HexToAddress(ensResolver):
+			return ethmock.Response{Result: hexutil.Encode(records[hash])}
+		default:
+			// No contract is deployed there.
+			return ethmock.Response{Result: "0x"}
+		}
+	})
+
+	return node
+}
+
+func encodeABIString(s string) []byte {
+	padded := (len(s) + 31) / 32 * 32
+	encoded := common.LeftPadBytes(big.NewInt(32).Bytes(), 32)
+	encoded = append(encoded, common.LeftPadBytes(big.NewInt(int64(len(s))).Bytes(), 32)...)
+	return append(encoded, common.RightPadBytes([]byte(s), padded)...)
+}
+
+func TestNamehash(t *testing.T) {
+	// Test vectors of EIP-137.
+	assertCorrectValue(t, eth.Namehash(""), common.Hash{})
+	assertCorrectValue(t, eth.Namehash("eth").Hex(), "0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae")
+	assertCorrectValue(t, eth.Namehash("foo.eth").Hex(),
+		"0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f")
+}
+
+func TestNormalizeENSName(t *testing.T) {
+	cases := []struct {
+		name    string
+		want    string
+		wantErr string
+	}{
+		{name: " Alice.ETH ", want: "alice.eth"},
+		{name: "my-wallet_1.eth", want: "my-wallet_1.eth"},
+		{name: "alice", wantErr: "invalid ENS name"},
+		{name: "alice..eth", wantErr: "invalid ENS name"},
+		{name: hardhatAccount0, wantErr: "invalid ENS name"},
+		// The first letter is a Cyrillic a.
+		{name: "аlice.eth", wantErr: "unsupported character"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := eth.NormalizeENSName(tc.name)
+			if tc.wantErr != "" {
+				assertError(t, err, tc.wantErr)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to normalize %q: %v", tc.name, err)
+			}
+			assertCorrectValue(t, name, tc.want)
+		})
+	}
+}
+
+func TestResolveName(t *testing.T) {
+	node := newENSNode(t, map[string]string{"alice.eth": hardhatAccount1}, nil)
+	client := eth.NewClient(node.URL)
+	ctx := context.Background()
+
+	_, err := client.ResolveName(ctx, "alice.eth")
+	assertError(t, err, "does not resolve to an address with registry "+eth.DefaultENSRegistry)
+
+	client.SetENSRegistry(common.HexToAddress(ensRegistry))
+	address, err := client.ResolveName(ctx, "Alice.eth")
+	if err != nil {
+		t.Fatalf("Failed to resolve name: %v", err)
+	}
+	assertCorrectValue(t, address.Hex(), hardhatAccount1)
+
+	_, err = client.ResolveName(ctx, "bob.eth")
+	assertError(t, err, "ENS name bob.eth does not resolve to an address")
+}
+
+func TestLookupAddresses(t *testing.T) {
+	node := newENSNode(
+		t,
+		map[string]string{"alice.eth": hardhatAccount1},
+		map[string]string{
+			hardhatAccount1: "alice.eth",
+			// Account 0 claims a name that does not point to it.
+			hardhatAccount0: "alice.eth",
+		},
+	)
+	client := eth.NewClient(node.URL)
+	client.SetENSRegistry(common.HexToAddress(ensRegistry))
+
+	addresses := []common.Address{
+		common.HexToAddress(hardhatAccount0),
+		common.HexToAddress(hardhatAccount1),
+		common.HexToAddress(contractAddress),
+	}
+	names, err := client.LookupAddresses(context.Background(), addresses)
+	if err != nil {
+		t.Fatalf("Failed to look up addresses: %v", err)
+	}
+
+	assertCorrectValue(t, names, map[common.Address]string{common.HexToAddress(hardhatAccount1): "alice.eth"})
+	// Resolvers and records are looked up in batches, for the reverse then the forward records.
+	assertCorrectValue(t, node.RoundTrips(), 4)
+}
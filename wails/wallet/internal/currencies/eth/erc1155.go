@@ -0,0 +1,308 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// StandardERC1155 is the standard of multi-tokens, where an account can hold several
+// units of each token ID.
+const StandardERC1155 = "ERC-1155"
+
+// erc1155ABIJSON holds the ERC-1155 methods and events used to list and transfer tokens.
+// name and symbol are not part of the standard, but most collections have them.
+const erc1155ABIJSON = `[
+	{"type": "event", "name": "TransferSingle", "anonymous": false, "inputs": [
+		{"name": "operator", "type": "address", "indexed": true},
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "id", "type": "uint256", "indexed": false},
+		{"name": "value", "type": "uint256", "indexed": false}]},
+	{"type": "event", "name": "TransferBatch", "anonymous": false, "inputs": [
+		{"name": "operator", "type": "address", "indexed": true},
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "ids", "type": "uint256[]", "indexed": false},
+		{"name": "values", "type": "uint256[]", "indexed": false}]},
+	{"type": "function", "name": "balanceOfBatch", "stateMutability": "view", "inputs": [
+		{"name": "accounts", "type": "address[]"},
+		{"name": "ids", "type": "uint256[]"}], "outputs": [{"name": "", "type": "uint256[]"}]},
+	{"type": "function", "name": "uri", "stateMutability": "view",
+		"inputs": [{"name": "id", "type": "uint256"}], "outputs": [{"name": "", "type": "string"}]},
+	{"type": "function", "name": "name", "stateMutability": "view",
+		"inputs": [], "outputs": [{"name": "", "type": "string"}]},
+	{"type": "function", "name": "symbol", "stateMutability": "view",
+		"inputs": [], "outputs": [{"name": "", "type": "string"}]},
+	{"type": "function", "name": "safeTransferFrom", "stateMutability": "nonpayable", "inputs": [
+		{"name": "from", "type": "address"},
+		{"name": "to", "type": "address"},
+		{"name": "id", "type": "uint256"},
+		{"name": "value", "type": "uint256"},
+		{"name": "data", "type": "bytes"}], "outputs": []},
+	{"type": "function", "name": "safeBatchTransferFrom", "stateMutability": "nonpayable", "inputs": [
+		{"name": "from", "type": "address"},
+		{"name": "to", "type": "address"},
+		{"name": "ids", "type": "uint256[]"},
+		{"name": "values", "type": "uint256[]"},
+		{"name": "data", "type": "bytes"}], "outputs": []}
+]`
+
+var erc1155ABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(erc1155ABIJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid ERC-1155 ABI: %v", err))
+	}
+
+	return parsed
+}()
+
+// ERC1155TokenURI substitutes the {id} placeholder of an ERC-1155 URI with the token ID,
+// written as 64 lowercase hexadecimal digits.
+func ERC1155TokenURI(uri string, id *big.Int) string {
+	return strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", id))
+}
+
+type multiTokenKey struct {
+	contract, owner common.Address
+	id              common.Hash
+}
+
+// ScanERC1155 finds the ERC-1155 tokens the owners received since fromBlock, from their
+// TransferSingle and TransferBatch events, and returns those they still hold, see
+// readERC1155.
+func (c *Client) ScanERC1155(ctx context.Context, owners []common.Address, fromBlock *big.Int) ([]Collectible, error) {
+	if len(owners) == 0 {
+		return nil, nil
+	}
+
+	logs, err := NewContractBackend(c).FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		Topics:    erc1155Topics(owners),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan ERC-1155 transfers: %w", err)
+	}
+
+	return c.readERC1155(ctx, erc1155Keys(logs))
+}
+
+// erc1155Topics builds the eth_getLogs topics matching the TransferSingle and
+// TransferBatch events to the owners.
+func erc1155Topics(owners []common.Address) [][]common.Hash {
+	ownerTopics := make([]common.Hash, len(owners))
+	for i, owner := range owners {
+		ownerTopics[i] = common.BytesToHash(owner.Bytes())
+	}
+
+	single, batched := erc1155ABI.Events["TransferSingle"], erc1155ABI.Events["TransferBatch"]
+	return [][]common.Hash{{single.ID, batched.ID}, nil, nil, ownerTopics}
+}
+
+// erc1155Keys returns the contract, recipient and token ID of each token transferred by
+// the ERC-1155 logs.
+func erc1155Keys(logs []types.Log) []multiTokenKey {
+	single, batched := erc1155ABI.Events["TransferSingle"], erc1155ABI.Events["TransferBatch"]
+	var keys []multiTokenKey
+	for _, log := range logs {
+		if len(log.Topics) != 4 || log.Removed {
+			continue
+		}
+
+		var ids []*big.Int
+		switch log.Topics[0] {
+		case single.ID:
+			values, err := single.Inputs.NonIndexed().Unpack(log.Data)
+			if err != nil {
+				continue
+			}
+			ids = []*big.Int{values[0].(*big.Int)}
+		case batched.ID:
+			values, err := batched.Inputs.NonIndexed().Unpack(log.Data)
+			if err != nil {
+				continue
+			}
+			ids = values[0].([]*big.Int)
+		}
+
+		owner := common.BytesToAddress(log.Topics[3].Bytes())
+		for _, id := range ids {
+			keys = append(keys, multiTokenKey{contract: log.Address, owner: owner, id: common.BigToHash(id)})
+		}
+	}
+
+	return keys
+}
+
+// readERC1155 returns the tokens of keys their owner still holds. The balances are read
+// with one balanceOfBatch call per contract, in a single batch with the URIs, names and
+// symbols.
+func (c *Client) readERC1155(ctx context.Context, keys []multiTokenKey) ([]Collectible, error) {
+	// Tokens are grouped per contract, so that each contract answers a single
+	// balanceOfBatch call.
+	var contracts []common.Address
+	tokens := make(map[common.Address][]multiTokenKey)
+	seen := make(map[multiTokenKey]bool)
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		if len(tokens[key.contract]) == 0 {
+			contracts = append(contracts, key.contract)
+		}
+		tokens[key.contract] = append(tokens[key.contract], key)
+	}
+
+	if len(contracts) == 0 {
+		return nil, nil
+	}
+
+	// Each contract gets its balanceOfBatch, name and symbol calls, then the URI of each
+	// of its token IDs.
+	var batch []BatchElem
+	var results []*hexutil.Bytes
+	call := func(contract common.Address, method string, args ...interface{}) {
+		result := new(hexutil.Bytes)
+		results = append(results, result)
+		batch = append(batch, tokenCall(&erc1155ABI, contract, result, method, args...))
+	}
+
+	starts := make(map[common.Address]int, len(contracts))
+	uris := make(map[multiTokenKey]int)
+	for _, contract := range contracts {
+		accounts := make([]common.Address, len(tokens[contract]))
+		ids := make([]*big.Int, len(tokens[contract]))
+		for i, key := range tokens[contract] {
+			accounts[i], ids[i] = key.owner, key.id.Big()
+		}
+
+		starts[contract] = len(batch)
+		call(contract, "balanceOfBatch", accounts, ids)
+		call(contract, "name")
+		call(contract, "symbol")
+		for _, key := range tokens[contract] {
+			uriKey := multiTokenKey{contract: contract, id: key.id}
+			if _, ok := uris[uriKey]; !ok {
+				uris[uriKey] = len(batch)
+				call(contract, "uri", key.id.Big())
+			}
+		}
+	}
+
+	err := c.BatchCall(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ERC-1155 balances: %w", err)
+	}
+
+	var collectibles []Collectible
+	for _, contract := range contracts {
+		start := starts[contract]
+		if batch[start].Error != nil {
+			continue
+		}
+
+		balances, ok := unpackTokenValue(&erc1155ABI, "balanceOfBatch", *results[start]).([]*big.Int)
+		if !ok || len(balances) != len(tokens[contract]) {
+			continue
+		}
+
+		// The metadata methods are optional, so they may fail.
+		name, _ := unpackTokenValue(&erc1155ABI, "name", *results[start+1]).(string)
+		symbol, _ := unpackTokenValue(&erc1155ABI, "symbol", *results[start+2]).(string)
+		for i, key := range tokens[contract] {
+			if balances[i].Sign() == 0 {
+				continue
+			}
+
+			id := key.id.Big()
+			result := results[uris[multiTokenKey{contract: contract, id: key.id}]]
+			uri, _ := unpackTokenValue(&erc1155ABI, "uri", *result).(string)
+			if uri != "" {
+				uri = ERC1155TokenURI(uri, id)
+			}
+
+			collectibles = append(collectibles, Collectible{
+				Standard: StandardERC1155,
+				Contract: contract.Hex(),
+				Name:     name,
+				Symbol:   symbol,
+				TokenID:  id.String(),
+				Owner:    key.owner.Hex(),
+				Balance:  balances[i].String(),
+				URI:      uri,
+			})
+		}
+	}
+
+	sortCollectibles(collectibles)
+	return collectibles, nil
+}
+
+// MultiTokenTransferRequest builds the transaction sending ERC-1155 tokens from an
+// account to a recipient: safeTransferFrom for a single token ID, safeBatchTransferFrom
+// for several. ids and amounts are decimal integers, paired by position.
+func (a *MasterAccount) MultiTokenTransferRequest(
+	contract, to string,
+	ids, amounts []string,
+	accountIndex int,
+) (*TransactionRequest, error) {
+	if !common.IsHexAddress(to) {
+		return nil, fmt.Errorf("invalid recipient address: %s", to)
+	}
+
+	if len(ids) == 0 || len(ids) != len(amounts) {
+		return nil, fmt.Errorf("expected an amount for each of the %d token IDs, got %d", len(ids), len(amounts))
+	}
+
+	tokenIDs := make([]*big.Int, len(ids))
+	values := make([]*big.Int, len(amounts))
+	for i := range ids {
+		id, ok := new(big.Int).SetString(strings.TrimSpace(ids[i]), 10)
+		if !ok || id.Sign() < 0 {
+			return nil, fmt.Errorf("invalid token ID %q", ids[i])
+		}
+
+		value, ok := new(big.Int).SetString(strings.TrimSpace(amounts[i]), 10)
+		if !ok || value.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid amount %q of token %s", amounts[i], ids[i])
+		}
+
+		tokenIDs[i], values[i] = id, value
+	}
+
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	address, err := a.contractAddress(cliCtx, strings.TrimSpace(contract))
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := a.accountDB.GetAccountAddress(cliCtx, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account address from DB: %w", err)
+	}
+
+	from := common.HexToAddress(owner)
+	var data []byte
+	if len(tokenIDs) == 1 {
+		data, err = erc1155ABI.Pack("safeTransferFrom", from, common.HexToAddress(to), tokenIDs[0], values[0], []byte{})
+	} else {
+		data, err = erc1155ABI.Pack("safeBatchTransferFrom", from, common.HexToAddress(to), tokenIDs, values, []byte{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error encoding token transfer: %w", err)
+	}
+
+	return &TransactionRequest{From: from, To: &address, Value: (*hexutil.Big)(new(big.Int)), Data: data}, nil
+}
@@ -0,0 +1,139 @@
+package eth_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethmock"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const multiTokenABIJSON = `[
+	{"type": "event", "name": "TransferSingle", "inputs": [
+		{"name": "operator", "type": "address", "indexed": true},
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "id", "type": "uint256"},
+		{"name": "value", "type": "uint256"}]},
+	{"type": "event", "name": "TransferBatch", "inputs": [
+		{"name": "operator", "type": "address", "indexed": true},
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "ids", "type": "uint256[]"},
+		{"name": "values", "type": "uint256[]"}]},
+	{"type": "function", "name": "balanceOfBatch", "inputs": [
+		{"name": "accounts", "type": "address[]"},
+		{"name": "ids", "type": "uint256[]"}], "outputs": [{"name": "", "type": "uint256[]"}]},
+	{"type": "function", "name": "uri",
+		"inputs": [{"name": "id", "type": "uint256"}], "outputs": [{"name": "", "type": "string"}]}
+]`
+
+func TestERC1155TokenURI(t *testing.T) {
+	// The example of EIP-1155.
+	assertCorrectValue(t,
+		eth.ERC1155TokenURI("https://token-cdn-domain/{id}.json", big.NewInt(314592)),
+		"https://token-cdn-domain/000000000000000000000000000000000000000000000000000000000004cce0.json",
+	)
+	assertCorrectValue(t, eth.ERC1155TokenURI("ipfs://QmGame/1.json", big.NewInt(1)), "ipfs://QmGame/1.json")
+}
+
+func TestScanERC1155(t *testing.T) {
+	multiTokenABI, err := abi.JSON(strings.NewReader(multiTokenABIJSON))
+	if err != nil {
+		t.Fatalf("Failed to parse ABI: %v", err)
+	}
+
+	topic := func(address string) common.Hash {
+		return common.BytesToHash(common.HexToAddress(address).Bytes())
+	}
+	transferLog := func(event, to string, args ...interface{}) map[string]interface{} {
+		data, err := multiTokenABI.Events[event].Inputs.NonIndexed().Pack(args...)
+		if err != nil {
+			t.Fatalf("Failed to pack %s: %v", event, err)
+		}
+
+		return map[string]interface{}{
+			"address":         collectionAddress,
+			"topics":          []common.Hash{multiTokenABI.Events[event].ID, topic(hardhatAccount0), {}, topic(to)},
+			"data":            hexutil.Encode(data),
+			"transactionHash": common.Hash{}.Hex(),
+		}
+	}
+
+	node := newMockNode(t)
+	node.OnResult("eth_getLogs", []interface{}{
+		transferLog("TransferSingle", hardhatAccount0, big.NewInt(1), big.NewInt(5)),
+		transferLog("TransferBatch", hardhatAccount0,
+			[]*big.Int{big.NewInt(2), big.NewInt(3)}, []*big.Int{big.NewInt(1), big.NewInt(2)}),
+		transferLog("TransferSingle", hardhatAccount1, big.NewInt(1), big.NewInt(1)),
+		transferLog("TransferSingle", hardhatAccount0, big.NewInt(3), big.NewInt(1)),
+	})
+
+	balances := map[common.Address]map[int64]int64{
+		// Account 0 gave its token 2 away.
+		common.HexToAddress(hardhatAccount0): {1: 5, 2: 0, 3: 3},
+		common.HexToAddress(hardhatAccount1): {1: 1},
+	}
+	node.Handle("eth_call", func(req ethmock.Request) ethmock.Response {
+		var call struct {
+			Data hexutil.Bytes `json:"data"`
+		}
+		err := json.Unmarshal(req.Params[0], &call)
+		if err != nil || len(call.Data) < 4 {
+			return ethmock.Response{Error: &ethmock.Error{Code: -32602, Message: "invalid call"}}
+		}
+
+		method, err := multiTokenABI.MethodById(call.Data)
+		if err != nil {
+			// The collection has no name or symbol.
+			return ethmock.Response{Error: &ethmock.Error{Code: 3, Message: "execution reverted"}}
+		}
+
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		if err != nil {
+			return ethmock.Response{Error: &ethmock.Error{Code: -32602, Message: "invalid call"}}
+		}
+
+		var result []byte
+		switch method.Name {
+		case "balanceOfBatch":
+			accounts, ids := args[0].([]common.Address), args[1].([]*big.Int)
+			amounts := make([]*big.Int, len(ids))
+			for i := range ids {
+				amounts[i] = big.NewInt(balances[accounts[i]][ids[i].Int64()])
+			}
+			result, _ = method.Outputs.Pack(amounts)
+		case "uri":
+			result, _ = method.Outputs.Pack("https://game.example/{id}.json")
+		}
+
+		return ethmock.Response{Result: hexutil.Encode(result)}
+	})
+
+	client := eth.NewClient(node.URL)
+	owners := []common.Address{common.HexToAddress(hardhatAccount0), common.HexToAddress(hardhatAccount1)}
+	collectibles, err := client.ScanERC1155(context.Background(), owners, big.NewInt(0))
+	if err != nil {
+		t.Fatalf("Failed to scan collectibles: %v", err)
+	}
+
+	held := make([]string, len(collectibles))
+	for i, collectible := range collectibles {
+		assertCorrectValue(t, collectible.Standard, eth.StandardERC1155)
+		assertCorrectValue(t, collectible.Contract, collectionAddress)
+		held[i] = collectible.Owner[:6] + " #" + collectible.TokenID + " x" + collectible.Balance
+	}
+	assertCorrectValue(t, held, []string{"0xf39F #1 x5", "0x7099 #1 x1", "0xf39F #3 x3"})
+	assertCorrectValue(t, collectibles[2].URI,
+		"https://game.example/0000000000000000000000000000000000000000000000000000000000000003.json")
+
+	// The logs, then one balanceOfBatch call, the name, symbol and every URI in a single batch.
+	assertCorrectValue(t, node.RoundTrips(), 2)
+	assertCorrectValue(t, len(node.Requests("eth_call")), 6)
+}
@@ -0,0 +1,340 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// StandardERC721 is the standard of non-fungible tokens, each held by a single owner.
+const StandardERC721 = "ERC-721"
+
+// erc721ABIJSON holds the ERC-721 methods and events used to list and transfer tokens,
+// with the name, symbol and tokenURI of the metadata extension.
+const erc721ABIJSON = `[
+	{"type": "event", "name": "Transfer", "anonymous": false, "inputs": [
+		{"name": "from", "type": "address", "indexed": true},
+		{"name": "to", "type": "address", "indexed": true},
+		{"name": "tokenId", "type": "uint256", "indexed": true}]},
+	{"type": "function", "name": "ownerOf", "stateMutability": "view",
+		"inputs": [{"name": "tokenId", "type": "uint256"}], "outputs": [{"name": "", "type": "address"}]},
+	{"type": "function", "name": "tokenURI", "stateMutability": "view",
+		"inputs": [{"name": "tokenId", "type": "uint256"}], "outputs": [{"name": "", "type": "string"}]},
+	{"type": "function", "name": "name", "stateMutability": "view",
+		"inputs": [], "outputs": [{"name": "", "type": "string"}]},
+	{"type": "function", "name": "symbol", "stateMutability": "view",
+		"inputs": [], "outputs": [{"name": "", "type": "string"}]},
+	{"type": "function", "name": "safeTransferFrom", "stateMutability": "nonpayable", "inputs": [
+		{"name": "from", "type": "address"},
+		{"name": "to", "type": "address"},
+		{"name": "tokenId", "type": "uint256"}], "outputs": []}
+]`
+
+var erc721ABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(erc721ABIJSON))
+	if err != nil {
+		panic(fmt.Sprintf("invalid ERC-721 ABI: %v", err))
+	}
+
+	return parsed
+}()
+
+// Collectible is an ERC-721 or ERC-1155 token held by an account.
+type Collectible struct {
+	Standard string `json:"standard"`
+	Contract string `json:"contract"`
+	// Name and Symbol are those of the collection.
+	Name       string `json:"name"`
+	Symbol     string `json:"symbol"`
+	TokenID    string `json:"tokenId"`
+	Owner      string `json:"owner"`
+	OwnerIndex int    `json:"ownerIndex"`
+	// Balance is the number of units held, always 1 for ERC-721 tokens.
+	Balance string `json:"balance"`
+	// URI points to the metadata of the token, which is left out when it cannot be read.
+	URI      string         `json:"uri,omitempty"`
+	Metadata *TokenMetadata `json:"metadata,omitempty"`
+}
+
+type collectibleKey struct {
+	contract common.Address
+	tokenID  common.Hash
+}
+
+// ScanERC721 finds the ERC-721 tokens the owners received since fromBlock, from their
+// Transfer events, and returns those they still own, see readERC721.
+func (c *Client) ScanERC721(ctx context.Context, owners []common.Address, fromBlock *big.Int) ([]Collectible, error) {
+	if len(owners) == 0 {
+		return nil, nil
+	}
+
+	logs, err := NewContractBackend(c).FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		Topics:    erc721Topics(owners),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan ERC-721 transfers: %w", err)
+	}
+
+	return c.readERC721(ctx, erc721Keys(logs), owners)
+}
+
+// erc721Topics builds the eth_getLogs topics matching the Transfer events to the owners.
+func erc721Topics(owners []common.Address) [][]common.Hash {
+	ownerTopics := make([]common.Hash, len(owners))
+	for i, owner := range owners {
+		ownerTopics[i] = common.BytesToHash(owner.Bytes())
+	}
+
+	return [][]common.Hash{{erc721ABI.Events["Transfer"].ID}, nil, ownerTopics}
+}
+
+// erc721Keys returns the contract, recipient and token ID of each ERC-721 Transfer log.
+// ERC-20 transfers share the event signature, but do not index the amount.
+func erc721Keys(logs []types.Log) []multiTokenKey {
+	var keys []multiTokenKey
+	for _, log := range logs {
+		if len(log.Topics) != 4 || log.Removed {
+			continue
+		}
+
+		keys = append(keys, multiTokenKey{
+			contract: log.Address,
+			owner:    common.BytesToAddress(log.Topics[2].Bytes()),
+			id:       log.Topics[3],
+		})
+	}
+
+	return keys
+}
+
+// readERC721 returns the tokens of keys still owned by one of the owners. The owner, URI,
+// and collection name and symbol are read in a single batch.
+func (c *Client) readERC721(
+	ctx context.Context,
+	received []multiTokenKey,
+	owners []common.Address,
+) ([]Collectible, error) {
+	var keys []collectibleKey
+	seen := make(map[collectibleKey]bool)
+	contracts := make(map[common.Address]bool)
+	for _, token := range received {
+		key := collectibleKey{contract: token.contract, tokenID: token.id}
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+		contracts[key.contract] = true
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	// The owners and URIs of the tokens come first in the batch, then the name and symbol
+	// of each collection.
+	size := 2*len(keys) + 2*len(contracts)
+	batch := make([]BatchElem, 0, size)
+	results := make([]hexutil.Bytes, size)
+	for _, key := range keys {
+		tokenID := key.tokenID.Big()
+		batch = append(batch,
+			tokenCall(&erc721ABI, key.contract, &results[len(batch)], "ownerOf", tokenID),
+			tokenCall(&erc721ABI, key.contract, &results[len(batch)+1], "tokenURI", tokenID),
+		)
+	}
+
+	collections := make(map[common.Address]int, len(contracts))
+	for contract := range contracts {
+		collections[contract] = len(batch)
+		batch = append(batch,
+			tokenCall(&erc721ABI, contract, &results[len(batch)], "name"),
+			tokenCall(&erc721ABI, contract, &results[len(batch)+1], "symbol"),
+		)
+	}
+
+	err := c.BatchCall(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ERC-721 owners: %w", err)
+	}
+
+	isOwner := ownerSet(owners)
+	var collectibles []Collectible
+	for i, key := range keys {
+		if batch[2*i].Error != nil {
+			continue
+		}
+
+		owner, ok := unpackTokenValue(&erc721ABI, "ownerOf", results[2*i]).(common.Address)
+		if !ok || !isOwner[owner] {
+			continue
+		}
+
+		// The metadata extension is optional, so its methods may fail.
+		m := collections[key.contract]
+		uri, _ := unpackTokenValue(&erc721ABI, "tokenURI", results[2*i+1]).(string)
+		name, _ := unpackTokenValue(&erc721ABI, "name", results[m]).(string)
+		symbol, _ := unpackTokenValue(&erc721ABI, "symbol", results[m+1]).(string)
+		collectibles = append(collectibles, Collectible{
+			Standard: StandardERC721,
+			Contract: key.contract.Hex(),
+			Name:     name,
+			Symbol:   symbol,
+			TokenID:  key.tokenID.Big().String(),
+			Owner:    owner.Hex(),
+			Balance:  "1",
+			URI:      uri,
+		})
+	}
+
+	sortCollectibles(collectibles)
+	return collectibles, nil
+}
+
+// sortCollectibles orders collectibles by contract, then by token ID.
+func sortCollectibles(collectibles []Collectible) {
+	sort.SliceStable(collectibles, func(i, j int) bool {
+		if collectibles[i].Contract != collectibles[j].Contract {
+			return collectibles[i].Contract < collectibles[j].Contract
+		}
+
+		a, _ := new(big.Int).SetString(collectibles[i].TokenID, 10)
+		b, _ := new(big.Int).SetString(collectibles[j].TokenID, 10)
+		return a.Cmp(b) < 0
+	})
+}
+
+// GetCollectibles lists the ERC-721 and ERC-1155 tokens held by the accounts of the
+// wallet, with their metadata. The transfers to the accounts are read eventSyncRange
+// blocks at a time, from the block the accounts were last scanned up to, and the tokens
+// they received are stored so the next scan only reads the new blocks. Metadata is
+// fetched once per token URI.
+func (a *MasterAccount) GetCollectibles() ([]Collectible, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, eventSyncTimeout)
+	defer cancel()
+
+	owners, indexes, err := a.accountAddresses(cliCtx)
+	if err != nil || len(owners) == 0 {
+		return nil, err
+	}
+
+	head, err := a.client.BlockNumber(cliCtx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving block number: %w", err)
+	}
+
+	received, err := a.syncCollectibles(cliCtx, StandardERC721, owners, erc721Topics(owners), erc721Keys, head)
+	if err != nil {
+		return nil, err
+	}
+
+	collectibles, err := a.client.readERC721(cliCtx, received, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	received, err = a.syncCollectibles(cliCtx, StandardERC1155, owners, erc1155Topics(owners), erc1155Keys, head)
+	if err != nil {
+		return nil, err
+	}
+
+	multiTokens, err := a.client.readERC1155(cliCtx, received)
+	if err != nil {
+		return nil, err
+	}
+	collectibles = append(collectibles, multiTokens...)
+	sortCollectibles(collectibles)
+
+	for i := range collectibles {
+		collectibles[i].OwnerIndex = indexes[common.HexToAddress(collectibles[i].Owner)]
+	}
+
+	fetchCollectiblesMetadata(a.ctx, collectibles, &a.tokenMetadata)
+	a.collectibles.Store(&collectibles)
+	return slices.Clone(collectibles), nil
+}
+
+// LastCollectibles returns the collectibles found by the last GetCollectibles scan
+// without reading the chain, or nil before the first scan.
+func (a *MasterAccount) LastCollectibles() []Collectible {
+	collectibles := a.collectibles.Load()
+	if collectibles == nil {
+		return nil
+	}
+
+	return slices.Clone(*collectibles)
+}
+
+// syncCollectibles stores the tokens of a standard found in the logs matching topics, from
+// the block the owners were last scanned up to head, and returns all the stored tokens of
+// the owners.
+func (a *MasterAccount) syncCollectibles(
+	ctx context.Context,
+	standard string,
+	owners []common.Address,
+	topics [][]common.Hash,
+	keys func(logs []types.Log) []multiTokenKey,
+	head uint64,
+) ([]multiTokenKey, error) {
+	from, err := a.eventDB.scanStart(ctx, standard, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.filterContractLogs(ctx, nil, topics, from, head, func(logs []types.Log, lastBlock uint64) error {
+		return a.eventDB.saveCollectibles(ctx, standard, owners, keys(logs), lastBlock)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning %s transfers: %w", standard, err)
+	}
+
+	return a.eventDB.collectibles(ctx, standard, owners)
+}
+
+// CollectibleTransferRequest builds the safeTransferFrom transaction sending an ERC-721
+// token from an account to a recipient. Transfers to contracts that cannot handle the
+// token revert.
+func (a *MasterAccount) CollectibleTransferRequest(
+	contract, tokenID, to string,
+	accountIndex int,
+) (*TransactionRequest, error) {
+	if !common.IsHexAddress(to) {
+		return nil, fmt.Errorf("invalid recipient address: %s", to)
+	}
+
+	id, ok := new(big.Int).SetString(strings.TrimSpace(tokenID), 10)
+	if !ok || id.Sign() < 0 {
+		return nil, fmt.Errorf("invalid token ID %q", tokenID)
+	}
+
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	address, err := a.contractAddress(cliCtx, strings.TrimSpace(contract))
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := a.accountDB.GetAccountAddress(cliCtx, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account address from DB: %w", err)
+	}
+
+	from := common.HexToAddress(owner)
+	data, err := erc721ABI.Pack("safeTransferFrom", from, common.HexToAddress(to), id)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding token transfer: %w", err)
+	}
+
+	return &TransactionRequest{From: from, To: &address, Value: (*hexutil.Big)(new(big.Int)), Data: data}, nil
+}
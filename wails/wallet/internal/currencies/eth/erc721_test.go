@@ -0,0 +1,115 @@
+package eth_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethmock"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const collectionAddress = "0x0000000000000000000000000000000000c30000"
+
+func selector(signature string) string {
+	return hexutil.Encode(crypto.Keccak256([]byte(signature))[:4])
+}
+
+func TestScanERC721(t *testing.T) {
+	transfer := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	topic := func(address string) common.Hash {
+		return common.BytesToHash(common.HexToAddress(address).Bytes())
+	}
+	transferLog := func(contract string, topics ...common.Hash) map[string]interface{} {
+		return map[string]interface{}{
+			"address":         contract,
+			"topics":          append([]common.Hash{transfer}, topics...),
+			"data":            "0x",
+			"transactionHash": common.Hash{}.Hex(),
+		}
+	}
+
+	mint := common.Hash{}
+	node := newMockNode(t)
+	node.OnResult("eth_getLogs", []interface{}{
+		transferLog(collectionAddress, mint, topic(hardhatAccount0), common.BigToHash(big.NewInt(2))),
+		transferLog(collectionAddress, mint, topic(hardhatAccount0), common.BigToHash(big.NewInt(1))),
+		transferLog(collectionAddress, mint, topic(hardhatAccount0), common.BigToHash(big.NewInt(3))),
+		// An ERC-20 transfer, whose amount is not indexed.
+		transferLog(contractAddress, mint, topic(hardhatAccount0)),
+	})
+
+	metadata := `{"name": "Rocket #1", "image": "ipfs://QmRocket/1.png"}`
+	uris := map[int64]string{
+		1: "data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(metadata)),
+		2: "ipfs://QmRocket/2.json",
+	}
+	node.Handle("eth_call", func(req ethmock.Request) ethmock.Response {
+		var call struct {
+			Data hexutil.Bytes `json:"data"`
+		}
+		err := json.Unmarshal(req.Params[0], &call)
+		if err != nil || len(call.Data) < 4 {
+			return ethmock.Response{Error: &ethmock.Error{Code: -32602, Message: "invalid call"}}
+		}
+
+		var result []byte
+		switch hexutil.Encode(call.Data[:4]) {
+		case selector("ownerOf(uint256)"):
+			// Token 3 was sold to account 1.
+			owner := hardhatAccount0
+			if new(big.Int).SetBytes(call.Data[4:]).Int64() == 3 {
+				owner = hardhatAccount1
+			}
+			result = common.LeftPadBytes(common.HexToAddress(owner).Bytes(), 32)
+		case selector("tokenURI(uint256)"):
+			result = encodeABIString(uris[new(big.Int).SetBytes(call.Data[4:]).Int64()])
+		case selector("name()"):
+			result = encodeABIString("Rockets")
+		case selector("symbol()"):
+			result = encodeABIString("RKT")
+		}
+
+		return ethmock.Response{Result: hexutil.Encode(result)}
+	})
+
+	client := eth.NewClient(node.URL)
+	owners := []common.Address{common.HexToAddress(hardhatAccount0)}
+	collectibles, err := client.ScanERC721(context.Background(), owners, big.NewInt(0))
+	if err != nil {
+		t.Fatalf("Failed to scan collectibles: %v", err)
+	}
+
+	want := []eth.Collectible{
+		{
+			Standard: eth.StandardERC721,
+			Contract: collectionAddress,
+			Name:     "Rockets",
+			Symbol:   "RKT",
+			TokenID:  "1",
+			Owner:    hardhatAccount0,
+			Balance:  "1",
+			URI:      uris[1],
+		},
+		{
+			Standard: eth.StandardERC721,
+			Contract: collectionAddress,
+			Name:     "Rockets",
+			Symbol:   "RKT",
+			TokenID:  "2",
+			Owner:    hardhatAccount0,
+			Balance:  "1",
+			URI:      uris[2],
+		},
+	}
+	assertCorrectValue(t, collectibles, want)
+
+	// The logs, then every owner and URI, and the collection name and symbol in a single batch.
+	assertCorrectValue(t, node.RoundTrips(), 2)
+	assertCorrectValue(t, len(node.Requests("eth_call")), 8)
+}
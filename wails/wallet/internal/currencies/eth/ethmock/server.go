@@ -0,0 +1,198 @@
+// Package ethmock runs a scriptable JSON-RPC node over HTTP, so tests can check how
+// eth.Client copes with a node that is slow, broken or lying.
+package ethmock
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// Response is what the node answers to a request. Result and Error build a regular
+// JSON-RPC response; Status and Body replace the HTTP response altogether.
+type Response struct {
+	Result interface{}
+	Error  *Error
+	// Status is the HTTP status code of the response, 200 when unset.
+	Status int
+	// Body is written as is instead of a JSON-RPC response, such as malformed JSON.
+	Body string
+	// Delay is waited before answering.
+	Delay time.Duration
+}
+
+// Error is a JSON-RPC error object.
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Request is a request received by the node.
+type Request struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// Handler answers the requests for a method.
+type Handler func(req Request) Response
+
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	handlers   map[string]Handler
+	requests   []Request
+	roundTrips int
+}
+
+// NewServer starts a node answering every method with a method not found error
+// until it is scripted with On or Handle. Batched requests are answered one by one.
+func NewServer() *Server {
+	s := &Server{handlers: map[string]Handler{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// On answers the requests for method with responses in turn, repeating the last one.
+func (s *Server) On(method string, responses ...Response) {
+	var mu sync.Mutex
+	calls := 0
+	s.Handle(method, func(Request) Response {
+		mu.Lock()
+		defer mu.Unlock()
+		response := responses[min(calls, len(responses)-1)]
+		calls++
+		return response
+	})
+}
+
+// OnResult answers the requests for method with result.
+func (s *Server) OnResult(method string, result interface{}) {
+	s.On(method, Response{Result: result})
+}
+
+// OnError answers the requests for method with a JSON-RPC error object.
+func (s *Server) OnError(method string, code int, message string) {
+	s.On(method, Response{Error: &Error{Code: code, Message: message}})
+}
+
+func (s *Server) Handle(method string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// Requests returns the requests received so far for method, or all of them when
+// method is empty.
+func (s *Server) Requests(method string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var requests []Request
+	for _, req := range s.requests {
+		if method == "" || req.Method == method {
+			requests = append(requests, req)
+		}
+	}
+
+	return requests
+}
+
+// RoundTrips returns the number of HTTP requests received so far; a batch counts once.
+func (s *Server) RoundTrips() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.roundTrips
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	var batch []Request
+	isBatch := json.Unmarshal(body, &batch) == nil
+	if !isBatch {
+		var req Request
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			http.Error(w, "invalid JSON-RPC request", http.StatusBadRequest)
+			return
+		}
+		batch = []Request{req}
+	}
+
+	s.mu.Lock()
+	s.roundTrips++
+	s.mu.Unlock()
+
+	// The HTTP part of the responses, delay, status and raw body, applies to the
+	// whole reply when requests are batched.
+	var reply Response
+	messages := make([]map[string]interface{}, len(batch))
+	for i, req := range batch {
+		response := s.respond(req)
+		reply.Delay = max(reply.Delay, response.Delay)
+		if reply.Status == 0 {
+			reply.Status = response.Status
+		}
+
+		if reply.Body == "" {
+			reply.Body = response.Body
+		}
+
+		messages[i] = map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if response.Error != nil {
+			messages[i]["error"] = response.Error
+		} else {
+			messages[i]["result"] = response.Result
+		}
+	}
+
+	if reply.Delay > 0 {
+		select {
+		case <-time.After(reply.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	status := reply.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	if reply.Body != "" {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(reply.Body))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if isBatch {
+		_ = json.NewEncoder(w).Encode(messages)
+	} else {
+		_ = json.NewEncoder(w).Encode(messages[0])
+	}
+}
+
+func (s *Server) respond(req Request) Response {
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	handler, ok := s.handlers[req.Method]
+	s.mu.Unlock()
+
+	if !ok {
+		return Response{Error: &Error{Code: -32601, Message: "the method " + req.Method + " does not exist"}}
+	}
+
+	return handler(req)
+}
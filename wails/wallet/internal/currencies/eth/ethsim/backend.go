@@ -0,0 +1,83 @@
+// Package ethsim runs an in-process Ethereum chain that eth.Client can talk to, so
+// wallet code can be tested end to end without a node listening on localhost.
+package ethsim
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ChainID is the chain ID of the simulated chain.
+const ChainID = 1337
+
+// Backend serves the requests of eth.Client, subscriptions included, with the
+// simulated node.
+type Backend struct {
+	*eth.RPCBackend
+
+	sim    *simulated.Backend
+	ipcDir string
+}
+
+// NewBackend starts a chain where every address in balances holds the given amount
+// of ether from the genesis block.
+func NewBackend(balances map[string]int64) (*Backend, error) {
+	alloc := types.GenesisAlloc{}
+	for address, ether := range balances {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid genesis address %q", address)
+		}
+
+		balance := new(big.Int).Mul(big.NewInt(ether), big.NewInt(params.Ether))
+		alloc[common.HexToAddress(address)] = types.Account{Balance: balance}
+	}
+
+	// The RPC client of the simulated backend is not exposed, so the node also serves
+	// IPC on a socket in a temporary directory and the backend dials it.
+	ipcDir, err := os.MkdirTemp("", "ethsim")
+	if err != nil {
+		return nil, fmt.Errorf("error creating IPC directory: %w", err)
+	}
+
+	ipcPath := filepath.Join(ipcDir, "node.ipc")
+	sim := simulated.NewBackend(alloc, func(nodeConf *node.Config, _ *ethconfig.Config) {
+		nodeConf.IPCPath = ipcPath
+	})
+	rpcClient, err := rpc.Dial(ipcPath)
+	if err != nil {
+		sim.Close()
+		os.RemoveAll(ipcDir)
+		return nil, fmt.Errorf("error connecting to simulated chain: %w", err)
+	}
+
+	return &Backend{RPCBackend: eth.NewRPCBackend(rpcClient), sim: sim, ipcDir: ipcDir}, nil
+}
+
+// Commit mines the pending transactions into a new block.
+func (b *Backend) Commit() common.Hash {
+	return b.sim.Commit()
+}
+
+// Fork sets the head back to parentHash, so that the next commits build a competing
+// chain that replaces the blocks after it once it is longer.
+func (b *Backend) Fork(parentHash common.Hash) error {
+	return b.sim.Fork(parentHash)
+}
+
+func (b *Backend) Close() error {
+	b.RPCBackend.Close()
+	defer os.RemoveAll(b.ipcDir)
+
+	return b.sim.Close()
+}
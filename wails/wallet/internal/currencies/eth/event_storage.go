@@ -0,0 +1,475 @@
+package eth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EventStorage keeps the decoded events of registered contracts, along with the last
+// block they were synced up to.
+type EventStorage struct {
+	db *sql.DB
+}
+
+func NewEventStorage(ctx context.Context, db *sql.DB) (*EventStorage, error) {
+	err := db.Ping()
+	if err != nil {
+		return nil, fmt.Errorf("error pinging database: %w", err)
+	}
+
+	_, err = db.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS ethContractEvents (
+			contract TEXT,
+			blockNumber INTEGER,
+			logIndex INTEGER,
+			txHash TEXT,
+			event TEXT,
+			signature TEXT,
+			fields TEXT,
+			PRIMARY KEY (contract, blockNumber, logIndex)
+		)`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating contract events table: %w", err)
+	}
+
+	_, err = db.ExecContext(
+		ctx,
+		"CREATE TABLE IF NOT EXISTS ethEventSync (contract TEXT PRIMARY KEY, lastBlock INTEGER, lastHash TEXT DEFAULT '')",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating event sync table: %w", err)
+	}
+
+	err = addColumnIfMissing(ctx, db, "ethEventSync", "lastHash", "TEXT DEFAULT ''")
+	if err != nil {
+		return nil, fmt.Errorf("error migrating event sync table: %w", err)
+	}
+
+	_, err = db.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS ethApprovals (
+			token TEXT,
+			owner TEXT,
+			spender TEXT,
+			PRIMARY KEY (token, owner, spender)
+		)`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating approvals table: %w", err)
+	}
+
+	_, err = db.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS ethCollectibles (
+			standard TEXT,
+			contract TEXT,
+			owner TEXT,
+			tokenId TEXT,
+			PRIMARY KEY (standard, contract, owner, tokenId)
+		)`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating collectibles table: %w", err)
+	}
+
+	_, err = db.ExecContext(
+		ctx,
+		"CREATE TABLE IF NOT EXISTS ethLogScans (scan TEXT, owner TEXT, lastBlock INTEGER, PRIMARY KEY (scan, owner))",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating log scan table: %w", err)
+	}
+
+	return &EventStorage{db: db}, nil
+}
+
+// SaveEvents stores the events of a contract found up to lastBlock, and moves its sync
+// position to lastBlock, whose hash is lastHash when known. Events already stored are
+// kept as they are.
+func (e *EventStorage) SaveEvents(
+	ctx context.Context,
+	contract string,
+	events []EventLog,
+	lastBlock uint64,
+	lastHash common.Hash,
+) error {
+	address := common.HexToAddress(contract).Hex()
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, event := range events {
+		fields, err := json.Marshal(event.Fields)
+		if err != nil {
+			return fmt.Errorf("error encoding %s event fields: %w", event.Event, err)
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT OR IGNORE INTO ethContractEvents
+			(contract, blockNumber, logIndex, txHash, event, signature, fields) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			address, event.BlockNumber, event.LogIndex, event.TxHash, event.Event, event.Signature, string(fields),
+		)
+		if err != nil {
+			return fmt.Errorf("error saving %s event of %s: %w", event.Event, address, err)
+		}
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO ethEventSync (contract, lastBlock, lastHash) VALUES (?, ?, ?)
+		ON CONFLICT(contract) DO UPDATE SET lastBlock = excluded.lastBlock, lastHash = excluded.lastHash`,
+		address, lastBlock, blockHashText(lastHash),
+	)
+	if err != nil {
+		return fmt.Errorf("error saving event sync position of %s: %w", address, err)
+	}
+
+	return tx.Commit()
+}
+
+// LastSyncedBlock returns the block the events of a contract were synced up to, with its
+// hash when known, and false when they were never synced.
+func (e *EventStorage) LastSyncedBlock(ctx context.Context, contract string) (uint64, common.Hash, bool, error) {
+	var lastBlock uint64
+	var lastHash string
+	err := e.db.QueryRowContext(
+		ctx,
+		"SELECT lastBlock, lastHash FROM ethEventSync WHERE contract = ?",
+		common.HexToAddress(contract).Hex(),
+	).Scan(&lastBlock, &lastHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, common.Hash{}, false, nil
+	}
+
+	if err != nil {
+		return 0, common.Hash{}, false, fmt.Errorf("error retrieving event sync position of %s: %w", contract, err)
+	}
+
+	return lastBlock, common.HexToHash(lastHash), true, nil
+}
+
+// RollbackEvents drops the events of a contract from fromBlock on, and moves its sync
+// position back to the block before, so that they are synced again.
+func (e *EventStorage) RollbackEvents(ctx context.Context, contract string, fromBlock uint64) error {
+	address := common.HexToAddress(contract).Hex()
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM ethContractEvents WHERE contract = ? AND blockNumber >= ?",
+		address, fromBlock,
+	)
+	if err != nil {
+		return fmt.Errorf("error dropping events of %s: %w", address, err)
+	}
+
+	if fromBlock == 0 {
+		_, err = tx.ExecContext(ctx, "DELETE FROM ethEventSync WHERE contract = ?", address)
+	} else {
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE ethEventSync SET lastBlock = ?, lastHash = '' WHERE contract = ?",
+			fromBlock-1, address,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("error saving event sync position of %s: %w", address, err)
+	}
+
+	return tx.Commit()
+}
+
+// blockHashText stores unknown block hashes as empty strings.
+func blockHashText(hash common.Hash) string {
+	if hash == (common.Hash{}) {
+		return ""
+	}
+
+	return hash.Hex()
+}
+
+// addColumnIfMissing upgrades tables created by older versions of the wallet.
+func addColumnIfMissing(ctx context.Context, db *sql.DB, table, column, definition string) error {
+	var count int
+	err := db.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?",
+		table,
+		column,
+	).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	_, err = db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
+// GetEvents returns the latest events of a contract, newest first, optionally only those
+// named event. A limit of 0 returns them all.
+func (e *EventStorage) GetEvents(ctx context.Context, contract, event string, limit int) ([]EventLog, error) {
+	query := `SELECT contract, blockNumber, logIndex, txHash, event, signature, fields FROM ethContractEvents
+		WHERE contract = ? AND (? = '' OR event = ?) ORDER BY blockNumber DESC, logIndex DESC`
+	args := []interface{}{common.HexToAddress(contract).Hex(), event, event}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := e.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying contract events: %w", err)
+	}
+
+	defer rows.Close()
+	var events []EventLog
+	for rows.Next() {
+		var eventLog EventLog
+		var fields string
+		err = rows.Scan(
+			&eventLog.Contract,
+			&eventLog.BlockNumber,
+			&eventLog.LogIndex,
+			&eventLog.TxHash,
+			&eventLog.Event,
+			&eventLog.Signature,
+			&fields,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+
+		err = json.Unmarshal([]byte(fields), &eventLog.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s event fields: %w", eventLog.Event, err)
+		}
+		events = append(events, eventLog)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving contract event rows from db: %w", err)
+	}
+
+	return events, nil
+}
+
+// approvalScan names the scan position of the Approval events in ethLogScans.
+const approvalScan = "approvals"
+
+// saveApprovals stores the approved spenders found up to lastBlock, and moves the approval
+// scan position of the owners to lastBlock.
+func (e *EventStorage) saveApprovals(
+	ctx context.Context,
+	owners []common.Address,
+	keys []allowanceKey,
+	lastBlock uint64,
+) error {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, key := range keys {
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT OR IGNORE INTO ethApprovals (token, owner, spender) VALUES (?, ?, ?)",
+			key.token.Hex(), key.owner.Hex(), key.spender.Hex(),
+		)
+		if err != nil {
+			return fmt.Errorf("error saving approval of %s: %w", key.token.Hex(), err)
+		}
+	}
+
+	err = saveScanPosition(ctx, tx, approvalScan, owners, lastBlock)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// approvals returns the stored approved spenders of the owners.
+func (e *EventStorage) approvals(ctx context.Context, owners []common.Address) ([]allowanceKey, error) {
+	rows, err := e.db.QueryContext(ctx, "SELECT token, owner, spender FROM ethApprovals")
+	if err != nil {
+		return nil, fmt.Errorf("error querying approvals: %w", err)
+	}
+
+	defer rows.Close()
+	isOwner := ownerSet(owners)
+	var keys []allowanceKey
+	for rows.Next() {
+		var token, owner, spender string
+		err = rows.Scan(&token, &owner, &spender)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+
+		key := allowanceKey{
+			token:   common.HexToAddress(token),
+			owner:   common.HexToAddress(owner),
+			spender: common.HexToAddress(spender),
+		}
+		if isOwner[key.owner] {
+			keys = append(keys, key)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving approval rows from db: %w", err)
+	}
+
+	return keys, nil
+}
+
+// saveCollectibles stores the tokens of a standard the owners received up to lastBlock,
+// and moves the scan position of the owners for the standard to lastBlock.
+func (e *EventStorage) saveCollectibles(
+	ctx context.Context,
+	standard string,
+	owners []common.Address,
+	keys []multiTokenKey,
+	lastBlock uint64,
+) error {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, key := range keys {
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT OR IGNORE INTO ethCollectibles (standard, contract, owner, tokenId) VALUES (?, ?, ?, ?)",
+			standard, key.contract.Hex(), key.owner.Hex(), key.id.Hex(),
+		)
+		if err != nil {
+			return fmt.Errorf("error saving %s token of %s: %w", standard, key.contract.Hex(), err)
+		}
+	}
+
+	err = saveScanPosition(ctx, tx, standard, owners, lastBlock)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// collectibles returns the stored tokens of a standard received by the owners, in the
+// order they were stored.
+func (e *EventStorage) collectibles(
+	ctx context.Context,
+	standard string,
+	owners []common.Address,
+) ([]multiTokenKey, error) {
+	rows, err := e.db.QueryContext(
+		ctx,
+		"SELECT contract, owner, tokenId FROM ethCollectibles WHERE standard = ? ORDER BY rowid",
+		standard,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying collectibles: %w", err)
+	}
+
+	defer rows.Close()
+	isOwner := ownerSet(owners)
+	var keys []multiTokenKey
+	for rows.Next() {
+		var contract, owner, tokenID string
+		err = rows.Scan(&contract, &owner, &tokenID)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+
+		key := multiTokenKey{
+			contract: common.HexToAddress(contract),
+			owner:    common.HexToAddress(owner),
+			id:       common.HexToHash(tokenID),
+		}
+		if isOwner[key.owner] {
+			keys = append(keys, key)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving collectible rows from db: %w", err)
+	}
+
+	return keys, nil
+}
+
+// scanStart returns the first block a scan of the owners' logs still needs to read from.
+// Owners that were never scanned start from block 0.
+func (e *EventStorage) scanStart(ctx context.Context, scan string, owners []common.Address) (uint64, error) {
+	start := uint64(0)
+	for i, owner := range owners {
+		var lastBlock uint64
+		err := e.db.QueryRowContext(
+			ctx,
+			"SELECT lastBlock FROM ethLogScans WHERE scan = ? AND owner = ?",
+			scan, owner.Hex(),
+		).Scan(&lastBlock)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("error retrieving %s scan position of %s: %w", scan, owner.Hex(), err)
+		}
+
+		if i == 0 || lastBlock+1 < start {
+			start = lastBlock + 1
+		}
+	}
+
+	return start, nil
+}
+
+// saveScanPosition moves the scan position of the owners to lastBlock.
+func saveScanPosition(ctx context.Context, tx *sql.Tx, scan string, owners []common.Address, lastBlock uint64) error {
+	for _, owner := range owners {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO ethLogScans (scan, owner, lastBlock) VALUES (?, ?, ?)
+			ON CONFLICT(scan, owner) DO UPDATE SET lastBlock = excluded.lastBlock`,
+			scan, owner.Hex(), lastBlock,
+		)
+		if err != nil {
+			return fmt.Errorf("error saving %s scan position of %s: %w", scan, owner.Hex(), err)
+		}
+	}
+
+	return nil
+}
+
+func ownerSet(owners []common.Address) map[common.Address]bool {
+	isOwner := make(map[common.Address]bool, len(owners))
+	for _, owner := range owners {
+		isOwner[owner] = true
+	}
+
+	return isOwner
+}
@@ -0,0 +1,351 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// eventSyncRange is the number of blocks asked for in one eth_getLogs request. Public
+	// providers reject log queries over too many blocks.
+	eventSyncRange = 5000
+	// eventSyncTimeout bounds a whole event sync, which can take several requests.
+	eventSyncTimeout = 30 * time.Second
+	// eventReorgDepth is the number of blocks synced again when the last synced block was
+	// replaced by a reorganization of the chain.
+	eventReorgDepth = 64
+)
+
+// EventLog is a log emitted by a contract, decoded with its ABI.
+type EventLog struct {
+	Contract    string          `json:"contract"`
+	Event       string          `json:"event"`
+	Signature   string          `json:"signature"`
+	BlockNumber uint64          `json:"blockNumber"`
+	TxHash      string          `json:"txHash"`
+	LogIndex    uint            `json:"logIndex"`
+	Fields      []ContractParam `json:"fields"`
+}
+
+// EventTopics builds the topic filter of eth_getLogs matching an event whose indexed
+// inputs have the given values, in order. Empty values match anything.
+func (c *Contract) EventTopics(name string, args []string) ([][]common.Hash, error) {
+	event, ok := c.abi.Events[name]
+	if !ok {
+		return nil, fmt.Errorf("contract %s has no event %q", c.Name, name)
+	}
+
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+
+	if len(args) > len(indexed) {
+		return nil, fmt.Errorf("event %s has %d indexed inputs, got %d values", event.Sig, len(indexed), len(args))
+	}
+
+	topics := [][]common.Hash{{event.ID}}
+	for i, arg := range args {
+		if strings.TrimSpace(arg) == "" {
+			topics = append(topics, nil)
+			continue
+		}
+
+		value, err := parseABIArgument(indexed[i].Type, arg)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s (%s): %w", indexed[i].Name, indexed[i].Type.String(), err)
+		}
+
+		rules, err := abi.MakeTopics([]interface{}{value})
+		if err != nil {
+			return nil, fmt.Errorf("argument %s (%s): %w", indexed[i].Name, indexed[i].Type.String(), err)
+		}
+		topics = append(topics, rules[0])
+	}
+
+	return topics, nil
+}
+
+// DecodeLog decodes a log of the contract into the named fields of its event. Indexed
+// strings, bytes, arrays and structs are only logged as their hash, which is shown as is.
+func (c *Contract) DecodeLog(log types.Log) (*EventLog, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("log %d of transaction %s has no topic", log.Index, log.TxHash.Hex())
+	}
+
+	event, err := c.abi.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, fmt.Errorf("contract %s has no event with topic %s", c.Name, log.Topics[0].Hex())
+	}
+
+	fields := describeArguments(event.Inputs)
+	values, err := event.Inputs.NonIndexed().Unpack(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s data: %w", event.Sig, err)
+	}
+
+	topics := log.Topics[1:]
+	for i, input := range event.Inputs {
+		if !input.Indexed {
+			fields[i].Value = formatABIValue(values[0])
+			values = values[1:]
+			continue
+		}
+
+		// Events sharing a signature may index different inputs, such as ERC-20 and
+		// ERC-721 transfers.
+		if len(topics) == 0 {
+			return nil, fmt.Errorf("log does not match the indexed inputs of %s", event.Sig)
+		}
+
+		fields[i].Value, err = decodeTopic(input, topics[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s topic %s: %w", event.Sig, input.Name, err)
+		}
+		topics = topics[1:]
+	}
+
+	if len(topics) > 0 {
+		return nil, fmt.Errorf("log does not match the indexed inputs of %s", event.Sig)
+	}
+
+	return &EventLog{
+		Contract:    log.Address.Hex(),
+		Event:       event.Name,
+		Signature:   event.Sig,
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash.Hex(),
+		LogIndex:    log.Index,
+		Fields:      fields,
+	}, nil
+}
+
+// decodeLogs decodes the logs of the contract, skipping those its ABI does not describe.
+func (c *Contract) decodeLogs(logs []types.Log) []EventLog {
+	events := make([]EventLog, 0, len(logs))
+	for _, log := range logs {
+		event, err := c.DecodeLog(log)
+		if err != nil || log.Removed {
+			continue
+		}
+		events = append(events, *event)
+	}
+
+	return events
+}
+
+func decodeTopic(input abi.Argument, topic common.Hash) (string, error) {
+	switch input.Type.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return topic.Hex(), nil
+	}
+
+	values := make(map[string]interface{})
+	err := abi.ParseTopicsIntoMap(values, abi.Arguments{input}, []common.Hash{topic})
+	if err != nil {
+		return "", err
+	}
+
+	return formatABIValue(values[input.Name]), nil
+}
+
+// filterContractLogs runs eth_getLogs on contracts from block from to block to, inclusive,
+// eventSyncRange blocks at a time. No addresses match the logs of any contract. onLogs is
+// called after each range with its logs and the last block it covers.
+func (a *MasterAccount) filterContractLogs(
+	ctx context.Context,
+	addresses []common.Address,
+	topics [][]common.Hash,
+	from, to uint64,
+	onLogs func(logs []types.Log, lastBlock uint64) error,
+) error {
+	backend := NewContractBackend(a.client)
+	for start := from; start <= to; start += eventSyncRange {
+		end := min(start+eventSyncRange-1, to)
+		logs, err := backend.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: addresses,
+			Topics:    topics,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read logs of blocks %d to %d: %w", start, end, err)
+		}
+
+		err = onLogs(logs, end)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// QueryContractEvents looks up the events of a registered contract between two blocks,
+// inclusive, without storing them. A toBlock of 0 stands for the latest block. When event
+// is set, only its logs are returned, filtered by the values of its indexed inputs.
+func (a *MasterAccount) QueryContractEvents(
+	contractName, event string,
+	args []string,
+	fromBlock, toBlock uint64,
+) ([]EventLog, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, eventSyncTimeout)
+	defer cancel()
+	contract, err := a.contractDB.GetContract(cliCtx, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	var topics [][]common.Hash
+	if event != "" {
+		topics, err = contract.EventTopics(event, args)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if toBlock == 0 {
+		toBlock, err = a.client.BlockNumber(cliCtx)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving block number: %w", err)
+		}
+	}
+
+	var events []EventLog
+	addresses := []common.Address{common.HexToAddress(contract.Address)}
+	err = a.filterContractLogs(cliCtx, addresses, topics, fromBlock, toBlock, func(logs []types.Log, _ uint64) error {
+		events = append(events, contract.decodeLogs(logs)...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// SyncContractEvents stores the events a registered contract emitted since it was last
+// synced, or since the first block, and returns them. The sync position is saved after
+// every range of blocks, so an interrupted sync resumes where it stopped.
+func (a *MasterAccount) SyncContractEvents(contractName string) ([]EventLog, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, eventSyncTimeout)
+	defer cancel()
+	contract, err := a.contractDB.GetContract(cliCtx, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	from := uint64(0)
+	lastBlock, lastHash, synced, err := a.eventDB.LastSyncedBlock(cliCtx, contract.Address)
+	if err != nil {
+		return nil, err
+	}
+	if synced {
+		from = lastBlock + 1
+		replaced, err := a.blockReplaced(cliCtx, lastBlock, lastHash)
+		if err != nil {
+			return nil, err
+		}
+
+		// The events of the replaced blocks are dropped, and the blocks synced again.
+		if replaced {
+			from -= min(from, eventReorgDepth)
+			err = a.eventDB.RollbackEvents(cliCtx, contract.Address, from)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	head, err := a.latestBlock(cliCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []EventLog
+	addresses := []common.Address{common.HexToAddress(contract.Address)}
+	onLogs := func(logs []types.Log, lastBlock uint64) error {
+		decoded := contract.decodeLogs(logs)
+		events = append(events, decoded...)
+
+		// Only the hash of the head is known, the earlier ranges are not checked.
+		var lastHash common.Hash
+		if lastBlock == head.Number {
+			lastHash = head.Hash
+		}
+		return a.eventDB.SaveEvents(cliCtx, contract.Address, decoded, lastBlock, lastHash)
+	}
+	err = a.filterContractLogs(cliCtx, addresses, nil, from, head.Number, onLogs)
+	if err != nil {
+		return events, fmt.Errorf("error syncing events of %s: %w", contract.Name, err)
+	}
+
+	return events, nil
+}
+
+// blockRef is the number and hash of a block.
+type blockRef struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+// latestBlock returns the number and hash of the latest block.
+func (a *MasterAccount) latestBlock(ctx context.Context) (*blockRef, error) {
+	var block *struct {
+		Number hexutil.Uint64 `json:"number"`
+		Hash   common.Hash    `json:"hash"`
+	}
+	err := NewContractBackend(a.client).call(ctx, &block, "eth_getBlockByNumber", "latest", false)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving latest block: %w", err)
+	}
+
+	if block == nil {
+		return nil, fmt.Errorf("error retrieving latest block: %w", ethereum.NotFound)
+	}
+
+	return &blockRef{Number: uint64(block.Number), Hash: block.Hash}, nil
+}
+
+// blockReplaced reports whether the block at number no longer has the given hash, or no
+// longer exists, after a reorganization of the chain. Unknown hashes are not checked.
+func (a *MasterAccount) blockReplaced(ctx context.Context, number uint64, hash common.Hash) (bool, error) {
+	if hash == (common.Hash{}) {
+		return false, nil
+	}
+
+	var block *struct {
+		Hash common.Hash `json:"hash"`
+	}
+	tag := hexutil.EncodeUint64(number)
+	err := NewContractBackend(a.client).call(ctx, &block, "eth_getBlockByNumber", tag, false)
+	if err != nil {
+		return false, fmt.Errorf("error retrieving block %d: %w", number, err)
+	}
+
+	return block == nil || block.Hash != hash, nil
+}
+
+// GetContractEvents returns the stored events of a registered contract, newest first,
+// optionally only those named event. A limit of 0 returns them all.
+func (a *MasterAccount) GetContractEvents(contractName, event string, limit int) ([]EventLog, error) {
+	dbCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	contract, err := a.contractDB.GetContract(dbCtx, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.eventDB.GetEvents(dbCtx, contract.Address, event, limit)
+}
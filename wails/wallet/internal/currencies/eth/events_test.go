@@ -0,0 +1,85 @@
+package eth_test
+
+import (
+	"math/big"
+	"testing"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestContractEvents(t *testing.T) {
+	demoToken := loadArtifactContract(t, "DemoToken")
+	transfer := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	topic := func(address string) common.Hash {
+		return common.BytesToHash(common.HexToAddress(address).Bytes())
+	}
+
+	t.Run("Artifact events are listed with their indexed inputs", func(t *testing.T) {
+		assertCorrectValue(t, len(demoToken.Events), 2)
+		assertCorrectValue(t, demoToken.Events[1].Signature, "Transfer(address,address,uint256)")
+		assertCorrectValue(t, demoToken.Events[1].Inputs, []eth.ContractParam{
+			{Name: "from", Type: "address", Indexed: true},
+			{Name: "to", Type: "address", Indexed: true},
+			{Name: "value", Type: "uint256"},
+		})
+	})
+
+	t.Run("Indexed values filter the topics", func(t *testing.T) {
+		topics, err := demoToken.EventTopics("Transfer", []string{"", hardhatAccount1})
+		if err != nil {
+			t.Fatalf("Failed to build topics: %v", err)
+		}
+		assertCorrectValue(t, topics, [][]common.Hash{{transfer}, nil, {topic(hardhatAccount1)}})
+
+		_, err = demoToken.EventTopics("Transfer", []string{"", "", "1"})
+		assertError(t, err, "event Transfer(address,address,uint256) has 2 indexed inputs, got 3 values")
+
+		_, err = demoToken.EventTopics("Transfer", []string{"0x1234"})
+		assertError(t, err, `argument from (address): invalid address "0x1234"`)
+
+		_, err = demoToken.EventTopics("Mint", nil)
+		assertError(t, err, `contract DemoToken has no event "Mint"`)
+	})
+
+	t.Run("Logs are decoded into named fields", func(t *testing.T) {
+		log := types.Log{
+			Address:     common.HexToAddress(contractAddress),
+			Topics:      []common.Hash{transfer, topic(hardhatAccount0), topic(hardhatAccount1)},
+			Data:        common.BigToHash(big.NewInt(25)).Bytes(),
+			BlockNumber: 7,
+			TxHash:      common.HexToHash("0x01"),
+			Index:       2,
+		}
+
+		event, err := demoToken.DecodeLog(log)
+		if err != nil {
+			t.Fatalf("Failed to decode log: %v", err)
+		}
+
+		assertCorrectValue(t, *event, eth.EventLog{
+			Contract:    common.HexToAddress(contractAddress).Hex(),
+			Event:       "Transfer",
+			Signature:   "Transfer(address,address,uint256)",
+			BlockNumber: 7,
+			TxHash:      common.HexToHash("0x01").Hex(),
+			LogIndex:    2,
+			Fields: []eth.ContractParam{
+				{Name: "from", Type: "address", Value: hardhatAccount0, Indexed: true},
+				{Name: "to", Type: "address", Value: hardhatAccount1, Indexed: true},
+				{Name: "value", Type: "uint256", Value: "25"},
+			},
+		})
+
+		// An ERC-721 transfer shares the signature but also indexes the token ID.
+		log.Topics = append(log.Topics, common.BigToHash(big.NewInt(25)))
+		_, err = demoToken.DecodeLog(log)
+		assertError(t, err, "log does not match the indexed inputs of Transfer(address,address,uint256)")
+
+		log.Topics = []common.Hash{crypto.Keccak256Hash([]byte("Paused(address)"))}
+		_, err = demoToken.DecodeLog(log)
+		assertError(t, err, "contract DemoToken has no event with topic")
+	})
+}
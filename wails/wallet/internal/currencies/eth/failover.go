@@ -0,0 +1,351 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	defaultRequestTimeout = 10 * time.Second
+	defaultMaxRetries     = 2
+	defaultBackoff        = 250 * time.Millisecond
+	defaultMaxBlockLag    = 5
+)
+
+// idempotentMethods are the read-only methods that can be sent again, possibly to
+// another endpoint, when a node fails to answer. Anything else, eth_sendRawTransaction
+// above all, is sent once: a node may have accepted a transaction it failed to confirm.
+var idempotentMethods = map[string]bool{
+	"eth_blockNumber":           true,
+	"eth_call":                  true,
+	"eth_chainId":               true,
+	"eth_estimateGas":           true,
+	"eth_feeHistory":            true,
+	"eth_gasPrice":              true,
+	"eth_getBalance":            true,
+	"eth_getBlockByHash":        true,
+	"eth_getBlockByNumber":      true,
+	"eth_getCode":               true,
+	"eth_getLogs":               true,
+	"eth_getStorageAt":          true,
+	"eth_getTransactionByHash":  true,
+	"eth_getTransactionCount":   true,
+	"eth_getTransactionReceipt": true,
+	"eth_maxPriorityFeePerGas":  true,
+	"net_listening":             true,
+	"net_version":               true,
+	"web3_clientVersion":        true,
+}
+
+// ProviderStatus is the health of an RPC endpoint as last seen by the wallet.
+type ProviderStatus struct {
+	URL         string    `json:"url"`
+	Active      bool      `json:"active"`
+	Healthy     bool      `json:"healthy"`
+	Listening   bool      `json:"listening"`
+	BlockNumber uint64    `json:"blockNumber"`
+	BlockLag    uint64    `json:"blockLag"`
+	LatencyMs   int64     `json:"latencyMs"`
+	Failures    int       `json:"failures"`
+	LastError   string    `json:"lastError"`
+	LastChecked time.Time `json:"lastChecked"`
+}
+
+type endpoint struct {
+	url     string
+	backend Backend
+	status  ProviderStatus
+}
+
+// FailoverBackend spreads requests over several endpoints of the same network. Requests
+// stick to the active endpoint and move to the next healthiest one when it fails.
+// Idempotent methods are retried with exponential backoff.
+type FailoverBackend struct {
+	MaxRetries  int
+	Backoff     time.Duration
+	MaxBlockLag uint64
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+	active    int
+}
+
+func NewFailoverBackend(urls []string) (*FailoverBackend, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("at least one provider is required")
+	}
+
+	b := &FailoverBackend{
+		MaxRetries:  defaultMaxRetries,
+		Backoff:     defaultBackoff,
+		MaxBlockLag: defaultMaxBlockLag,
+	}
+	for _, url := range urls {
+		b.endpoints = append(b.endpoints, &endpoint{
+			url:     url,
+			backend: &HTTPBackend{URL: url},
+			status:  ProviderStatus{URL: url, Healthy: true},
+		})
+	}
+
+	return b, nil
+}
+
+func (b *FailoverBackend) Send(ctx context.Context, request []byte) ([]byte, error) {
+	attempts := 1
+	if isIdempotentRequest(request) {
+		attempts += b.MaxRetries
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			err := sleepContext(ctx, b.Backoff<<(attempt-1))
+			if err != nil {
+				return nil, fmt.Errorf("%w (last error: %v)", err, lastErr)
+			}
+		}
+
+		idx, ep := b.pick()
+		start := time.Now()
+		body, err := ep.backend.Send(ctx, request)
+		if err == nil && !json.Valid(body) {
+			err = errors.New("node returned malformed JSON")
+		}
+
+		if err == nil {
+			b.recordSuccess(idx, time.Since(start))
+			return body, nil
+		}
+
+		lastErr = fmt.Errorf("%s: %w", ep.url, err)
+		if ctx.Err() != nil {
+			return nil, lastErr
+		}
+
+		b.recordFailure(idx, err)
+	}
+
+	return nil, lastErr
+}
+
+// CheckHealth probes every endpoint with net_listening and eth_blockNumber. Endpoints
+// that are not listening or lag more than MaxBlockLag blocks behind the most advanced
+// one are marked unhealthy, and the active endpoint moves away from them.
+func (b *FailoverBackend) CheckHealth(ctx context.Context) []ProviderStatus {
+	b.mu.Lock()
+	endpoints := append([]*endpoint(nil), b.endpoints...)
+	b.mu.Unlock()
+
+	results := make([]ProviderStatus, len(endpoints))
+	var wg sync.WaitGroup
+	for i, ep := range endpoints {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			results[i] = probeEndpoint(ctx, ep.backend)
+		}(i, ep)
+	}
+	wg.Wait()
+
+	var highest uint64
+	for _, result := range results {
+		if result.LastError == "" && result.BlockNumber > highest {
+			highest = result.BlockNumber
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, ep := range endpoints {
+		result := results[i]
+		ep.status.Listening = result.Listening
+		ep.status.BlockNumber = result.BlockNumber
+		ep.status.LatencyMs = result.LatencyMs
+		ep.status.LastError = result.LastError
+		ep.status.LastChecked = time.Now()
+		ep.status.BlockLag = 0
+		if result.LastError == "" {
+			ep.status.BlockLag = highest - result.BlockNumber
+		}
+
+		ep.status.Healthy = result.LastError == "" && result.Listening && ep.status.BlockLag <= b.MaxBlockLag
+		if ep.status.Healthy {
+			ep.status.Failures = 0
+		}
+	}
+
+	if !b.endpoints[b.active].status.Healthy {
+		b.active = b.ranked()[0]
+	}
+
+	return b.statusLocked()
+}
+
+// Status returns the endpoints as of the last request or health check.
+func (b *FailoverBackend) Status() []ProviderStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.statusLocked()
+}
+
+func (b *FailoverBackend) statusLocked() []ProviderStatus {
+	statuses := make([]ProviderStatus, len(b.endpoints))
+	for i, ep := range b.endpoints {
+		statuses[i] = ep.status
+		statuses[i].Active = i == b.active
+	}
+
+	return statuses
+}
+
+// pick returns the active endpoint unless it is unhealthy and a healthy one is available.
+func (b *FailoverBackend) pick() (int, *endpoint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.endpoints[b.active].status.Healthy {
+		b.active = b.ranked()[0]
+	}
+
+	return b.active, b.endpoints[b.active]
+}
+
+// ranked orders endpoint indexes from the healthiest: healthy ones first, then the
+// least behind, the least failing and the fastest.
+func (b *FailoverBackend) ranked() []int {
+	order := make([]int, len(b.endpoints))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		a, c := b.endpoints[order[i]].status, b.endpoints[order[j]].status
+		switch {
+		case a.Healthy != c.Healthy:
+			return a.Healthy
+		case a.BlockLag != c.BlockLag:
+			return a.BlockLag < c.BlockLag
+		case a.Failures != c.Failures:
+			return a.Failures < c.Failures
+		default:
+			return a.LatencyMs < c.LatencyMs
+		}
+	})
+
+	return order
+}
+
+func (b *FailoverBackend) recordSuccess(idx int, latency time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	status := &b.endpoints[idx].status
+	status.Healthy = true
+	status.Failures = 0
+	status.LastError = ""
+	status.LatencyMs = latency.Milliseconds()
+	b.active = idx
+}
+
+// recordFailure marks the endpoint unhealthy and moves the active endpoint to the
+// healthiest of the others, so the next attempt goes elsewhere.
+func (b *FailoverBackend) recordFailure(idx int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	status := &b.endpoints[idx].status
+	status.Healthy = false
+	status.Failures++
+	status.LastError = err.Error()
+	if b.active == idx {
+		b.active = b.ranked()[0]
+	}
+}
+
+func probeEndpoint(ctx context.Context, backend Backend) ProviderStatus {
+	var status ProviderStatus
+	start := time.Now()
+	err := probe(ctx, backend, "net_listening", &status.Listening)
+	status.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		status.LastError = err.Error()
+		return status
+	}
+
+	var blockNumber hexutil.Uint64
+	err = probe(ctx, backend, "eth_blockNumber", &blockNumber)
+	if err != nil {
+		status.LastError = err.Error()
+		return status
+	}
+
+	status.BlockNumber = uint64(blockNumber)
+	return status
+}
+
+func probe(ctx context.Context, backend Backend, method string, result interface{}) error {
+	client := NewClientWithBackend(backend)
+	response, err := client.sendRequestToNode(ctx, RPCPayload{
+		Jsonrpc: "2.0",
+		Method:  method,
+		Params:  []interface{}{},
+		ID:      method,
+	})
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", method, err)
+	}
+
+	data, err := json.Marshal(response["result"])
+	if err != nil {
+		return fmt.Errorf("failed to read %s result: %w", method, err)
+	}
+
+	err = json.Unmarshal(data, result)
+	if err != nil {
+		return fmt.Errorf("invalid %s result: %w", method, err)
+	}
+
+	return nil
+}
+
+// isIdempotentRequest tells whether a request, or every request of a batch, can be
+// sent again.
+func isIdempotentRequest(request []byte) bool {
+	type call struct {
+		Method string `json:"method"`
+	}
+
+	var calls []call
+	if json.Unmarshal(request, &calls) != nil {
+		var single call
+		if json.Unmarshal(request, &single) != nil {
+			return false
+		}
+		calls = []call{single}
+	}
+
+	for _, c := range calls {
+		if !idempotentMethods[c.Method] {
+			return false
+		}
+	}
+
+	return len(calls) > 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
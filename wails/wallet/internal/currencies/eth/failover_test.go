@@ -0,0 +1,148 @@
+package eth_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethmock"
+)
+
+func TestFailoverBackend(t *testing.T) {
+	ctx := context.Background()
+	unavailable := ethmock.Response{Status: http.StatusServiceUnavailable, Body: "unavailable"}
+
+	t.Run("Idempotent requests fail over to the next endpoint", func(t *testing.T) {
+		primary, secondary := newMockNode(t), newMockNode(t)
+		primary.On("eth_gasPrice", unavailable)
+		secondary.OnResult("eth_gasPrice", "0x64")
+
+		backend := newFailoverBackend(t, primary.URL, secondary.URL)
+		gasPrice, err := eth.NewClientWithBackend(backend).GetGasPrice(ctx)
+		if err != nil {
+			t.Fatalf("Failed to get gas price: %v", err)
+		}
+		assertCorrectValue(t, gasPrice, big.NewInt(100))
+
+		status := backend.Status()
+		assertCorrectValue(t, status[0].Healthy, false)
+		assertCorrectValue(t, status[0].Failures, 1)
+		assertCorrectValue(t, status[1].Active, true)
+
+		_, err = eth.NewClientWithBackend(backend).GetGasPrice(ctx)
+		if err != nil {
+			t.Fatalf("Failed to get gas price: %v", err)
+		}
+		assertCorrectValue(t, len(primary.Requests("")), 1)
+	})
+
+	t.Run("Idempotent requests are retried with backoff", func(t *testing.T) {
+		node := newMockNode(t)
+		node.On("eth_getTransactionCount", unavailable, ethmock.Response{Body: "{"}, ethmock.Response{Result: "0x3"})
+
+		backend := newFailoverBackend(t, node.URL)
+		nonce, err := eth.NewClientWithBackend(backend).GetNonce(ctx, hardhatAccount0)
+		if err != nil {
+			t.Fatalf("Failed to get nonce: %v", err)
+		}
+		assertCorrectValue(t, nonce, uint64(3))
+		assertCorrectValue(t, len(node.Requests("eth_getTransactionCount")), 3)
+		assertCorrectValue(t, backend.Status()[0].Healthy, true)
+	})
+
+	t.Run("Raw transactions are never sent twice", func(t *testing.T) {
+		primary, secondary := newMockNode(t), newMockNode(t)
+		primary.On("eth_sendRawTransaction", ethmock.Response{Status: http.StatusGatewayTimeout})
+		secondary.OnResult("eth_sendRawTransaction", mockTxHash)
+
+		backend := newFailoverBackend(t, primary.URL, secondary.URL)
+		_, err := eth.NewClientWithBackend(backend).BroadcastTransaction(ctx, "0x01")
+		assertError(t, err, "HTTP 504")
+		assertCorrectValue(t, len(primary.Requests("eth_sendRawTransaction")), 1)
+		assertCorrectValue(t, len(secondary.Requests("eth_sendRawTransaction")), 0)
+	})
+
+	t.Run("Node errors are not retried", func(t *testing.T) {
+		primary, secondary := newMockNode(t), newMockNode(t)
+		primary.OnError("eth_call", 3, "execution reverted")
+
+		backend := newFailoverBackend(t, primary.URL, secondary.URL)
+		_, err := eth.NewClientWithBackend(backend).Call(ctx, hardhatAccount0, contractAddress, nil)
+		assertError(t, err, "execution reverted")
+		assertCorrectValue(t, len(secondary.Requests("")), 0)
+		assertCorrectValue(t, backend.Status()[0].Healthy, true)
+	})
+
+	t.Run("Retries stop when the context is done", func(t *testing.T) {
+		node := newMockNode(t)
+		node.On("eth_gasPrice", unavailable)
+
+		backend := newFailoverBackend(t, node.URL)
+		backend.Backoff = time.Second
+		timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		_, err := eth.NewClientWithBackend(backend).GetGasPrice(timeoutCtx)
+		assertError(t, err, context.DeadlineExceeded.Error())
+		assertCorrectValue(t, len(node.Requests("")), 1)
+	})
+}
+
+func TestFailoverBackendHealthCheck(t *testing.T) {
+	lagging, synced, deaf, down := newMockNode(t), newMockNode(t), newMockNode(t), newMockNode(t)
+	for node, blockNumber := range map[*ethmock.Server]string{lagging: "0x5a", synced: "0x64", deaf: "0x64"} {
+		node.OnResult("eth_blockNumber", blockNumber)
+	}
+	lagging.OnResult("net_listening", true)
+	synced.OnResult("net_listening", true)
+	deaf.OnResult("net_listening", false)
+	down.On("net_listening", ethmock.Response{Status: http.StatusInternalServerError})
+
+	backend := newFailoverBackend(t, lagging.URL, synced.URL, deaf.URL, down.URL)
+	status := backend.CheckHealth(context.Background())
+
+	cases := []struct {
+		name        string
+		status      eth.ProviderStatus
+		healthy     bool
+		active      bool
+		blockNumber uint64
+		blockLag    uint64
+	}{
+		{name: "Lagging", status: status[0], blockNumber: 90, blockLag: 10},
+		{name: "Synced", status: status[1], healthy: true, active: true, blockNumber: 100},
+		{name: "Not listening", status: status[2], blockNumber: 100},
+		{name: "Down", status: status[3]},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertCorrectValue(t, tc.status.Healthy, tc.healthy)
+			assertCorrectValue(t, tc.status.Active, tc.active)
+			assertCorrectValue(t, tc.status.BlockNumber, tc.blockNumber)
+			assertCorrectValue(t, tc.status.BlockLag, tc.blockLag)
+		})
+	}
+	assertCorrectValue(t, status[3].LastError != "", true)
+
+	synced.OnResult("eth_chainId", "0x539")
+	chainID, err := eth.NewClientWithBackend(backend).GetChainID(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get chain ID: %v", err)
+	}
+	assertCorrectValue(t, chainID, int64(1337))
+	assertCorrectValue(t, len(lagging.Requests("eth_chainId")), 0)
+}
+
+func newFailoverBackend(t testing.TB, urls ...string) *eth.FailoverBackend {
+	t.Helper()
+	backend, err := eth.NewFailoverBackend(urls)
+	if err != nil {
+		t.Fatalf("Failed to create failover backend: %v", err)
+	}
+	backend.Backoff = time.Millisecond
+
+	return backend
+}
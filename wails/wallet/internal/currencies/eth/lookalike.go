@@ -0,0 +1,59 @@
+package eth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// lookalikeAffixLength is the number of leading and trailing hex characters that address
+// poisoning attacks usually match, since wallets often shorten addresses to them.
+const lookalikeAffixLength = 4
+
+// KnownAddress is an address the user dealt with before, such as a contact, a past
+// recipient or an account of the wallet, with a label to name it in warnings.
+type KnownAddress struct {
+	Address string
+	Label   string
+}
+
+// IsLookalikeAddress tells whether two different addresses share their first and last
+// hex characters, as the addresses generated to poison a transaction history do.
+func IsLookalikeAddress(a, b string) bool {
+	a = strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(a, "0x"), "0X"))
+	b = strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(b, "0x"), "0X"))
+	if a == b || len(a) != len(b) || len(a) < 2*lookalikeAffixLength {
+		return false
+	}
+
+	return a[:lookalikeAffixLength] == b[:lookalikeAffixLength] &&
+		a[len(a)-lookalikeAffixLength:] == b[len(b)-lookalikeAffixLength:]
+}
+
+// CheckRecipient compares the recipient of the review with the known addresses. It warns
+// about a lookalike of one of them, which is likely to be a poisoned address copied from
+// the history, and about a recipient the user never dealt with. Either way, the recipient
+// has to be confirmed before the transaction is signed.
+func (r *TransactionReview) CheckRecipient(known []KnownAddress) {
+	if r.HasIssue(IssueInvalidAddress) {
+		return
+	}
+
+	firstTime := true
+	for _, address := range known {
+		if strings.EqualFold(address.Address, r.To) {
+			firstTime = false
+			continue
+		}
+
+		if IsLookalikeAddress(address.Address, r.To) {
+			r.addWarning(IssueLookalikeAddress, fmt.Sprintf(
+				"The recipient %s looks like %s (%s) but is a different address.", r.To, address.Address, address.Label))
+			r.ConfirmRecipient = true
+		}
+	}
+
+	if firstTime {
+		r.addWarning(IssueFirstTimeRecipient, "You have never sent to this address.")
+		r.ConfirmRecipient = true
+	}
+}
@@ -0,0 +1,82 @@
+package eth_test
+
+import (
+	"testing"
+	"wallet/internal/currencies/eth"
+)
+
+const poisonedAccount1 = "0x70997970000000000000000000000000000079C8"
+
+func TestIsLookalikeAddress(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{name: "Same prefix and suffix", a: hardhatAccount1, b: poisonedAccount1, want: true},
+		{name: "Case is ignored", a: hardhatAccount1, b: "0x70997970000000000000000000000000000079c8", want: true},
+		{name: "Same address", a: hardhatAccount1, b: "0x70997970c51812dc3a010c7d01b50e0d17dc79c8", want: false},
+		{name: "Different prefix", a: hardhatAccount1, b: "0x80997970c51812dc3a010c7d01b50e0d17dc79C8", want: false},
+		{name: "Different suffix", a: hardhatAccount1, b: "0x70997970c51812dc3a010c7d01b50e0d17dc79C9", want: false},
+		{name: "Unrelated addresses", a: hardhatAccount0, b: hardhatAccount1, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertCorrectValue(t, eth.IsLookalikeAddress(tc.a, tc.b), tc.want)
+		})
+	}
+}
+
+func TestCheckRecipient(t *testing.T) {
+	known := []eth.KnownAddress{
+		{Address: hardhatAccount0, Label: "account 0"},
+		{Address: hardhatAccount1, Label: "contact Bob"},
+	}
+
+	cases := []struct {
+		name        string
+		review      eth.TransactionReview
+		wantWarns   []string
+		wantConfirm bool
+	}{
+		{
+			name:   "Known recipient",
+			review: eth.TransactionReview{To: "0x70997970c51812dc3a010c7d01b50e0d17dc79c8"},
+		},
+		{
+			name:        "Lookalike of a contact",
+			review:      eth.TransactionReview{To: poisonedAccount1},
+			wantWarns:   []string{eth.IssueLookalikeAddress, eth.IssueFirstTimeRecipient},
+			wantConfirm: true,
+		},
+		{
+			name:        "First time recipient",
+			review:      eth.TransactionReview{To: contractAddress},
+			wantWarns:   []string{eth.IssueFirstTimeRecipient},
+			wantConfirm: true,
+		},
+		{
+			name: "Invalid address",
+			review: eth.TransactionReview{
+				To:     "0x123",
+				Errors: []eth.ReviewIssue{{Code: eth.IssueInvalidAddress}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.review.CheckRecipient(known)
+			assertCorrectValue(t, issueCodes(tc.review.Warnings), tc.wantWarns)
+			assertCorrectValue(t, tc.review.ConfirmRecipient, tc.wantConfirm)
+		})
+	}
+
+	t.Run("Warning names the lookalike", func(t *testing.T) {
+		review := eth.TransactionReview{To: poisonedAccount1}
+		review.CheckRecipient(known)
+		assertCorrectValue(t, review.Warnings[0].Message, "The recipient "+poisonedAccount1+" looks like "+
+			hardhatAccount1+" (contact Bob) but is a different address.")
+	})
+}
@@ -2,26 +2,51 @@ package eth
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"database/sql"
 	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 	"wallet/internal/utils"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/tyler-smith/go-bip32"
 )
 
-var providers = map[string]string{
-	"hardhat": "http://localhost:8545",
+// providers lists the RPC endpoints of each network, in order of preference.
+var providers = map[string][]string{
+	"hardhat": {"http://localhost:8545"},
 }
 
-const defaultNetwork = "hardhat"
+// wsProviders is the WebSocket endpoint of each network, used for subscriptions.
+var wsProviders = map[string]string{
+	"hardhat": "ws://localhost:8545",
+}
+
+// DefaultNetwork is the network the accounts connect to.
+const DefaultNetwork = "hardhat"
 
 type MasterAccount struct {
-	tokenName string
-	client    *Client
-	ctx       context.Context
-	accountDB *AccountStorage
+	tokenName  string
+	client     *Client
+	ctx        context.Context
+	accountDB  *AccountStorage
+	contractDB *ContractStorage
+	eventDB    *EventStorage
+	nonces     *NonceManager
+	// signatures is the signature database used to decode calls, nil until one is loaded.
+	signatures atomic.Pointer[SignatureDatabase]
+	// tokenMetadata caches the metadata of collectibles by token URI.
+	tokenMetadata sync.Map
+	// collectibles holds the result of the last GetCollectibles scan, nil until one ran.
+	collectibles atomic.Pointer[[]Collectible]
 }
 
 func NewETHAccount(ctx context.Context, masterKey *bip32.Key, tokenName string, db *sql.DB) (*MasterAccount, error) {
@@ -60,12 +85,35 @@ func NewETHAccount(ctx context.Context, masterKey *bip32.Key, tokenName string,
 		}
 	}
 
-	client := NewClient(providers[defaultNetwork])
+	contractDB, err := NewContractStorage(dbCtx, db)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing %s contract DB: %w", tokenName, err)
+	}
+
+	eventDB, err := NewEventStorage(dbCtx, db)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing %s event DB: %w", tokenName, err)
+	}
+
+	nonceDB, err := NewNonceStorage(dbCtx, db)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing %s nonce DB: %w", tokenName, err)
+	}
+
+	client, err := NewClientWithProviders(providers[DefaultNetwork])
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s client: %w", tokenName, err)
+	}
+	client.SetWebSocketProvider(wsProviders[DefaultNetwork])
+
 	return &MasterAccount{
-		tokenName: tokenName,
-		client:    client,
-		ctx:       ctx,
-		accountDB: accountDB,
+		tokenName:  tokenName,
+		client:     client,
+		ctx:        ctx,
+		accountDB:  accountDB,
+		contractDB: contractDB,
+		eventDB:    eventDB,
+		nonces:     NewNonceManager(client, nonceDB),
 	}, nil
 }
 
@@ -81,6 +129,26 @@ func (a *MasterAccount) GetAddress(accountIndex int) (string, error) {
 	return address, nil
 }
 
+// GetAccountIndex returns the index of the account with the given address.
+func (a *MasterAccount) GetAccountIndex(address string) (int, error) {
+	if !common.IsHexAddress(address) {
+		return -1, fmt.Errorf("invalid address: %s", address)
+	}
+
+	dbCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	accountIndex, found, err := a.accountDB.GetAccountIndex(dbCtx, address)
+	if err != nil {
+		return -1, err
+	}
+
+	if !found {
+		return -1, fmt.Errorf("address %s does not belong to the %s wallet", address, a.tokenName)
+	}
+
+	return accountIndex, nil
+}
+
 func (a *MasterAccount) GetAllAccounts() (map[int]string, error) {
 	dbCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
 	defer cancel()
@@ -88,6 +156,24 @@ func (a *MasterAccount) GetAllAccounts() (map[int]string, error) {
 	return a.accountDB.GetAllAccounts(dbCtx)
 }
 
+// accountAddresses returns the addresses of the accounts, and the index of each address.
+func (a *MasterAccount) accountAddresses(ctx context.Context) ([]common.Address, map[common.Address]int, error) {
+	accounts, err := a.accountDB.GetAllAccounts(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving accounts from DB: %w", err)
+	}
+
+	indexes := make(map[common.Address]int, len(accounts))
+	addresses := make([]common.Address, 0, len(accounts))
+	for index, account := range accounts {
+		address := common.HexToAddress(account)
+		indexes[address] = index
+		addresses = append(addresses, address)
+	}
+
+	return addresses, indexes, nil
+}
+
 func (a *MasterAccount) RetrieveBalance(accountIndex int) (string, error) {
 	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
 	defer cancel()
@@ -105,6 +191,36 @@ func (a *MasterAccount) RetrieveBalance(accountIndex int) (string, error) {
 	return balance, nil
 }
 
+// RetrieveBalances returns the balance of every account, fetched in a single batch.
+func (a *MasterAccount) RetrieveBalances() (map[int]string, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	accounts, err := a.accountDB.GetAllAccounts(cliCtx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving accounts from DB: %w", err)
+	}
+
+	indexes := make([]int, 0, len(accounts))
+	addresses := make([]string, 0, len(accounts))
+	for idx, address := range accounts {
+		indexes = append(indexes, idx)
+		addresses = append(addresses, address)
+	}
+
+	balances, err := a.client.GetBalances(cliCtx, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving balances: %w", err)
+	}
+
+	result := make(map[int]string, len(balances))
+	for i, balance := range balances {
+		result[indexes[i]] = balance
+	}
+
+	return result, nil
+}
+
 func (a *MasterAccount) EstimateGas(to, value string, accountIndex int) (string, error) {
 	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
 	defer cancel()
@@ -132,9 +248,62 @@ func (a *MasterAccount) EstimateGas(to, value string, accountIndex int) (string,
 	return totalCostEther, nil
 }
 
+// ReviewTransaction runs the checks of a transfer of value ether to to before it is sent,
+// and warns about transfers to the accounts of the wallet.
+func (a *MasterAccount) ReviewTransaction(to, value string, accountIndex int) (*TransactionReview, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	weiValue, err := EtherToWei(value)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ether transaction value: %w", err)
+	}
+
+	from, err := a.accountDB.GetAccountAddress(cliCtx, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account address from DB: %w", err)
+	}
+
+	if !utils.ValidateAddress(to, a.tokenName) {
+		review := &TransactionReview{From: from, To: to, Value: Ether(weiValue).String()}
+		review.addError(IssueInvalidAddress, fmt.Sprintf("%s is not a valid %s address.", to, a.tokenName))
+		return review, nil
+	}
+
+	toAddress := common.HexToAddress(to)
+	review, err := a.client.ReviewTransaction(cliCtx, &TransactionRequest{
+		From:  common.HexToAddress(from),
+		To:    &toAddress,
+		Value: (*hexutil.Big)(weiValue),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing transaction: %w", err)
+	}
+
+	accounts, err := a.accountDB.GetAllAccounts(cliCtx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving accounts from DB: %w", err)
+	}
+
+	for idx, address := range accounts {
+		switch {
+		case !strings.EqualFold(address, to):
+		case idx == accountIndex:
+			review.addWarning(IssueOwnAccount, "The recipient is the sending account.")
+		default:
+			review.addWarning(IssueOwnAccount, fmt.Sprintf("The recipient is account %d of this wallet.", idx))
+		}
+	}
+
+	return review, nil
+}
+
 func (a *MasterAccount) SendTransaction(to, value string, masterKey *bip32.Key, accountIndex int) (string, error) {
 	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
 	defer cancel()
+	if !utils.ValidateAddress(to, a.tokenName) {
+		return "", fmt.Errorf("invalid %s address: %s", a.tokenName, to)
+	}
+
 	weiValue, err := EtherToWei(value)
 	if err != nil {
 		return "", fmt.Errorf("error parsing ether value into wei: %w", err)
@@ -145,24 +314,487 @@ func (a *MasterAccount) SendTransaction(to, value string, masterKey *bip32.Key,
 		return "", fmt.Errorf("error retrieving account address from DB: %w", err)
 	}
 
-	ethKey, err := utils.DeriveKeyForAccount(masterKey, "ETH", accountIndex)
+	privateKey, err := derivePrivateKey(masterKey, accountIndex)
 	if err != nil {
-		return "", fmt.Errorf("error deriving eth key for index %d : %w", accountIndex, err)
+		return "", err
 	}
 
-	privateKey, err := crypto.ToECDSA(ethKey.Key)
-	if err != nil {
-		return "", fmt.Errorf("failed to convert eth master key to ECDSA: %w", err)
+	toAddress := common.HexToAddress(to)
+	req := &TransactionRequest{
+		From:  common.HexToAddress(from),
+		To:    &toAddress,
+		Value: (*hexutil.Big)(weiValue),
 	}
-
-	transactionHash, err := a.client.ProcessTransaction(cliCtx, from, to, weiValue, privateKey)
+	sentTx, err := a.sendRequest(cliCtx, req, privateKey)
 	if err != nil {
 		return "", fmt.Errorf("error procesing %s transaction %w", a.tokenName, err)
 	}
 
-	return transactionHash, nil
+	return sentTx.Hash, nil
 }
 
 func (a *MasterAccount) ChangeProvider(provider string) {
 	a.client.SetProvider(provider)
 }
+
+// SetProviders replaces the endpoints the account fails over between.
+func (a *MasterAccount) SetProviders(urls []string) error {
+	return a.client.SetProviders(urls)
+}
+
+func (a *MasterAccount) ProviderStatus() ([]ProviderStatus, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	return a.client.ProviderStatus(cliCtx)
+}
+
+// SubscribeNewHeads calls onHead with the number of every new block until ctx is done.
+func (a *MasterAccount) SubscribeNewHeads(ctx context.Context, onHead func(blockNumber uint64)) error {
+	err := a.client.SubscribeNewHeads(ctx, func(head Head) {
+		onHead(uint64(head.Number))
+	})
+	if err != nil {
+		return fmt.Errorf("error subscribing to new blocks: %w", err)
+	}
+
+	return nil
+}
+
+// SubscribeTokenTransfers calls onTransfer with every token transfer to the accounts
+// until ctx is done. Accounts added after the call are not followed.
+func (a *MasterAccount) SubscribeTokenTransfers(ctx context.Context, onTransfer func(TokenTransfer)) error {
+	dbCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	owners, _, err := a.accountAddresses(dbCtx)
+	if err != nil || len(owners) == 0 {
+		return err
+	}
+
+	// ERC-20 and ERC-721 transfers index the recipient as the second topic, and ERC-1155
+	// ones as the third, after the operator.
+	subscriptions := []struct {
+		topics  [][]common.Hash
+		toTopic int
+	}{
+		{topics: erc721Topics(owners), toTopic: 2},
+		{topics: erc1155Topics(owners), toTopic: 3},
+	}
+	for _, subscription := range subscriptions {
+		query := ethereum.FilterQuery{Topics: subscription.topics}
+		toTopic := subscription.toTopic
+		err = a.client.SubscribeLogs(ctx, query, func(log types.Log) {
+			if log.Removed || len(log.Topics) <= toTopic {
+				return
+			}
+
+			onTransfer(TokenTransfer{
+				Contract:        log.Address.Hex(),
+				To:              common.BytesToAddress(log.Topics[toTopic].Bytes()).Hex(),
+				TransactionHash: log.TxHash.Hex(),
+				BlockNumber:     log.BlockNumber,
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("error subscribing to token transfers: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (a *MasterAccount) BlockNumber() (uint64, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	return a.client.BlockNumber(cliCtx)
+}
+
+// TransactionReceipts returns the receipts of transactions, nil for the ones not mined yet.
+func (a *MasterAccount) TransactionReceipts(hashes []string) ([]*Receipt, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	return a.client.GetTransactionReceipts(cliCtx, hashes)
+}
+
+// SetBackend routes the account's node requests through backend instead of the provider URL.
+func (a *MasterAccount) SetBackend(backend Backend) {
+	a.client.SetBackend(backend)
+}
+
+func (a *MasterAccount) BuildTransaction(to, value string, accountIndex int) (string, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	weiValue, err := EtherToWei(value)
+	if err != nil {
+		return "", fmt.Errorf("error parsing ether value into wei: %w", err)
+	}
+
+	from, err := a.accountDB.GetAccountAddress(cliCtx, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving account address from DB: %w", err)
+	}
+
+	toAddress := common.HexToAddress(to)
+	unsignedTx, err := a.fillTransaction(cliCtx, &TransactionRequest{
+		From:  common.HexToAddress(from),
+		To:    &toAddress,
+		Value: (*hexutil.Big)(weiValue),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building %s transaction: %w", a.tokenName, err)
+	}
+
+	return EncodeUnsignedTransaction(unsignedTx)
+}
+
+func (a *MasterAccount) SignTransaction(payload string, masterKey *bip32.Key, accountIndex int) (string, error) {
+	unsignedTx, err := DecodeUnsignedTransaction(payload)
+	if err != nil {
+		return "", fmt.Errorf("error decoding unsigned %s transaction: %w", a.tokenName, err)
+	}
+
+	privateKey, err := derivePrivateKey(masterKey, accountIndex)
+	if err != nil {
+		return "", err
+	}
+
+	return SignUnsignedTransaction(unsignedTx, privateKey)
+}
+
+func (a *MasterAccount) BroadcastTransaction(rawTx string) (*SentTransaction, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	sentTx, err := a.nonces.Broadcast(cliCtx, rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("error broadcasting %s transaction: %w", a.tokenName, err)
+	}
+
+	return sentTx, nil
+}
+
+func (a *MasterAccount) FillTransaction(req *TransactionRequest) (string, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	unsignedTx, err := a.fillTransaction(cliCtx, req)
+	if err != nil {
+		return "", fmt.Errorf("error filling %s transaction: %w", a.tokenName, err)
+	}
+
+	return EncodeUnsignedTransaction(unsignedTx)
+}
+
+// SendTransactionRequest fills a transaction request, signs it with the account at
+// accountIndex and broadcasts it.
+func (a *MasterAccount) SendTransactionRequest(
+	req *TransactionRequest,
+	masterKey *bip32.Key,
+	accountIndex int,
+) (*SentTransaction, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	privateKey, err := derivePrivateKey(masterKey, accountIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	sentTx, err := a.sendRequest(cliCtx, req, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error sending %s transaction: %w", a.tokenName, err)
+	}
+
+	return sentTx, nil
+}
+
+// SpeedUpTransaction sends a pending transaction again with the same nonce and higher fees.
+func (a *MasterAccount) SpeedUpTransaction(
+	hash string,
+	masterKey *bip32.Key,
+	accountIndex int,
+) (*SentTransaction, error) {
+	return a.replaceTransaction(hash, false, masterKey, accountIndex)
+}
+
+// CancelTransaction replaces a pending transaction with an empty transfer to the sender.
+func (a *MasterAccount) CancelTransaction(
+	hash string,
+	masterKey *bip32.Key,
+	accountIndex int,
+) (*SentTransaction, error) {
+	return a.replaceTransaction(hash, true, masterKey, accountIndex)
+}
+
+func (a *MasterAccount) replaceTransaction(
+	hash string,
+	cancel bool,
+	masterKey *bip32.Key,
+	accountIndex int,
+) (*SentTransaction, error) {
+	cliCtx, cancelCtx := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancelCtx()
+	privateKey, err := derivePrivateKey(masterKey, accountIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	sentTx, err := a.nonces.Replace(cliCtx, hash, func(rawTx string) (string, error) {
+		unsignedTx, err := a.client.ReplacementTransaction(cliCtx, rawTx, cancel)
+		if err != nil {
+			return "", err
+		}
+
+		return SignUnsignedTransaction(unsignedTx, privateKey)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error replacing %s transaction %s: %w", a.tokenName, hash, err)
+	}
+
+	return sentTx, nil
+}
+
+// NonceGaps reports the accounts whose sent transactions the node does not know about.
+func (a *MasterAccount) NonceGaps() ([]NonceGap, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	return a.nonces.Gaps(cliCtx)
+}
+
+// ResetNonceGap forgets the lost transactions of address so the next one fills the gap.
+func (a *MasterAccount) ResetNonceGap(address string) error {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	return a.nonces.ResetGap(cliCtx, address)
+}
+
+// fillTransaction completes req, taking its nonce from the nonce manager when it has none.
+func (a *MasterAccount) fillTransaction(ctx context.Context, req *TransactionRequest) (*UnsignedTransaction, error) {
+	if req.Nonce != nil {
+		return a.client.FillTransaction(ctx, req)
+	}
+
+	nonce, err := a.nonces.NextNonce(ctx, req.From.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	filled := *req
+	filled.Nonce = (*hexutil.Uint64)(&nonce)
+	return a.client.FillTransaction(ctx, &filled)
+}
+
+// sendRequest fills req with the next nonce of its sender, unless it sets one, signs it
+// with privateKey and broadcasts it.
+func (a *MasterAccount) sendRequest(
+	ctx context.Context,
+	req *TransactionRequest,
+	privateKey *ecdsa.PrivateKey,
+) (*SentTransaction, error) {
+	return a.nonces.Send(ctx, req.From.Hex(), func(nonce uint64) (string, error) {
+		filled := *req
+		if filled.Nonce == nil {
+			filled.Nonce = (*hexutil.Uint64)(&nonce)
+		}
+
+		unsignedTx, err := a.client.FillTransaction(ctx, &filled)
+		if err != nil {
+			return "", fmt.Errorf("failed to build transaction: %w", err)
+		}
+
+		return SignUnsignedTransaction(unsignedTx, privateKey)
+	})
+}
+
+func (a *MasterAccount) SignMessage(message []byte, masterKey *bip32.Key, accountIndex int) (string, error) {
+	privateKey, err := derivePrivateKey(masterKey, accountIndex)
+	if err != nil {
+		return "", err
+	}
+
+	return SignPersonalMessage(message, privateKey)
+}
+
+func (a *MasterAccount) SignTypedData(typedDataJSON []byte, masterKey *bip32.Key, accountIndex int) (string, error) {
+	typedData, err := ParseTypedData(typedDataJSON)
+	if err != nil {
+		return "", err
+	}
+
+	privateKey, err := derivePrivateKey(masterKey, accountIndex)
+	if err != nil {
+		return "", err
+	}
+
+	return SignTypedData(typedData, privateKey)
+}
+
+func (a *MasterAccount) ReviewTypedData(typedDataJSON []byte) (*TypedDataReview, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	typedData, err := ParseTypedData(typedDataJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, err := a.client.GetChainID(cliCtx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving chain ID: %w", err)
+	}
+
+	return ReviewTypedData(typedData, chainID)
+}
+
+func (a *MasterAccount) RegisterContract(name, address string, abiJSON []byte) (*Contract, error) {
+	dbCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	contract, err := NewContract(name, address, abiJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.contractDB.SaveContract(dbCtx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+func (a *MasterAccount) GetContracts() ([]*Contract, error) {
+	dbCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	return a.contractDB.GetContracts(dbCtx)
+}
+
+func (a *MasterAccount) RemoveContract(name string) error {
+	dbCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	return a.contractDB.DeleteContract(dbCtx, name)
+}
+
+// CallContract runs a method through eth_call from the given account and decodes its outputs.
+func (a *MasterAccount) CallContract(
+	contractName, method string,
+	args []string,
+	accountIndex int,
+) ([]ContractParam, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	contract, err := a.contractDB.GetContract(cliCtx, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := contract.EncodeCall(method, args)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := a.accountDB.GetAccountAddress(cliCtx, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account address from DB: %w", err)
+	}
+
+	result, err := a.client.Call(cliCtx, from, contract.Address, data)
+	if err != nil {
+		return nil, fmt.Errorf("error calling %s.%s: %w", contract.Name, method, err)
+	}
+
+	return contract.DecodeResult(method, result)
+}
+
+// ContractTransaction builds the transaction request for a state-changing method call.
+// The value is in ether and must be empty or zero for non payable methods.
+func (a *MasterAccount) ContractTransaction(
+	contractName, method string,
+	args []string,
+	value string,
+	accountIndex int,
+) (*TransactionRequest, error) {
+	dbCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	contract, err := a.contractDB.GetContract(dbCtx, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	contractMethod, err := contract.Method(method)
+	if err != nil {
+		return nil, err
+	}
+
+	if contractMethod.ReadOnly {
+		return nil, fmt.Errorf("method %s is read-only and does not need a transaction", contractMethod.Signature)
+	}
+
+	weiValue := new(big.Int)
+	if value != "" {
+		weiValue, err = EtherToWei(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing ether value into wei: %w", err)
+		}
+	}
+
+	if weiValue.Sign() > 0 && !contractMethod.Payable {
+		return nil, fmt.Errorf("method %s is not payable", contractMethod.Signature)
+	}
+
+	data, err := contract.EncodeCall(method, args)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := a.accountDB.GetAccountAddress(dbCtx, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account address from DB: %w", err)
+	}
+
+	to := common.HexToAddress(contract.Address)
+	return &TransactionRequest{
+		From:  common.HexToAddress(from),
+		To:    &to,
+		Value: (*hexutil.Big)(weiValue),
+		Data:  data,
+	}, nil
+}
+
+// EstimateContractGas returns the cost in ether of a state-changing method call.
+// A call that would revert fails here with the revert reason reported by the node.
+func (a *MasterAccount) EstimateContractGas(
+	contractName, method string,
+	args []string,
+	value string,
+	accountIndex int,
+) (string, error) {
+	req, err := a.ContractTransaction(contractName, method, args, value, accountIndex)
+	if err != nil {
+		return "", err
+	}
+
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	unsignedTx, err := a.client.FillTransaction(cliCtx, req)
+	if err != nil {
+		return "", fmt.Errorf("error estimating gas: %w", err)
+	}
+
+	return CalculateTotalGasCostInEther(uint64(unsignedTx.GasLimit), unsignedTx.GasPrice.ToInt()), nil
+}
+
+func derivePrivateKey(masterKey *bip32.Key, accountIndex int) (*ecdsa.PrivateKey, error) {
+	ethKey, err := utils.DeriveKeyForAccount(masterKey, "ETH", accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving eth key for index %d : %w", accountIndex, err)
+	}
+
+	privateKey, err := crypto.ToECDSA(ethKey.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert eth master key to ECDSA: %w", err)
+	}
+
+	return privateKey, nil
+}
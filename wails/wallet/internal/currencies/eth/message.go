@@ -0,0 +1,56 @@
+package eth
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignPersonalMessage produces an EIP-191 (version 0x45) signature with the
+// recovery id shifted to 27/28, matching what personal_sign returns.
+func SignPersonalMessage(message []byte, privateKey *ecdsa.PrivateKey) (string, error) {
+	signature, err := crypto.Sign(accounts.TextHash(message), privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	signature[crypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(signature), nil
+}
+
+// RecoverPersonalMessageSigner returns the address that produced an EIP-191 signature.
+// Both the 27/28 and the 0/1 recovery id conventions are accepted.
+func RecoverPersonalMessageSigner(message []byte, signature string) (common.Address, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("invalid signature length: expected %d bytes, got %d", crypto.SignatureLength, len(sig))
+	}
+
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pubKey, err := crypto.SigToPub(accounts.TextHash(message), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover signer: %w", err)
+	}
+
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+func VerifyPersonalMessage(address string, message []byte, signature string) (bool, error) {
+	signerAddress, err := RecoverPersonalMessageSigner(message, signature)
+	if err != nil {
+		return false, err
+	}
+
+	return signerAddress == common.HexToAddress(address), nil
+}
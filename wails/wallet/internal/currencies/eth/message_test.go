@@ -0,0 +1,81 @@
+package eth_test
+
+import (
+	"testing"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestPersonalMessageSignature(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(hardhatKey0)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	address := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	message := []byte("I own this address")
+	signature, err := eth.SignPersonalMessage(message, privateKey)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+
+	lowRecoveryID := hexutil.MustDecode(signature)
+	lowRecoveryID[crypto.RecoveryIDOffset] -= 27
+
+	cases := []struct {
+		name      string
+		address   string
+		message   []byte
+		signature string
+		isValid   bool
+	}{
+		{
+			name:      "Signature from the signing account",
+			address:   address,
+			message:   message,
+			signature: signature,
+			isValid:   true,
+		},
+		{
+			name:      "Signature with a 0/1 recovery id",
+			address:   address,
+			message:   message,
+			signature: hexutil.Encode(lowRecoveryID),
+			isValid:   true,
+		},
+		{
+			name:      "Tampered message",
+			address:   address,
+			message:   []byte("I own this address!"),
+			signature: signature,
+			isValid:   false,
+		},
+		{
+			name:      "Different account",
+			address:   "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+			message:   message,
+			signature: signature,
+			isValid:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := eth.VerifyPersonalMessage(tc.address, tc.message, tc.signature)
+			if err != nil {
+				t.Fatalf("Failed to verify signature: %v", err)
+			}
+
+			assertCorrectValue(t, ok, tc.isValid)
+		})
+	}
+
+	t.Run("Malformed signature is rejected", func(t *testing.T) {
+		_, err := eth.VerifyPersonalMessage(address, message, "0x1234")
+		if err == nil {
+			t.Errorf("Expected an error verifying a short signature")
+		}
+	})
+}
@@ -0,0 +1,276 @@
+package eth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethmock"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	mockChainID = 1337
+	mockNonce   = 7
+	mockTxHash  = "0x5d4b1a3f2bfa4b1c6fd2c1e8c1d6b7a2a0a6f5b3c9e8d7f6a5b4c3d2e1f0a9b8"
+)
+
+func TestSendRequestToNodeErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		response ethmock.Response
+		timeout  time.Duration
+		want     *big.Int
+		wantErr  string
+	}{
+		{
+			name:     "Canned result",
+			response: ethmock.Response{Result: "0x3b9aca00"},
+			want:     big.NewInt(1000000000),
+		},
+		{
+			name:     "HTTP 500",
+			response: ethmock.Response{Status: http.StatusInternalServerError, Body: "upstream unavailable"},
+			wantErr:  "node returned HTTP 500: upstream unavailable",
+		},
+		{
+			name:     "Malformed JSON",
+			response: ethmock.Response{Body: `{"jsonrpc": "2.0", "result": "0x1`},
+			wantErr:  "failed to unmarshal response body",
+		},
+		{
+			name:     "JSON-RPC error object",
+			response: ethmock.Response{Error: &ethmock.Error{Code: -32005, Message: "rate limit exceeded"}},
+			wantErr:  "rpc error -32005: rate limit exceeded",
+		},
+		{
+			name:     "Missing result",
+			response: ethmock.Response{},
+			wantErr:  "unexpected result type",
+		},
+		{
+			name:     "Invalid quantity",
+			response: ethmock.Response{Result: "1000000000"},
+			wantErr:  "invalid gas price",
+		},
+		{
+			name:     "Slow node",
+			response: ethmock.Response{Result: "0x1", Delay: time.Second},
+			timeout:  50 * time.Millisecond,
+			wantErr:  context.DeadlineExceeded.Error(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := newMockNode(t)
+			node.On("eth_gasPrice", tc.response)
+
+			ctx := context.Background()
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			gasPrice, err := eth.NewClient(node.URL).GetGasPrice(ctx)
+			assertError(t, err, tc.wantErr)
+			if tc.wantErr == "" {
+				assertCorrectValue(t, gasPrice, tc.want)
+			}
+		})
+	}
+
+	t.Run("Error objects keep their code", func(t *testing.T) {
+		node := newMockNode(t)
+		node.OnError("eth_gasPrice", -32000, "header not found")
+
+		_, err := eth.NewClient(node.URL).GetGasPrice(context.Background())
+		var rpcErr *eth.RPCError
+		if !errors.As(err, &rpcErr) {
+			t.Fatalf("expected an RPCError, got %v", err)
+		}
+		assertCorrectValue(t, rpcErr.Code, -32000)
+	})
+}
+
+func TestGetNonce(t *testing.T) {
+	cases := []struct {
+		name     string
+		response ethmock.Response
+		want     uint64
+		wantErr  string
+	}{
+		{name: "Hex quantity", response: ethmock.Response{Result: "0x2a"}, want: 42},
+		{name: "Zero", response: ethmock.Response{Result: "0x0"}, want: 0},
+		{name: "Empty string", response: ethmock.Response{Result: ""}, wantErr: "invalid nonce"},
+		{name: "Missing prefix", response: ethmock.Response{Result: "2a"}, wantErr: "invalid nonce"},
+		{name: "Not hex", response: ethmock.Response{Result: "0xzz"}, wantErr: "invalid nonce"},
+		{name: "Overflow", response: ethmock.Response{Result: "0x10000000000000000"}, wantErr: "invalid nonce"},
+		{name: "Number instead of string", response: ethmock.Response{Result: 42}, wantErr: "unexpected result type"},
+		{
+			name:     "Unknown block",
+			response: ethmock.Response{Error: &ethmock.Error{Code: -32000, Message: "header not found"}},
+			wantErr:  "failed to get nonce: rpc error -32000: header not found",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := newMockNode(t)
+			node.On("eth_getTransactionCount", tc.response)
+
+			nonce, err := eth.NewClient(node.URL).GetNonce(context.Background(), hardhatAccount0)
+			assertError(t, err, tc.wantErr)
+			assertCorrectValue(t, nonce, tc.want)
+
+			requests := node.Requests("eth_getTransactionCount")
+			assertCorrectValue(t, len(requests), 1)
+			assertCorrectValue(t, string(requests[0].Params[0]), `"`+hardhatAccount0+`"`)
+		})
+	}
+}
+
+func TestProcessTransactionWithMisbehavingNode(t *testing.T) {
+	cases := []struct {
+		name string
+		// script overrides the responses of a well behaved node.
+		script        map[string]ethmock.Response
+		wantErr       string
+		wantBroadcast bool
+	}{
+		{
+			name:          "Consistent node",
+			wantBroadcast: true,
+		},
+		{
+			name:          "Chain ID mismatch",
+			script:        map[string]ethmock.Response{"eth_chainId": {Result: "0x1"}},
+			wantErr:       "invalid chain id for signer",
+			wantBroadcast: true,
+		},
+		{
+			name:          "Stale nonce",
+			script:        map[string]ethmock.Response{"eth_getTransactionCount": {Result: "0x5"}},
+			wantErr:       "nonce too low",
+			wantBroadcast: true,
+		},
+		{
+			name: "Nonce lookup fails",
+			script: map[string]ethmock.Response{
+				"eth_getTransactionCount": {Error: &ethmock.Error{Code: -32000, Message: "header not found"}},
+			},
+			wantErr: "failed to retrieve nonce: rpc error -32000: header not found",
+		},
+		{
+			name: "Lookup batch fails",
+			script: map[string]ethmock.Response{
+				"eth_getTransactionCount": {Status: http.StatusBadGateway, Body: "bad gateway"},
+			},
+			wantErr: "failed to retrieve transaction parameters",
+		},
+		{
+			name:    "Malformed chain ID",
+			script:  map[string]ethmock.Response{"eth_chainId": {Result: "1337"}},
+			wantErr: "failed to retrieve chain ID: invalid chain ID",
+		},
+		{
+			name:    "Malformed batch response",
+			script:  map[string]ethmock.Response{"eth_chainId": {Body: "not json"}},
+			wantErr: "failed to unmarshal batch response body",
+		},
+		{
+			name:          "Broadcast times out on the node",
+			script:        map[string]ethmock.Response{"eth_sendRawTransaction": {Status: http.StatusGatewayTimeout}},
+			wantErr:       "failed to broadcast transaction",
+			wantBroadcast: true,
+		},
+	}
+
+	privateKey, err := crypto.HexToECDSA(hardhatKey0)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := newConsistentMockNode(t)
+			for method, response := range tc.script {
+				node.On(method, response)
+			}
+
+			client := eth.NewClient(node.URL)
+			txHash, err := client.ProcessTransaction(
+				context.Background(), hardhatAccount0, hardhatAccount1, big.NewInt(1000), privateKey)
+			assertError(t, err, tc.wantErr)
+			if tc.wantErr == "" {
+				assertCorrectValue(t, txHash, mockTxHash)
+			}
+
+			broadcasts := node.Requests("eth_sendRawTransaction")
+			assertCorrectValue(t, len(broadcasts) == 1, tc.wantBroadcast)
+			// The nonce, gas price, gas limit and chain ID are looked up in a single batch.
+			assertCorrectValue(t, node.RoundTrips(), len(broadcasts)+1)
+		})
+	}
+}
+
+func newMockNode(t testing.TB) *ethmock.Server {
+	t.Helper()
+	node := ethmock.NewServer()
+	t.Cleanup(node.Close)
+
+	return node
+}
+
+// newConsistentMockNode answers the lookups made while building a transfer and only
+// accepts transactions signed for its chain with the next nonce of the sender, like a
+// real node would.
+func newConsistentMockNode(t testing.TB) *ethmock.Server {
+	t.Helper()
+	node := newMockNode(t)
+	node.OnResult("eth_chainId", hexutil.EncodeUint64(mockChainID))
+	node.OnResult("eth_getTransactionCount", hexutil.EncodeUint64(mockNonce))
+	node.OnResult("eth_gasPrice", "0x3b9aca00")
+	node.OnResult("eth_estimateGas", "0x5208")
+	node.Handle("eth_sendRawTransaction", func(req ethmock.Request) ethmock.Response {
+		var rawTx hexutil.Bytes
+		tx := new(types.Transaction)
+		if json.Unmarshal(req.Params[0], &rawTx) != nil || tx.UnmarshalBinary(rawTx) != nil {
+			return ethmock.Response{Error: &ethmock.Error{Code: -32602, Message: "invalid raw transaction"}}
+		}
+
+		switch {
+		case tx.ChainId().Int64() != mockChainID:
+			return ethmock.Response{Error: &ethmock.Error{Code: -32000, Message: "invalid chain id for signer"}}
+		case tx.Nonce() < mockNonce:
+			return ethmock.Response{Error: &ethmock.Error{Code: -32000, Message: "nonce too low"}}
+		case tx.Nonce() > mockNonce:
+			return ethmock.Response{Error: &ethmock.Error{Code: -32000, Message: "nonce too high"}}
+		}
+
+		return ethmock.Response{Result: mockTxHash}
+	})
+
+	return node
+}
+
+func assertError(t testing.TB, err error, want string) {
+	t.Helper()
+	switch {
+	case want == "" && err != nil:
+		t.Fatalf("unexpected error: %v", err)
+	case want != "" && err == nil:
+		t.Fatalf("expected an error containing %q", want)
+	case want != "" && !strings.Contains(err.Error(), want):
+		t.Fatalf("expected an error containing %q, got %v", want, err)
+	}
+}
@@ -0,0 +1,260 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// NonceGap reports transactions sent from an account that the node does not know about,
+// e.g. because they were dropped from its pool. They, and every later transaction of the
+// account, stay queued until a transaction with the nonce Next is mined.
+type NonceGap struct {
+	Address string `json:"address"`
+	// Next is the nonce the node expects for the account.
+	Next uint64 `json:"next"`
+	// Hashes are the transactions sent with Next or a later nonce, in nonce order.
+	Hashes []string `json:"hashes"`
+}
+
+// NonceManager hands out the nonces of the transactions the wallet sends and records
+// them, so transactions sent in a row, or at the same time, get consecutive nonces.
+type NonceManager struct {
+	client  *Client
+	storage *NonceStorage
+
+	mu sync.Mutex
+	// locks holds a semaphore per account, taken while a transaction is signed and sent.
+	locks map[common.Address]chan struct{}
+}
+
+func NewNonceManager(client *Client, storage *NonceStorage) *NonceManager {
+	return &NonceManager{
+		client:  client,
+		storage: storage,
+		locks:   make(map[common.Address]chan struct{}),
+	}
+}
+
+// Send calls sign with the next nonce of from and broadcasts the transaction it returns.
+// Sends from the same account are serialized. sign may use another nonce, e.g. to replace
+// a pending transaction; the nonce of the signed transaction is the one recorded.
+func (m *NonceManager) Send(
+	ctx context.Context,
+	from string,
+	sign func(nonce uint64) (string, error),
+) (*SentTransaction, error) {
+	unlock, err := m.lock(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	nonce, err := m.next(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	rawTx, err := sign(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	sentTx, err := DecodeSignedTransaction(rawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(sentTx.From, from) {
+		return nil, fmt.Errorf("transaction is signed by %s instead of %s", sentTx.From, from)
+	}
+
+	return m.broadcast(ctx, sentTx, rawTx)
+}
+
+// Broadcast sends a transaction signed elsewhere, e.g. on an offline machine, and records
+// its nonce.
+func (m *NonceManager) Broadcast(ctx context.Context, rawTx string) (*SentTransaction, error) {
+	sentTx, err := DecodeSignedTransaction(rawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := m.lock(ctx, sentTx.From)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return m.broadcast(ctx, sentTx, rawTx)
+}
+
+func (m *NonceManager) broadcast(ctx context.Context, sentTx *SentTransaction, rawTx string) (*SentTransaction, error) {
+	hash, err := m.client.BroadcastTransaction(ctx, rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	sentTx.Hash = hash
+	err = m.storage.SaveNonce(ctx, SentNonce{Address: sentTx.From, Nonce: sentTx.Nonce, Hash: hash, RawTx: rawTx})
+	if err != nil {
+		return sentTx, err
+	}
+
+	return sentTx, nil
+}
+
+// Replace sends the transaction built by replace with the nonce of the pending transaction
+// hash, given its raw transaction. The replacement takes the place of the original in the
+// recorded nonces.
+func (m *NonceManager) Replace(
+	ctx context.Context,
+	hash string,
+	replace func(rawTx string) (string, error),
+) (*SentTransaction, error) {
+	sent, err := m.storage.GetSentTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Send(ctx, sent.Address, func(uint64) (string, error) {
+		rawTx, err := replace(sent.RawTx)
+		if err != nil {
+			return "", err
+		}
+
+		replacement, err := DecodeSignedTransaction(rawTx)
+		if err != nil {
+			return "", err
+		}
+
+		if replacement.Nonce != sent.Nonce {
+			return "", fmt.Errorf("replacement nonce %d does not match nonce %d", replacement.Nonce, sent.Nonce)
+		}
+
+		return rawTx, nil
+	})
+}
+
+// NextNonce returns the nonce the next transaction of address would be sent with.
+func (m *NonceManager) NextNonce(ctx context.Context, address string) (uint64, error) {
+	unlock, err := m.lock(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	return m.next(ctx, address)
+}
+
+// Gaps compares the nonces the wallet sent with the pending nonce of every account, in a
+// single batch, and reports the accounts whose transactions the node lost.
+func (m *NonceManager) Gaps(ctx context.Context) ([]NonceGap, error) {
+	addresses, err := m.storage.GetAddresses(ctx)
+	if err != nil || len(addresses) == 0 {
+		return nil, err
+	}
+
+	pendingHex := make([]string, len(addresses))
+	batch := make([]BatchElem, len(addresses))
+	for i, address := range addresses {
+		batch[i] = BatchElem{
+			Method: "eth_getTransactionCount",
+			Params: []interface{}{address, "pending"},
+			Result: &pendingHex[i],
+		}
+	}
+
+	err = m.client.BatchCall(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve nonces: %w", err)
+	}
+
+	var gaps []NonceGap
+	for i, address := range addresses {
+		if batch[i].Error != nil {
+			return nil, fmt.Errorf("failed to retrieve nonce of %s: %w", address, batch[i].Error)
+		}
+
+		pending, err := hexutil.DecodeUint64(pendingHex[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce %q: %w", pendingHex[i], err)
+		}
+
+		sent, err := m.storage.GetNoncesFrom(ctx, address, pending)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(sent) == 0 {
+			continue
+		}
+
+		gap := NonceGap{Address: address, Next: pending}
+		for _, nonce := range sent {
+			gap.Hashes = append(gap.Hashes, nonce.Hash)
+		}
+		gaps = append(gaps, gap)
+	}
+
+	return gaps, nil
+}
+
+// ResetGap forgets the transactions of address that the node does not know about, so the
+// next one is sent with the pending nonce of the node and fills the gap. It is meant for
+// transactions that are lost for good, e.g. after a development chain was restarted.
+func (m *NonceManager) ResetGap(ctx context.Context, address string) error {
+	unlock, err := m.lock(ctx, address)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	pending, err := m.client.GetPendingNonce(ctx, address)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve nonce: %w", err)
+	}
+
+	return m.storage.DeleteNoncesFrom(ctx, address, pending)
+}
+
+// next is the greater of the pending nonce of the node and the nonce following the last
+// one the wallet sent. The latter is ahead when the node lags behind the one that accepted
+// the last transaction, or dropped it: a sent nonce is not reused until ResetGap, and
+// the gap is reported by Gaps instead.
+func (m *NonceManager) next(ctx context.Context, address string) (uint64, error) {
+	pending, err := m.client.GetPendingNonce(ctx, address)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve nonce: %w", err)
+	}
+
+	local, err := m.storage.NextNonce(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return max(pending, local), nil
+}
+
+// lock waits until no other transaction of address is being signed or sent, or ctx is done.
+func (m *NonceManager) lock(ctx context.Context, address string) (func(), error) {
+	key := common.HexToAddress(address)
+	m.mu.Lock()
+	sem, ok := m.locks[key]
+	if !ok {
+		sem = make(chan struct{}, 1)
+		m.locks[key] = sem
+	}
+	m.mu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for pending send from %s: %w", address, ctx.Err())
+	}
+}
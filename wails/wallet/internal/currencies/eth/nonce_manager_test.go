@@ -0,0 +1,156 @@
+package eth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"database/sql"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	_ "modernc.org/sqlite"
+)
+
+func TestNonceManagerSendsConcurrentTransactions(t *testing.T) {
+	backend := newSimulatedBackend(t, map[string]int64{hardhatAccount0: 10})
+	client := eth.NewClientWithBackend(backend)
+	manager, _ := newNonceManager(t, client)
+	privateKey, err := crypto.HexToECDSA(hardhatKey0)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	// Every transaction is sent before a block is mined, so the nonces only come from
+	// the node's pool and the manager.
+	sent := make([]*eth.SentTransaction, 5)
+	errs := make([]error, len(sent))
+	var wg sync.WaitGroup
+	for i := range sent {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sign := signTransfer(client, privateKey, big.NewInt(int64(i+1)))
+			sent[i], errs[i] = manager.Send(context.Background(), hardhatAccount0, sign)
+		}()
+	}
+	wg.Wait()
+
+	hashes := make([]string, len(sent))
+	nonces := make([]uint64, len(sent))
+	for i, sentTx := range sent {
+		if errs[i] != nil {
+			t.Fatalf("Failed to send transaction %d: %v", i, errs[i])
+		}
+		hashes[i] = sentTx.Hash
+		nonces[i] = sentTx.Nonce
+	}
+
+	slices.Sort(nonces)
+	assertCorrectValue(t, nonces, []uint64{0, 1, 2, 3, 4})
+
+	backend.Commit()
+	receipts, err := client.GetTransactionReceipts(context.Background(), hashes)
+	if err != nil {
+		t.Fatalf("Failed to get receipts: %v", err)
+	}
+
+	for i, receipt := range receipts {
+		if receipt == nil || !receipt.Succeeded() {
+			t.Errorf("Transaction %s was not mined: %+v", hashes[i], receipt)
+		}
+	}
+}
+
+func TestNonceManagerGaps(t *testing.T) {
+	// The node lost the transactions the wallet sent with nonces 7 and 8.
+	node := newMockNode(t)
+	node.OnResult("eth_getTransactionCount", hexutil.EncodeUint64(mockNonce))
+	manager, storage := newNonceManager(t, eth.NewClient(node.URL))
+	ctx := context.Background()
+	for _, nonce := range []uint64{mockNonce - 1, mockNonce, mockNonce + 1} {
+		sent := eth.SentNonce{Address: hardhatAccount0, Nonce: nonce, Hash: nonceHash(nonce)}
+		err := storage.SaveNonce(ctx, sent)
+		if err != nil {
+			t.Fatalf("Failed to save nonce: %v", err)
+		}
+	}
+
+	nonce, err := manager.NextNonce(ctx, hardhatAccount0)
+	if err != nil {
+		t.Fatalf("Failed to get next nonce: %v", err)
+	}
+	assertCorrectValue(t, nonce, uint64(mockNonce+2))
+	assertCorrectValue(t, string(node.Requests("eth_getTransactionCount")[0].Params[1]), `"pending"`)
+
+	gaps, err := manager.Gaps(ctx)
+	if err != nil {
+		t.Fatalf("Failed to check gaps: %v", err)
+	}
+	assertCorrectValue(t, gaps, []eth.NonceGap{{
+		Address: hardhatAccount0,
+		Next:    mockNonce,
+		Hashes:  []string{nonceHash(mockNonce), nonceHash(mockNonce + 1)},
+	}})
+
+	err = manager.ResetGap(ctx, hardhatAccount0)
+	if err != nil {
+		t.Fatalf("Failed to reset gap: %v", err)
+	}
+
+	nonce, err = manager.NextNonce(ctx, hardhatAccount0)
+	if err != nil {
+		t.Fatalf("Failed to get next nonce: %v", err)
+	}
+	assertCorrectValue(t, nonce, uint64(mockNonce))
+
+	gaps, err = manager.Gaps(ctx)
+	if err != nil {
+		t.Fatalf("Failed to check gaps: %v", err)
+	}
+	assertCorrectValue(t, len(gaps), 0)
+}
+
+func newNonceManager(t testing.TB, client *eth.Client) (*eth.NonceManager, *eth.NonceStorage) {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	// Every connection to :memory: opens a new database.
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	storage, err := eth.NewNonceStorage(context.Background(), db)
+	if err != nil {
+		t.Fatalf("Failed to create nonce storage: %v", err)
+	}
+
+	return eth.NewNonceManager(client, storage), storage
+}
+
+// signTransfer signs a transfer of value wei to hardhatAccount1 with the nonce it is given.
+func signTransfer(client *eth.Client, privateKey *ecdsa.PrivateKey, value *big.Int) func(uint64) (string, error) {
+	return func(nonce uint64) (string, error) {
+		to := common.HexToAddress(hardhatAccount1)
+		unsignedTx, err := client.FillTransaction(context.Background(), &eth.TransactionRequest{
+			From:  common.HexToAddress(hardhatAccount0),
+			To:    &to,
+			Value: (*hexutil.Big)(value),
+			Nonce: (*hexutil.Uint64)(&nonce),
+		})
+		if err != nil {
+			return "", err
+		}
+
+		return eth.SignUnsignedTransaction(unsignedTx, privateKey)
+	}
+}
+
+func nonceHash(nonce uint64) string {
+	return common.BigToHash(new(big.Int).SetUint64(nonce)).Hex()
+}
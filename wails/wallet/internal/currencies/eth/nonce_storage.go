@@ -0,0 +1,174 @@
+package eth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SentNonce is a nonce the wallet used for a transaction it broadcast.
+type SentNonce struct {
+	Address string
+	Nonce   uint64
+	Hash    string
+	RawTx   string
+}
+
+type NonceStorage struct {
+	db *sql.DB
+}
+
+func NewNonceStorage(ctx context.Context, db *sql.DB) (*NonceStorage, error) {
+	err := db.Ping()
+	if err != nil {
+		return nil, fmt.Errorf("error pinging database: %w", err)
+	}
+
+	_, err = db.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS ethNonces (
+			address TEXT,
+			nonce INTEGER,
+			hash TEXT,
+			rawTx TEXT,
+			PRIMARY KEY (address, nonce)
+		)`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating nonces table: %w", err)
+	}
+
+	return &NonceStorage{db: db}, nil
+}
+
+// SaveNonce records the transaction sent with a nonce, replacing the one previously
+// sent with the same nonce.
+func (n *NonceStorage) SaveNonce(ctx context.Context, sent SentNonce) error {
+	_, err := n.db.ExecContext(
+		ctx,
+		`INSERT INTO ethNonces (address, nonce, hash, rawTx) VALUES (?, ?, ?, ?)
+		ON CONFLICT(address, nonce) DO UPDATE SET hash = excluded.hash, rawTx = excluded.rawTx`,
+		common.HexToAddress(sent.Address).Hex(), sent.Nonce, sent.Hash, sent.RawTx,
+	)
+	if err != nil {
+		return fmt.Errorf("error saving nonce %d of %s: %w", sent.Nonce, sent.Address, err)
+	}
+
+	return nil
+}
+
+// NextNonce returns the nonce following the last one sent from address, or 0 when the
+// wallet never sent from it.
+func (n *NonceStorage) NextNonce(ctx context.Context, address string) (uint64, error) {
+	var last sql.NullInt64
+	err := n.db.QueryRowContext(
+		ctx,
+		"SELECT MAX(nonce) FROM ethNonces WHERE address = ?",
+		common.HexToAddress(address).Hex(),
+	).Scan(&last)
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving last nonce of %s: %w", address, err)
+	}
+
+	if !last.Valid {
+		return 0, nil
+	}
+
+	return uint64(last.Int64) + 1, nil
+}
+
+// GetSentTransaction finds a transaction the wallet sent by its hash.
+func (n *NonceStorage) GetSentTransaction(ctx context.Context, hash string) (*SentNonce, error) {
+	var sent SentNonce
+	err := n.db.QueryRowContext(
+		ctx,
+		"SELECT address, nonce, hash, rawTx FROM ethNonces WHERE lower(hash) = lower(?)",
+		hash,
+	).Scan(&sent.Address, &sent.Nonce, &sent.Hash, &sent.RawTx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("transaction %s was not sent by this wallet", hash)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving transaction %s from DB: %w", hash, err)
+	}
+
+	return &sent, nil
+}
+
+// GetNoncesFrom returns the nonces sent from address that are equal to or greater than
+// nonce, in ascending order.
+func (n *NonceStorage) GetNoncesFrom(ctx context.Context, address string, nonce uint64) ([]SentNonce, error) {
+	rows, err := n.db.QueryContext(
+		ctx,
+		"SELECT address, nonce, hash, rawTx FROM ethNonces WHERE address = ? AND nonce >= ? ORDER BY nonce",
+		common.HexToAddress(address).Hex(), nonce,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying nonces of %s: %w", address, err)
+	}
+
+	defer rows.Close()
+	var nonces []SentNonce
+	for rows.Next() {
+		var sent SentNonce
+		err = rows.Scan(&sent.Address, &sent.Nonce, &sent.Hash, &sent.RawTx)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+
+		nonces = append(nonces, sent)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving nonce rows from db: %w", err)
+	}
+
+	return nonces, nil
+}
+
+// DeleteNoncesFrom forgets the nonces sent from address that are equal to or greater
+// than nonce.
+func (n *NonceStorage) DeleteNoncesFrom(ctx context.Context, address string, nonce uint64) error {
+	_, err := n.db.ExecContext(
+		ctx,
+		"DELETE FROM ethNonces WHERE address = ? AND nonce >= ?",
+		common.HexToAddress(address).Hex(), nonce,
+	)
+	if err != nil {
+		return fmt.Errorf("error deleting nonces of %s: %w", address, err)
+	}
+
+	return nil
+}
+
+// GetAddresses returns the addresses the wallet sent transactions from.
+func (n *NonceStorage) GetAddresses(ctx context.Context) ([]string, error) {
+	rows, err := n.db.QueryContext(ctx, "SELECT DISTINCT address FROM ethNonces ORDER BY address")
+	if err != nil {
+		return nil, fmt.Errorf("error querying nonce addresses: %w", err)
+	}
+
+	defer rows.Close()
+	var addresses []string
+	for rows.Next() {
+		var address string
+		err = rows.Scan(&address)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving nonce rows from db: %w", err)
+	}
+
+	return addresses, nil
+}
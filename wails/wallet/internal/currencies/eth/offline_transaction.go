@@ -0,0 +1,188 @@
+package eth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// UnsignedTransaction holds everything an offline machine needs to sign a transaction
+// without talking to a node. It is serialized as JSON so it can be moved around as a file
+// or embedded into a QR code. To is nil when the transaction deploys a contract.
+type UnsignedTransaction struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Value    *hexutil.Big    `json:"value"`
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	GasLimit hexutil.Uint64  `json:"gasLimit"`
+	ChainID  *hexutil.Big    `json:"chainId"`
+	Data     hexutil.Bytes   `json:"data,omitempty"`
+}
+
+// String shows what the transaction does and what it costs at most, for the user to check
+// on the offline machine before signing it.
+func (tx *UnsignedTransaction) String() string {
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "from: %s", tx.From.Hex())
+	to := "contract creation"
+	if tx.To != nil {
+		to = tx.To.Hex()
+	}
+	fmt.Fprintf(&summary, "\nto: %s", to)
+	fmt.Fprintf(&summary, "\nvalue: %s ether", Ether(tx.Value.ToInt()).String())
+	fmt.Fprintf(&summary, "\nnonce: %d", uint64(tx.Nonce))
+	fmt.Fprintf(&summary, "\ngas limit: %d", uint64(tx.GasLimit))
+	fmt.Fprintf(&summary, "\ngas price: %s gwei", NewAmount(tx.GasPrice.ToInt(), GweiDecimals).String())
+	maxFee := new(big.Int).Mul(tx.GasPrice.ToInt(), new(big.Int).SetUint64(uint64(tx.GasLimit)))
+	fmt.Fprintf(&summary, "\nmax fee: %s ether", Ether(maxFee).String())
+	fmt.Fprintf(&summary, "\nchain ID: %s", tx.ChainID.ToInt().String())
+	if len(tx.Data) > 0 {
+		fmt.Fprintf(&summary, "\ndata: %s", tx.Data.String())
+	}
+
+	return summary.String()
+}
+
+// SentTransaction describes a signed transaction that was broadcast to the network.
+type SentTransaction struct {
+	Hash  string
+	From  string
+	To    string
+	Value string
+	Nonce uint64
+}
+
+func (c *Client) BuildTransaction(ctx context.Context, from, to string, value *big.Int) (*UnsignedTransaction, error) {
+	toAddress := common.HexToAddress(to)
+	return c.FillTransaction(ctx, &TransactionRequest{
+		From:  common.HexToAddress(from),
+		To:    &toAddress,
+		Value: (*hexutil.Big)(value),
+	})
+}
+
+func (c *Client) BroadcastTransaction(ctx context.Context, rawTxHex string) (string, error) {
+	rawTxHex = normalizeRawTransaction(rawTxHex)
+
+	payload := RPCPayload{
+		Jsonrpc: "2.0",
+		Method:  "eth_sendRawTransaction",
+		Params:  []interface{}{rawTxHex},
+		ID:      uuid.New().String(),
+	}
+
+	response, err := c.sendRequestToNode(ctx, payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to send transaction: %w", err)
+	}
+	txHash, ok := response["result"].(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected result type: expected string")
+	}
+
+	return txHash, nil
+}
+
+func EncodeUnsignedTransaction(tx *UnsignedTransaction) (string, error) {
+	data, err := json.Marshal(tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal unsigned transaction: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func DecodeUnsignedTransaction(payload string) (*UnsignedTransaction, error) {
+	var tx UnsignedTransaction
+	err := json.Unmarshal([]byte(strings.TrimSpace(payload)), &tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal unsigned transaction: %w", err)
+	}
+
+	if tx.Value == nil || tx.GasPrice == nil || tx.ChainID == nil {
+		return nil, fmt.Errorf("unsigned transaction is missing value, gas price or chain ID")
+	}
+
+	return &tx, nil
+}
+
+// SignUnsignedTransaction signs the transaction with the given key and returns the
+// 0x-prefixed raw transaction ready to be broadcast. It never touches the network.
+func SignUnsignedTransaction(tx *UnsignedTransaction, privateKey *ecdsa.PrivateKey) (string, error) {
+	signer := crypto.PubkeyToAddress(privateKey.PublicKey)
+	if signer != tx.From {
+		return "", fmt.Errorf("transaction sender %s does not match signing account %s", tx.From.Hex(), signer.Hex())
+	}
+
+	legacyTx := types.NewTx(&types.LegacyTx{
+		Nonce:    uint64(tx.Nonce),
+		To:       tx.To,
+		Value:    tx.Value.ToInt(),
+		Gas:      uint64(tx.GasLimit),
+		GasPrice: tx.GasPrice.ToInt(),
+		Data:     tx.Data,
+	})
+	signedTx, err := types.SignTx(legacyTx, types.NewEIP155Signer(tx.ChainID.ToInt()), privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	rawTxBytes, err := signedTx.MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode signed transaction: %w", err)
+	}
+
+	return hexutil.Encode(rawTxBytes), nil
+}
+
+// DecodeSignedTransaction recovers the sender, recipient and value of a raw signed transaction.
+// The recipient of a contract creation is the address the contract is deployed at.
+func DecodeSignedTransaction(rawTxHex string) (*SentTransaction, error) {
+	rawTxBytes, err := hexutil.Decode(normalizeRawTransaction(rawTxHex))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode raw transaction hex: %w", err)
+	}
+
+	var tx types.Transaction
+	err = tx.UnmarshalBinary(rawTxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode raw transaction: %w", err)
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), &tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover transaction sender: %w", err)
+	}
+
+	to := crypto.CreateAddress(from, tx.Nonce())
+	if tx.To() != nil {
+		to = *tx.To()
+	}
+
+	return &SentTransaction{
+		Hash:  tx.Hash().Hex(),
+		From:  from.Hex(),
+		To:    to.Hex(),
+		Value: Ether(tx.Value()).String(),
+		Nonce: tx.Nonce(),
+	}, nil
+}
+
+func normalizeRawTransaction(rawTxHex string) string {
+	rawTxHex = strings.TrimSpace(rawTxHex)
+	if !strings.HasPrefix(rawTxHex, "0x") {
+		rawTxHex = "0x" + rawTxHex
+	}
+
+	return rawTxHex
+}
@@ -0,0 +1,103 @@
+package eth_test
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Private keys of the first two accounts of the default hardhat node.
+const (
+	hardhatKey0 = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	hardhatKey1 = "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
+)
+
+func TestOfflineSigning(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(hardhatKey0)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	unsignedTx := &eth.UnsignedTransaction{
+		From:     common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+		To:       &to,
+		Value:    (*hexutil.Big)(big.NewInt(1_000_000_000_000_000_000)),
+		Nonce:    3,
+		GasPrice: (*hexutil.Big)(big.NewInt(1_875_000_000)),
+		GasLimit: 21000,
+		ChainID:  (*hexutil.Big)(big.NewInt(31337)),
+	}
+
+	t.Run("Unsigned transaction survives encoding", func(t *testing.T) {
+		payload, err := eth.EncodeUnsignedTransaction(unsignedTx)
+		if err != nil {
+			t.Fatalf("Failed to encode unsigned transaction: %v", err)
+		}
+
+		decoded, err := eth.DecodeUnsignedTransaction(payload)
+		if err != nil {
+			t.Fatalf("Failed to decode unsigned transaction: %v", err)
+		}
+
+		assertCorrectValue(t, decoded, unsignedTx)
+	})
+
+	t.Run("Unsigned transaction is summarized for review", func(t *testing.T) {
+		assertCorrectValue(t, unsignedTx.String(), `from: 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266
+to: 0x70997970C51812dc3A010C7d01b50e0d17dc79C8
+value: 1 ether
+nonce: 3
+gas limit: 21000
+gas price: 1.875 gwei
+max fee: 0.000039375 ether
+chain ID: 31337`)
+	})
+
+	t.Run("Signed transaction recovers sender and recipient", func(t *testing.T) {
+		rawTx, err := eth.SignUnsignedTransaction(unsignedTx, privateKey)
+		if err != nil {
+			t.Fatalf("Failed to sign transaction: %v", err)
+		}
+
+		sentTx, err := eth.DecodeSignedTransaction(rawTx)
+		if err != nil {
+			t.Fatalf("Failed to decode signed transaction: %v", err)
+		}
+
+		assertCorrectValue(t, sentTx.From, unsignedTx.From.Hex())
+		assertCorrectValue(t, sentTx.To, unsignedTx.To.Hex())
+		assertCorrectValue(t, sentTx.Value, "1")
+	})
+
+	t.Run("Signing with a different account fails", func(t *testing.T) {
+		otherKey, err := crypto.HexToECDSA(hardhatKey1)
+		if err != nil {
+			t.Fatalf("Failed to parse private key: %v", err)
+		}
+
+		_, err = eth.SignUnsignedTransaction(unsignedTx, otherKey)
+		if err == nil {
+			t.Errorf("Expected an error when signing with a key that does not own the sender address")
+		}
+	})
+
+	t.Run("Incomplete payload is rejected", func(t *testing.T) {
+		_, err := eth.DecodeUnsignedTransaction(`{"from":"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"}`)
+		if err == nil {
+			t.Errorf("Expected an error decoding an incomplete unsigned transaction")
+		}
+	})
+}
+
+func assertCorrectValue[T any](t testing.TB, got, want T) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,353 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"wallet/internal/contracts"
+	"wallet/internal/utils"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// PaymentURIScheme is the scheme of EIP-681 payment request URIs.
+const PaymentURIScheme = "ethereum"
+
+// TransferFunction is the only contract function payment requests can call, the ERC-20
+// transfer(address,uint256).
+const TransferFunction = "transfer"
+
+// PaymentRequest is a request for a payment, shared as an EIP-681 URI such as
+// ethereum:0x...@1337?value=1e18. Amounts are decimal integers in the smallest unit.
+type PaymentRequest struct {
+	// To is the recipient of ether, or the contract of a token transfer. It is either
+	// an address or an ENS name.
+	To      string `json:"to"`
+	ChainID uint64 `json:"chainId,omitempty"`
+	// Value is the number of wei to send.
+	Value string `json:"value,omitempty"`
+	// Function is set to TransferFunction for ERC-20 transfers, which send Units of the
+	// token at To to Recipient.
+	Function  string `json:"function,omitempty"`
+	Recipient string `json:"recipient,omitempty"`
+	Units     string `json:"units,omitempty"`
+	GasLimit  uint64 `json:"gasLimit,omitempty"`
+	GasPrice  string `json:"gasPrice,omitempty"`
+	// Amount and Symbol describe the payment in the unit of the token, e.g. 1.5 ETH. They
+	// are filled in by the wallet and are not part of the URI.
+	Amount string `json:"amount,omitempty"`
+	Symbol string `json:"symbol,omitempty"`
+}
+
+// IsTokenTransfer tells whether the request is for an ERC-20 transfer rather than ether.
+func (r *PaymentRequest) IsTokenTransfer() bool {
+	return r.Function == TransferFunction
+}
+
+// Payee returns the address that gets paid: the recipient of the tokens, or To for ether.
+func (r *PaymentRequest) Payee() string {
+	if r.IsTokenTransfer() {
+		return r.Recipient
+	}
+
+	return r.To
+}
+
+// URI encodes the request as an EIP-681 URI.
+func (r *PaymentRequest) URI() string {
+	var uri strings.Builder
+	uri.WriteString(PaymentURIScheme + ":" + r.To)
+	if r.ChainID != 0 {
+		uri.WriteString("@" + strconv.FormatUint(r.ChainID, 10))
+	}
+
+	params := url.Values{}
+	if r.IsTokenTransfer() {
+		uri.WriteString("/" + r.Function)
+		params.Set("address", r.Recipient)
+		params.Set("uint256", r.Units)
+	} else if r.Value != "" {
+		params.Set("value", r.Value)
+	}
+
+	if r.GasLimit != 0 {
+		params.Set("gasLimit", strconv.FormatUint(r.GasLimit, 10))
+	}
+
+	if r.GasPrice != "" {
+		params.Set("gasPrice", r.GasPrice)
+	}
+
+	if len(params) > 0 {
+		uri.WriteString("?" + params.Encode())
+	}
+
+	return uri.String()
+}
+
+// ParsePaymentURI parses an EIP-681 URI requesting ether or an ERC-20 transfer. Numbers
+// may use an exponent, as in value=2.014e18, but have to be whole numbers of the
+// smallest unit.
+func ParsePaymentURI(uri string) (*PaymentRequest, error) {
+	uri = strings.TrimSpace(uri)
+	scheme, rest, ok := strings.Cut(uri, ":")
+	if !ok || !strings.EqualFold(scheme, PaymentURIScheme) {
+		return nil, fmt.Errorf("not an %s payment URI: %q", PaymentURIScheme, uri)
+	}
+
+	path, query, _ := strings.Cut(rest, "?")
+	path, function, _ := strings.Cut(path, "/")
+	target, chainID, hasChainID := strings.Cut(strings.TrimPrefix(path, "pay-"), "@")
+
+	request := &PaymentRequest{To: target, Function: function}
+	if !utils.ValidateETHAddress(target) && !IsENSName(target) {
+		return nil, fmt.Errorf("invalid payment recipient %q", target)
+	}
+
+	if hasChainID {
+		id, err := strconv.ParseUint(chainID, 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("invalid chain ID %q", chainID)
+		}
+		request.ChainID = id
+	}
+
+	if function != "" && function != TransferFunction {
+		return nil, fmt.Errorf("unsupported payment function %q", function)
+	}
+
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment parameters: %w", err)
+	}
+
+	numbers := map[string]*string{"value": &request.Value, "gasPrice": &request.GasPrice}
+	if request.IsTokenTransfer() {
+		numbers["uint256"] = &request.Units
+	}
+
+	for name, field := range numbers {
+		if params.Has(name) {
+			number, err := parsePaymentNumber(params.Get(name))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*field = number.String()
+		}
+	}
+
+	// gas is the name EIP-681 gives the gas limit, gasLimit is the one wallets use.
+	for _, name := range []string{"gas", "gasLimit"} {
+		if params.Has(name) {
+			gasLimit, err := parsePaymentNumber(params.Get(name))
+			if err != nil || !gasLimit.IsUint64() {
+				return nil, fmt.Errorf("invalid %s %q", name, params.Get(name))
+			}
+			request.GasLimit = gasLimit.Uint64()
+		}
+	}
+
+	if request.IsTokenTransfer() {
+		request.Recipient = params.Get("address")
+		if !utils.ValidateETHAddress(request.Recipient) {
+			return nil, fmt.Errorf("invalid token recipient %q", request.Recipient)
+		}
+
+		if request.Units == "" {
+			return nil, fmt.Errorf("the token transfer has no uint256 amount")
+		}
+	}
+
+	return request, nil
+}
+
+// parsePaymentNumber parses an EIP-681 number, a decimal with an optional exponent that
+// has to make it a whole number.
+func parsePaymentNumber(s string) (*big.Int, error) {
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(s), "e")
+	decimals := 0
+	if hasExponent {
+		var err error
+		decimals, err = strconv.Atoi(exponent)
+		// A uint256 has at most 78 digits.
+		if err != nil || decimals < 0 || decimals > 78 {
+			return nil, fmt.Errorf("invalid exponent in %q", s)
+		}
+	}
+
+	amount, err := ParseAmount(mantissa, uint8(decimals))
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a whole number: %w", s, err)
+	}
+
+	return amount.Units(), nil
+}
+
+// TransactionRequest builds the transaction paying the request from an account. The
+// request must have been resolved to addresses, see MasterAccount.ReadPaymentRequest.
+// The gas limit and gas price of the URI are left out: whoever wrote the request could
+// set them to drain the payer in fees, so they are estimated like for any transfer.
+func (r *PaymentRequest) TransactionRequest(from common.Address) (*TransactionRequest, error) {
+	if !common.IsHexAddress(r.To) {
+		return nil, fmt.Errorf("unresolved payment recipient %s", r.To)
+	}
+
+	to := common.HexToAddress(r.To)
+	req := &TransactionRequest{From: from, To: &to, Value: (*hexutil.Big)(new(big.Int))}
+
+	if !r.IsTokenTransfer() {
+		value, ok := new(big.Int).SetString(r.Value, 10)
+		if !ok {
+			return nil, fmt.Errorf("the payment request has no value")
+		}
+		req.Value = (*hexutil.Big)(value)
+		return req, nil
+	}
+
+	units, ok := new(big.Int).SetString(r.Units, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid token amount %q", r.Units)
+	}
+
+	tokenABI, err := contracts.DemoTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ERC-20 ABI: %w", err)
+	}
+
+	req.Data, err = tokenABI.Pack(TransferFunction, common.HexToAddress(r.Recipient), units)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding token transfer: %w", err)
+	}
+
+	return req, nil
+}
+
+// ReceiveRequest creates a request for a payment to an account, on the chain the account
+// is connected to. The amount, in ether, is optional.
+func (a *MasterAccount) ReceiveRequest(accountIndex int, amount string) (*PaymentRequest, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	address, err := a.accountDB.GetAccountAddress(cliCtx, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account address from DB: %w", err)
+	}
+
+	chainID, err := a.client.GetChainID(cliCtx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving chain ID: %w", err)
+	}
+
+	request := &PaymentRequest{To: address, ChainID: uint64(chainID), Symbol: a.tokenName}
+	if amount != "" {
+		value, err := ParseEther(amount)
+		if err != nil {
+			return nil, err
+		}
+		request.Value = value.Units().String()
+		request.Amount = value.String()
+	}
+
+	return request, nil
+}
+
+// ReadPaymentRequest parses a payment URI for the chain the account is connected to. ENS
+// names are resolved, and the amount is described in the unit of the token, looking up
+// the decimals and symbol of ERC-20 tokens.
+func (a *MasterAccount) ReadPaymentRequest(uri string) (*PaymentRequest, error) {
+	request, err := ParsePaymentURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	chainID, err := a.client.GetChainID(cliCtx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving chain ID: %w", err)
+	}
+
+	if request.ChainID != 0 && request.ChainID != uint64(chainID) {
+		return nil, fmt.Errorf("the payment request is for chain %d, but %s is on chain %d",
+			request.ChainID, a.tokenName, chainID)
+	}
+
+	if IsENSName(request.To) {
+		address, err := a.client.ResolveName(cliCtx, request.To)
+		if err != nil {
+			return nil, err
+		}
+		request.To = address.Hex()
+	}
+
+	if !request.IsTokenTransfer() {
+		request.Symbol = a.tokenName
+		if request.Value != "" {
+			value, _ := new(big.Int).SetString(request.Value, 10)
+			request.Amount = Ether(value).String()
+		}
+
+		return request, nil
+	}
+
+	token, err := contracts.NewDemoTokenCaller(common.HexToAddress(request.To), NewContractBackend(a.client))
+	if err != nil {
+		return nil, fmt.Errorf("error binding ERC-20 contract: %w", err)
+	}
+
+	opts := &bind.CallOpts{Context: cliCtx}
+	decimals, err := token.Decimals(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving decimals of token %s: %w", request.To, err)
+	}
+
+	request.Symbol, err = token.Symbol(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving symbol of token %s: %w", request.To, err)
+	}
+
+	units, _ := new(big.Int).SetString(request.Units, 10)
+	request.Amount = NewAmount(units, decimals).String()
+	return request, nil
+}
+
+// ReviewPaymentRequest runs the checks of ReviewTransaction on the transaction paying a
+// request read by ReadPaymentRequest. For token transfers, the review is about the payee
+// rather than the token contract, while its value and total only count ether.
+func (a *MasterAccount) ReviewPaymentRequest(request *PaymentRequest, accountIndex int) (*TransactionReview, error) {
+	cliCtx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+
+	from, err := a.accountDB.GetAccountAddress(cliCtx, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving account address from DB: %w", err)
+	}
+
+	req, err := request.TransactionRequest(common.HexToAddress(from))
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := a.client.ReviewTransaction(cliCtx, req)
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing payment: %w", err)
+	}
+
+	if request.IsTokenTransfer() {
+		review.To = common.HexToAddress(request.Recipient).Hex()
+		review.ToContract = false
+		review.Warnings = slices.DeleteFunc(review.Warnings, func(issue ReviewIssue) bool {
+			return issue.Code == IssueContractRecipient
+		})
+	}
+
+	return review, nil
+}
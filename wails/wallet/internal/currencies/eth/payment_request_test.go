@@ -0,0 +1,126 @@
+package eth_test
+
+import (
+	"testing"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestParsePaymentURI(t *testing.T) {
+	cases := []struct {
+		name    string
+		uri     string
+		want    eth.PaymentRequest
+		wantErr string
+	}{
+		{
+			name: "Ether with chain ID",
+			uri:  "ethereum:" + hardhatAccount1 + "@1337?value=1500000000000000000",
+			want: eth.PaymentRequest{To: hardhatAccount1, ChainID: 1337, Value: "1500000000000000000"},
+		},
+		{
+			name: "Value with an exponent",
+			uri:  "ethereum:pay-" + hardhatAccount1 + "?value=2.014e18&gas=21000",
+			want: eth.PaymentRequest{To: hardhatAccount1, Value: "2014000000000000000", GasLimit: 21000},
+		},
+		{
+			name: "ENS name",
+			uri:  "ethereum:alice.eth?value=1e15",
+			want: eth.PaymentRequest{To: "alice.eth", Value: "1000000000000000"},
+		},
+		{
+			name: "Token transfer",
+			uri:  "ethereum:" + contractAddress + "@1337/transfer?address=" + hardhatAccount1 + "&uint256=2.5e6",
+			want: eth.PaymentRequest{
+				To:        contractAddress,
+				ChainID:   1337,
+				Function:  eth.TransferFunction,
+				Recipient: hardhatAccount1,
+				Units:     "2500000",
+			},
+		},
+		{name: "Other scheme", uri: "bitcoin:" + hardhatAccount1, wantErr: "not an ethereum payment URI"},
+		{
+			name:    "Bad checksum",
+			uri:     "ethereum:0x70997970c51812dc3a010c7d01b50e0d17dc79C8",
+			wantErr: "invalid payment recipient",
+		},
+		{name: "Fraction of wei", uri: "ethereum:" + hardhatAccount1 + "?value=1.5", wantErr: "not a whole number"},
+		{name: "Negative value", uri: "ethereum:" + hardhatAccount1 + "?value=-1", wantErr: "invalid value"},
+		{name: "Invalid chain ID", uri: "ethereum:" + hardhatAccount1 + "@main", wantErr: "invalid chain ID"},
+		{
+			name:    "Other function",
+			uri:     "ethereum:" + contractAddress + "/approve?address=" + hardhatAccount1 + "&uint256=1",
+			wantErr: `unsupported payment function "approve"`,
+		},
+		{
+			name:    "Transfer without recipient",
+			uri:     "ethereum:" + contractAddress + "/transfer?uint256=1",
+			wantErr: "invalid token recipient",
+		},
+		{
+			name:    "Transfer without amount",
+			uri:     "ethereum:" + contractAddress + "/transfer?address=" + hardhatAccount1,
+			wantErr: "no uint256 amount",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := eth.ParsePaymentURI(tc.uri)
+			if tc.wantErr != "" {
+				assertError(t, err, tc.wantErr)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", tc.uri, err)
+			}
+			assertCorrectValue(t, *request, tc.want)
+
+			// Requests survive being encoded again.
+			parsed, err := eth.ParsePaymentURI(request.URI())
+			if err != nil {
+				t.Fatalf("Failed to parse %s: %v", request.URI(), err)
+			}
+			assertCorrectValue(t, *parsed, tc.want)
+		})
+	}
+}
+
+func TestPaymentTransactionRequest(t *testing.T) {
+	from := common.HexToAddress(hardhatAccount0)
+	transfer := eth.PaymentRequest{
+		To:        contractAddress,
+		Function:  eth.TransferFunction,
+		Recipient: hardhatAccount1,
+		Units:     "2500000",
+	}
+
+	req, err := transfer.TransactionRequest(from)
+	if err != nil {
+		t.Fatalf("Failed to build transaction: %v", err)
+	}
+
+	assertCorrectValue(t, req.To.Hex(), contractAddress)
+	assertCorrectValue(t, req.Value.ToInt().Sign(), 0)
+	assertCorrectValue(t, hexutil.Encode(req.Data[:4]), "0xa9059cbb")
+	assertCorrectValue(t, common.BytesToAddress(req.Data[4:36]).Hex(), hardhatAccount1)
+
+	// The fees of the request are not trusted.
+	costly := eth.PaymentRequest{To: hardhatAccount1, Value: "1", GasLimit: 30_000_000, GasPrice: "1000000000000000"}
+	req, err = costly.TransactionRequest(from)
+	if err != nil {
+		t.Fatalf("Failed to build transaction: %v", err)
+	}
+	assertCorrectValue(t, req.Gas, (*hexutil.Uint64)(nil))
+	assertCorrectValue(t, req.GasPrice, (*hexutil.Big)(nil))
+
+	_, err = (&eth.PaymentRequest{To: "alice.eth", Value: "1"}).TransactionRequest(from)
+	assertError(t, err, "unresolved payment recipient alice.eth")
+
+	_, err = (&eth.PaymentRequest{To: hardhatAccount1}).TransactionRequest(from)
+	assertError(t, err, "no value")
+}
@@ -0,0 +1,216 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Codes of the issues a transaction review reports.
+const (
+	IssueInvalidAddress    = "invalid_address"
+	IssueInsufficientFunds = "insufficient_funds"
+	IssueReverted          = "reverted"
+	IssueSimulationFailed  = "simulation_failed"
+	IssueContractRecipient = "contract_recipient"
+	IssueOwnAccount        = "own_account"
+	// IssueLookalikeAddress and IssueFirstTimeRecipient are reported by CheckRecipient.
+	IssueLookalikeAddress   = "lookalike_address"
+	IssueFirstTimeRecipient = "first_time_recipient"
+)
+
+// TransactionReview is the outcome of the checks run on a transaction before it is sent,
+// shown to the user to confirm it. Amounts are in ether, except the gas price in gwei.
+type TransactionReview struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Value    string `json:"value"`
+	Balance  string `json:"balance"`
+	GasLimit uint64 `json:"gasLimit"`
+	GasPrice string `json:"gasPrice"`
+	// MaxFee is the most the transaction can cost in fees, and Total adds the value to it.
+	MaxFee       string `json:"maxFee"`
+	Total        string `json:"total"`
+	ToContract   bool   `json:"toContract"`
+	RevertReason string `json:"revertReason,omitempty"`
+	// Errors are reasons the transaction would fail, while Warnings only need the user's
+	// attention.
+	Errors   []ReviewIssue `json:"errors"`
+	Warnings []ReviewIssue `json:"warnings"`
+	// ConfirmRecipient asks the user to check the recipient address once more before
+	// signing, as it may be a poisoned address.
+	ConfirmRecipient bool `json:"confirmRecipient"`
+}
+
+type ReviewIssue struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (r *TransactionReview) addError(code, message string) {
+	r.Errors = append(r.Errors, ReviewIssue{Code: code, Message: message})
+}
+
+func (r *TransactionReview) addWarning(code, message string) {
+	r.Warnings = append(r.Warnings, ReviewIssue{Code: code, Message: message})
+}
+
+// HasIssue tells whether the review reported an error or a warning with code.
+func (r *TransactionReview) HasIssue(code string) bool {
+	for _, issue := range slices.Concat(r.Errors, r.Warnings) {
+		if issue.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReviewTransaction simulates a transaction request without sending it. In a single batch,
+// it looks up the balance of the sender and the code of the recipient, and estimates the
+// gas of the transaction, or calls it when the gas is set, to find out whether it reverts.
+// Problems found on the chain are reported in the review rather than as an error.
+func (c *Client) ReviewTransaction(ctx context.Context, req *TransactionRequest) (*TransactionReview, error) {
+	if req.To == nil {
+		return nil, fmt.Errorf("contract creation transactions are not supported")
+	}
+
+	from := req.From.Hex()
+	value := new(big.Int)
+	if req.Value != nil {
+		value = req.Value.ToInt()
+	}
+
+	callObject := map[string]interface{}{
+		"from":  from,
+		"to":    req.To.Hex(),
+		"value": hexutil.EncodeBig(value),
+	}
+	if data := req.CallData(); len(data) > 0 {
+		callObject["data"] = data.String()
+	}
+
+	var balanceHex, code, gasPriceHex, gasLimitHex, callResult string
+	batch := []BatchElem{
+		{Method: "eth_getBalance", Params: []interface{}{from, "latest"}, Result: &balanceHex},
+		{Method: "eth_getCode", Params: []interface{}{req.To.Hex(), "latest"}, Result: &code},
+	}
+
+	if req.GasPrice == nil && req.MaxFeePerGas == nil {
+		batch = append(batch, BatchElem{Method: "eth_gasPrice", Result: &gasPriceHex})
+	}
+
+	// The simulation comes last: its error is an outcome of the review.
+	if req.Gas == nil {
+		batch = append(batch, BatchElem{
+			Method: "eth_estimateGas",
+			Params: []interface{}{callObject},
+			Result: &gasLimitHex,
+		})
+	} else {
+		batch = append(batch, BatchElem{
+			Method: "eth_call",
+			Params: []interface{}{callObject, "latest"},
+			Result: &callResult,
+		})
+	}
+
+	err := c.BatchCall(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to review transaction: %w", err)
+	}
+
+	for _, elem := range batch[:len(batch)-1] {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to review transaction: %s: %w", elem.Method, elem.Error)
+		}
+	}
+
+	balance, err := hexutil.DecodeBig(balanceHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid balance %q: %w", balanceHex, err)
+	}
+
+	var gasPrice *big.Int
+	switch {
+	case req.GasPrice != nil:
+		gasPrice = req.GasPrice.ToInt()
+	case req.MaxFeePerGas != nil:
+		gasPrice = req.MaxFeePerGas.ToInt()
+	default:
+		gasPrice, err = hexutil.DecodeBig(gasPriceHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas price %q: %w", gasPriceHex, err)
+		}
+	}
+
+	review := &TransactionReview{
+		From:       from,
+		To:         req.To.Hex(),
+		Value:      Ether(value).String(),
+		Balance:    Ether(balance).String(),
+		GasPrice:   NewAmount(gasPrice, GweiDecimals).String(),
+		ToContract: code != "" && code != "0x",
+	}
+
+	if review.ToContract {
+		review.addWarning(IssueContractRecipient, "The recipient is a contract.")
+	}
+
+	simulation := batch[len(batch)-1].Error
+	if req.Gas != nil {
+		review.GasLimit = uint64(*req.Gas)
+	} else if simulation == nil {
+		review.GasLimit, err = hexutil.DecodeUint64(gasLimitHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas limit %q: %w", gasLimitHex, err)
+		}
+	}
+
+	maxFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(review.GasLimit))
+	total := new(big.Int).Add(value, maxFee)
+	review.MaxFee = Ether(maxFee).String()
+	review.Total = Ether(total).String()
+
+	// Without a gas estimate, the balance can only be checked against the value.
+	insufficientFunds := total.Cmp(balance) > 0
+	if insufficientFunds {
+		review.addError(
+			IssueInsufficientFunds,
+			fmt.Sprintf("The balance of %s ETH does not cover %s ETH plus fees.", review.Balance, review.Value),
+		)
+	}
+
+	if simulation != nil {
+		var rpcErr *RPCError
+		switch {
+		case errors.As(simulation, &rpcErr) && rpcErr.Reverted():
+			review.RevertReason = rpcErr.RevertReason()
+			review.addError(IssueReverted, revertMessage(review.RevertReason))
+		case strings.Contains(simulation.Error(), "insufficient funds"):
+			if !insufficientFunds {
+				review.addError(IssueInsufficientFunds, "The balance does not cover the transaction.")
+			}
+		default:
+			review.addError(
+				IssueSimulationFailed,
+				fmt.Sprintf("The transaction could not be simulated: %v", simulation),
+			)
+		}
+	}
+
+	return review, nil
+}
+
+func revertMessage(reason string) string {
+	if reason == "" {
+		return "The transaction would revert."
+	}
+
+	return fmt.Sprintf("The transaction would revert: %s", reason)
+}
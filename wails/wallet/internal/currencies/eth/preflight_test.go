@@ -0,0 +1,131 @@
+package eth_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"wallet/internal/contracts"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethsim"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestReviewTransaction(t *testing.T) {
+	backend := newSimulatedBackend(t, map[string]int64{hardhatAccount0: 10, hardhatAccount1: 10})
+	client := eth.NewClientWithBackend(backend)
+	privateKey, err := crypto.HexToECDSA(hardhatKey0)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(ethsim.ChainID))
+	if err != nil {
+		t.Fatalf("Failed to create transactor: %v", err)
+	}
+
+	contract, _, _, err := contracts.DeployIncrement(opts, eth.NewContractBackend(client), big.NewInt(1), "counter")
+	if err != nil {
+		t.Fatalf("Failed to deploy Increment: %v", err)
+	}
+	backend.Commit()
+
+	incrementABI, err := contracts.IncrementMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("Failed to parse Increment ABI: %v", err)
+	}
+
+	increment, err := incrementABI.Pack("increment")
+	if err != nil {
+		t.Fatalf("Failed to pack increment call: %v", err)
+	}
+
+	gas := hexutil.Uint64(100000)
+	noValue := (*hexutil.Big)(new(big.Int))
+	recipient := common.HexToAddress(hardhatAccount1)
+	cases := []struct {
+		name       string
+		req        *eth.TransactionRequest
+		wantErrors []string
+		wantWarns  []string
+		wantReason string
+	}{
+		{
+			name: "Transfer",
+			req:  &eth.TransactionRequest{To: &recipient, Value: (*hexutil.Big)(big.NewInt(1e18))},
+		},
+		{
+			name: "Value above the balance",
+			req: &eth.TransactionRequest{
+				To:    &recipient,
+				Value: (*hexutil.Big)(new(big.Int).Mul(big.NewInt(20), big.NewInt(1e18))),
+			},
+			wantErrors: []string{eth.IssueInsufficientFunds},
+		},
+		{
+			name:      "Contract call",
+			req:       &eth.TransactionRequest{To: &contract, Value: noValue, Data: increment},
+			wantWarns: []string{eth.IssueContractRecipient},
+		},
+		{
+			name:       "Reverted estimate",
+			req:        &eth.TransactionRequest{From: recipient, To: &contract, Data: increment},
+			wantErrors: []string{eth.IssueReverted},
+			wantWarns:  []string{eth.IssueContractRecipient},
+			wantReason: "Only owner can call this function",
+		},
+		{
+			name:       "Reverted call with a set gas limit",
+			req:        &eth.TransactionRequest{From: recipient, To: &contract, Data: increment, Gas: &gas},
+			wantErrors: []string{eth.IssueReverted},
+			wantWarns:  []string{eth.IssueContractRecipient},
+			wantReason: "Only owner can call this function",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.req.From == (common.Address{}) {
+				tc.req.From = common.HexToAddress(hardhatAccount0)
+			}
+
+			review, err := client.ReviewTransaction(context.Background(), tc.req)
+			if err != nil {
+				t.Fatalf("Failed to review transaction: %v", err)
+			}
+
+			assertCorrectValue(t, issueCodes(review.Errors), tc.wantErrors)
+			assertCorrectValue(t, issueCodes(review.Warnings), tc.wantWarns)
+			assertCorrectValue(t, review.RevertReason, tc.wantReason)
+			if len(review.Errors) > 0 {
+				return
+			}
+
+			if review.GasLimit == 0 {
+				t.Fatalf("Expected a gas limit, got %+v", review)
+			}
+
+			gasPrice, err := eth.ParseGwei(review.GasPrice)
+			if err != nil {
+				t.Fatalf("Failed to parse gas price: %v", err)
+			}
+
+			maxFee := new(big.Int).Mul(gasPrice.Units(), new(big.Int).SetUint64(review.GasLimit))
+			total := new(big.Int).Add(maxFee, tc.req.Value.ToInt())
+			assertCorrectValue(t, review.MaxFee, eth.Ether(maxFee).String())
+			assertCorrectValue(t, review.Total, eth.Ether(total).String())
+		})
+	}
+}
+
+func issueCodes(issues []eth.ReviewIssue) []string {
+	var codes []string
+	for _, issue := range issues {
+		codes = append(codes, issue.Code)
+	}
+
+	return codes
+}
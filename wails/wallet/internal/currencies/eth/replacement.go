@@ -0,0 +1,105 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// replacementFeeBump is the increase, in percent, nodes require over the fees of a pending
+// transaction to accept another one with the same nonce.
+const replacementFeeBump = 10
+
+// ReplacementTransaction builds a transaction with the nonce of the pending transaction
+// rawTx, paying enough to replace it: the original fees plus 10%, and no less than the
+// current gas price. A speed-up repeats the original transaction, while a cancellation
+// sends nothing to the sender itself.
+func (c *Client) ReplacementTransaction(ctx context.Context, rawTx string, cancel bool) (*UnsignedTransaction, error) {
+	rawTxBytes, err := hexutil.Decode(normalizeRawTransaction(rawTx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode raw transaction hex: %w", err)
+	}
+
+	var tx types.Transaction
+	err = tx.UnmarshalBinary(rawTxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode raw transaction: %w", err)
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), &tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover transaction sender: %w", err)
+	}
+
+	var nonceHex, gasPriceHex string
+	batch := []BatchElem{
+		{Method: "eth_getTransactionCount", Params: []interface{}{from.Hex(), "latest"}, Result: &nonceHex},
+		{Method: "eth_gasPrice", Result: &gasPriceHex},
+	}
+	err = c.BatchCall(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transaction parameters: %w", err)
+	}
+
+	for _, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to retrieve transaction parameters: %w", elem.Error)
+		}
+	}
+
+	minedNonce, err := hexutil.DecodeUint64(nonceHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce %q: %w", nonceHex, err)
+	}
+
+	if minedNonce > tx.Nonce() {
+		return nil, fmt.Errorf("transaction %s is no longer pending", tx.Hash().Hex())
+	}
+
+	gasPrice, err := hexutil.DecodeBig(gasPriceHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price %q: %w", gasPriceHex, err)
+	}
+
+	replacement := &UnsignedTransaction{
+		From:     from,
+		Nonce:    hexutil.Uint64(tx.Nonce()),
+		GasPrice: (*hexutil.Big)(bigMax(bumpFee(tx.GasFeeCap()), bumpFee(tx.GasTipCap()), gasPrice)),
+		ChainID:  (*hexutil.Big)(tx.ChainId()),
+	}
+
+	if cancel {
+		replacement.To = &from
+		replacement.Value = (*hexutil.Big)(new(big.Int))
+		replacement.GasLimit = hexutil.Uint64(params.TxGas)
+		return replacement, nil
+	}
+
+	replacement.To = tx.To()
+	replacement.Value = (*hexutil.Big)(tx.Value())
+	replacement.GasLimit = hexutil.Uint64(tx.Gas())
+	replacement.Data = tx.Data()
+	return replacement, nil
+}
+
+// bumpFee raises a fee by replacementFeeBump percent, rounding up.
+func bumpFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+replacementFeeBump))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+func bigMax(values ...*big.Int) *big.Int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value.Cmp(result) > 0 {
+			result = value
+		}
+	}
+
+	return result
+}
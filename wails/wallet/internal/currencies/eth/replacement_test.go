@@ -0,0 +1,102 @@
+package eth_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestReplaceTransaction(t *testing.T) {
+	cases := []struct {
+		name   string
+		cancel bool
+		// wantReceived is the balance of the recipient, in wei, once the replacement is mined.
+		wantReceived string
+	}{
+		{name: "Speed up", wantReceived: "0x3e8"},
+		{name: "Cancel", cancel: true, wantReceived: "0x0"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend := newSimulatedBackend(t, map[string]int64{hardhatAccount0: 10})
+			client := eth.NewClientWithBackend(backend)
+			manager, _ := newNonceManager(t, client)
+			privateKey, err := crypto.HexToECDSA(hardhatKey0)
+			if err != nil {
+				t.Fatalf("Failed to parse private key: %v", err)
+			}
+
+			ctx := context.Background()
+			original, err := manager.Send(ctx, hardhatAccount0, signTransfer(client, privateKey, big.NewInt(1000)))
+			if err != nil {
+				t.Fatalf("Failed to send transaction: %v", err)
+			}
+
+			replace := func(rawTx string) (string, error) {
+				unsignedTx, err := client.ReplacementTransaction(ctx, rawTx, tc.cancel)
+				if err != nil {
+					return "", err
+				}
+
+				minGasPrice := new(big.Int).Mul(gasPriceOf(t, rawTx), big.NewInt(110))
+				minGasPrice.Div(minGasPrice, big.NewInt(100))
+				if unsignedTx.GasPrice.ToInt().Cmp(minGasPrice) < 0 {
+					t.Errorf("Replacement gas price %v is below %v", unsignedTx.GasPrice, minGasPrice)
+				}
+
+				return eth.SignUnsignedTransaction(unsignedTx, privateKey)
+			}
+
+			replacement, err := manager.Replace(ctx, original.Hash, replace)
+			if err != nil {
+				t.Fatalf("Failed to replace transaction: %v", err)
+			}
+			assertCorrectValue(t, replacement.Nonce, original.Nonce)
+
+			backend.Commit()
+			receipts, err := client.GetTransactionReceipts(ctx, []string{original.Hash, replacement.Hash})
+			if err != nil {
+				t.Fatalf("Failed to get receipts: %v", err)
+			}
+
+			if receipts[0] != nil || receipts[1] == nil {
+				t.Fatalf("Expected only the replacement to be mined, got %+v", receipts)
+			}
+
+			balance, err := client.GetBalance(ctx, hardhatAccount1)
+			if err != nil {
+				t.Fatalf("Failed to get balance: %v", err)
+			}
+			assertCorrectValue(t, balance, tc.wantReceived)
+
+			_, err = manager.Replace(ctx, replacement.Hash, replace)
+			assertError(t, err, "is no longer pending")
+		})
+	}
+
+	t.Run("Unknown transaction", func(t *testing.T) {
+		manager, _ := newNonceManager(t, eth.NewClient("http://unused.invalid"))
+		_, err := manager.Replace(context.Background(), mockTxHash, func(string) (string, error) {
+			t.Fatalf("Unexpected replacement")
+			return "", nil
+		})
+		assertError(t, err, "was not sent by this wallet")
+	})
+}
+
+func gasPriceOf(t testing.TB, rawTx string) *big.Int {
+	t.Helper()
+	var tx types.Transaction
+	err := tx.UnmarshalBinary(hexutil.MustDecode(rawTx))
+	if err != nil {
+		t.Fatalf("Failed to decode transaction: %v", err)
+	}
+
+	return tx.GasPrice()
+}
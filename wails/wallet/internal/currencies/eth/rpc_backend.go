@@ -0,0 +1,192 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	defaultResubscribeDelay = 500 * time.Millisecond
+	maxResubscribeDelay     = 30 * time.Second
+)
+
+// Subscriber is implemented by backends that push eth_subscribe notifications.
+type Subscriber interface {
+	// Subscribe calls notify with every notification of the subscription created with
+	// args, e.g. "newHeads", until ctx is done.
+	Subscribe(ctx context.Context, notify func(json.RawMessage), args ...interface{}) error
+}
+
+// RPCBackend sends requests through a go-ethereum RPC client, connected over
+// WebSocket or served in process. Unlike plain HTTP, it supports subscriptions.
+type RPCBackend struct {
+	// ResubscribeDelay is the first wait before subscribing again after the connection
+	// dropped. It doubles on every failed attempt.
+	ResubscribeDelay time.Duration
+
+	client *rpc.Client
+}
+
+type rpcRequest struct {
+	Jsonrpc string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type rpcResponse struct {
+	Jsonrpc string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcErrorObject `json:"error,omitempty"`
+}
+
+type rpcErrorObject struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func NewRPCBackend(client *rpc.Client) *RPCBackend {
+	return &RPCBackend{ResubscribeDelay: defaultResubscribeDelay, client: client}
+}
+
+// DialWebSocket connects to the WebSocket endpoint of a node. The connection is
+// dialed again when it drops. Subscriptions run with long-lived contexts, so the
+// handshake gets its own timeout to not hang on an unresponsive endpoint.
+func DialWebSocket(ctx context.Context, url string) (*RPCBackend, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	client, err := rpc.DialWebsocket(dialCtx, url, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", url, err)
+	}
+
+	return NewRPCBackend(client), nil
+}
+
+// Send serves a JSON-RPC request, or a batch of them, through the RPC client and
+// encodes the responses the way an HTTP endpoint would, errors included.
+func (b *RPCBackend) Send(ctx context.Context, data []byte) ([]byte, error) {
+	var batch []rpcRequest
+	if json.Unmarshal(data, &batch) == nil {
+		responses := make([]rpcResponse, len(batch))
+		for i, req := range batch {
+			responses[i] = b.serve(ctx, req)
+		}
+
+		return json.Marshal(responses)
+	}
+
+	var req rpcRequest
+	err := json.Unmarshal(data, &req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+
+	return json.Marshal(b.serve(ctx, req))
+}
+
+func (b *RPCBackend) serve(ctx context.Context, req rpcRequest) rpcResponse {
+	params := make([]interface{}, 0, len(req.Params))
+	for _, param := range req.Params {
+		params = append(params, param)
+	}
+
+	resp := rpcResponse{Jsonrpc: "2.0", ID: req.ID}
+	var result json.RawMessage
+	err := b.client.CallContext(ctx, &result, req.Method, params...)
+	if err != nil {
+		resp.Error = &rpcErrorObject{Code: -32000, Message: err.Error()}
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			resp.Error.Code = rpcErr.ErrorCode()
+		}
+
+		var dataErr rpc.DataError
+		if errors.As(err, &dataErr) {
+			resp.Error.Data = dataErr.ErrorData()
+		}
+	} else {
+		resp.Result = result
+		if resp.Result == nil {
+			resp.Result = json.RawMessage("null")
+		}
+	}
+
+	return resp
+}
+
+// Subscribe returns once the subscription is created. When the connection drops, the
+// subscription is created again, with backoff, until ctx is done or the backend is closed.
+func (b *RPCBackend) Subscribe(ctx context.Context, notify func(json.RawMessage), args ...interface{}) error {
+	ch := make(chan json.RawMessage)
+	sub, err := b.client.EthSubscribe(ctx, ch, args...)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to %v: %w", args[0], err)
+	}
+
+	go func() {
+		delay := b.ResubscribeDelay
+		for {
+			err := forwardNotifications(ctx, sub, ch, notify)
+			if err == nil || errors.Is(err, rpc.ErrClientQuit) {
+				return
+			}
+
+			for {
+				if sleepContext(ctx, delay) != nil {
+					return
+				}
+
+				sub, err = b.client.EthSubscribe(ctx, ch, args...)
+				if err == nil {
+					delay = b.ResubscribeDelay
+					break
+				}
+
+				if errors.Is(err, rpc.ErrClientQuit) {
+					return
+				}
+
+				delay = min(2*delay, maxResubscribeDelay)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (b *RPCBackend) Close() {
+	b.client.Close()
+}
+
+// forwardNotifications delivers notifications until ctx is done, returning nil, or the
+// subscription fails, returning its error.
+func forwardNotifications(
+	ctx context.Context,
+	sub *rpc.ClientSubscription,
+	ch <-chan json.RawMessage,
+	notify func(json.RawMessage),
+) error {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case notification := <-ch:
+			notify(notification)
+		case err := <-sub.Err():
+			if err == nil {
+				return rpc.ErrClientQuit
+			}
+
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
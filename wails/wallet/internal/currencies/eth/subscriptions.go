@@ -0,0 +1,183 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+)
+
+// Head is a block announced by a newHeads subscription.
+type Head struct {
+	Number     hexutil.Uint64 `json:"number"`
+	Hash       common.Hash    `json:"hash"`
+	ParentHash common.Hash    `json:"parentHash"`
+	Timestamp  hexutil.Uint64 `json:"timestamp"`
+}
+
+// TokenTransfer is an ERC-20, ERC-721 or ERC-1155 transfer to an account of the wallet,
+// announced by a logs subscription.
+type TokenTransfer struct {
+	Contract        string `json:"contract"`
+	To              string `json:"to"`
+	TransactionHash string `json:"transactionHash"`
+	BlockNumber     uint64 `json:"blockNumber"`
+}
+
+// Receipt is the outcome of a mined transaction.
+type Receipt struct {
+	TransactionHash common.Hash    `json:"transactionHash"`
+	BlockNumber     hexutil.Uint64 `json:"blockNumber"`
+	GasUsed         hexutil.Uint64 `json:"gasUsed"`
+	Status          hexutil.Uint64 `json:"status"`
+	// ContractAddress is set when the transaction deployed a contract.
+	ContractAddress *common.Address `json:"contractAddress"`
+}
+
+// Succeeded tells whether the transaction was executed without reverting.
+func (r *Receipt) Succeeded() bool {
+	return uint64(r.Status) == types.ReceiptStatusSuccessful
+}
+
+// SetWebSocketProvider sets the endpoint subscriptions are made on when requests go
+// over HTTP. It is dialed on the first subscription.
+func (c *Client) SetWebSocketProvider(url string) {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	if c.ws != nil {
+		c.ws.Close()
+		c.ws = nil
+	}
+
+	c.wsURL = url
+}
+
+// SubscribeNewHeads calls onHead with every new block until ctx is done.
+func (c *Client) SubscribeNewHeads(ctx context.Context, onHead func(Head)) error {
+	subscriber, err := c.subscriber(ctx)
+	if err != nil {
+		return err
+	}
+
+	return subscriber.Subscribe(ctx, func(notification json.RawMessage) {
+		var head Head
+		if json.Unmarshal(notification, &head) == nil {
+			onHead(head)
+		}
+	}, "newHeads")
+}
+
+// SubscribeLogs calls onLog with every log matching query until ctx is done. Only the
+// addresses and topics of the query are used.
+func (c *Client) SubscribeLogs(ctx context.Context, query ethereum.FilterQuery, onLog func(types.Log)) error {
+	subscriber, err := c.subscriber(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter := map[string]interface{}{
+		"address": query.Addresses,
+		"topics":  query.Topics,
+	}
+
+	return subscriber.Subscribe(ctx, func(notification json.RawMessage) {
+		var log types.Log
+		if json.Unmarshal(notification, &log) == nil {
+			onLog(log)
+		}
+	}, "logs", filter)
+}
+
+// subscriber returns the backend when it handles subscriptions itself, and the
+// WebSocket endpoint otherwise.
+func (c *Client) subscriber(ctx context.Context) (Subscriber, error) {
+	if subscriber, ok := c.backend.(Subscriber); ok {
+		return subscriber, nil
+	}
+
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	if c.ws != nil {
+		return c.ws, nil
+	}
+
+	if c.wsURL == "" {
+		return nil, errors.New("no WebSocket provider configured")
+	}
+
+	ws, err := DialWebSocket(ctx, c.wsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c.ws = ws
+	return ws, nil
+}
+
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	payload := RPCPayload{
+		Jsonrpc: "2.0",
+		Method:  "eth_blockNumber",
+		Params:  []interface{}{},
+		ID:      uuid.New().String(),
+	}
+
+	response, err := c.sendRequestToNode(ctx, payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block number: %w", err)
+	}
+
+	blockNumberHex, ok := response["result"].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected result type: expected string")
+	}
+
+	blockNumber, err := hexutil.DecodeUint64(blockNumberHex)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block number %q: %w", blockNumberHex, err)
+	}
+
+	return blockNumber, nil
+}
+
+// GetTransactionReceipts looks up the receipts of several transactions in one batch.
+// The receipt of a transaction that is not mined yet, or was replaced, is nil.
+func (c *Client) GetTransactionReceipts(ctx context.Context, hashes []string) ([]*Receipt, error) {
+	receipts := make([]*Receipt, len(hashes))
+	batch := make([]BatchElem, len(hashes))
+	for i, hash := range hashes {
+		batch[i] = BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Params: []interface{}{hash},
+			Result: &receipts[i],
+		}
+	}
+
+	err := c.BatchCall(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipts: %w", err)
+	}
+
+	for i, elem := range batch {
+		if elem.Error != nil && !isIndexingError(elem.Error) {
+			return nil, fmt.Errorf("failed to get receipt of %s: %w", hashes[i], elem.Error)
+		}
+	}
+
+	return receipts, nil
+}
+
+// isIndexingError tells whether geth could not find a transaction because it has not
+// indexed every block yet. The transaction is then treated as not mined, as geth does
+// not know about it either way.
+func isIndexingError(err error) bool {
+	var rpcErr *RPCError
+	return errors.As(err, &rpcErr) && strings.Contains(rpcErr.Message, "transaction indexing is in progress")
+}
@@ -0,0 +1,194 @@
+package eth_test
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestSubscribeNewHeadsWithSimulatedChain(t *testing.T) {
+	backend := newSimulatedBackend(t, map[string]int64{hardhatAccount0: 1})
+	client := eth.NewClientWithBackend(backend)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	heads := make(chan eth.Head, 1)
+	err := client.SubscribeNewHeads(ctx, func(head eth.Head) { heads <- head })
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	hash := backend.Commit()
+	head := receive(t, heads)
+	assertCorrectValue(t, head.Number, hexutil.Uint64(1))
+	assertCorrectValue(t, head.Hash, hash)
+}
+
+func TestSubscribeNewHeadsResubscribesAfterReconnect(t *testing.T) {
+	node := newHeadsNode(t)
+	client := eth.NewClient("http://unused.invalid")
+	client.SetWebSocketProvider(node.url)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	heads := make(chan eth.Head, 1)
+	err := client.SubscribeNewHeads(ctx, func(head eth.Head) { heads <- head })
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	receive(t, node.subscribed)
+	node.publish(1)
+	assertCorrectValue(t, receive(t, heads).Number, hexutil.Uint64(1))
+
+	node.dropConnections()
+	receive(t, node.subscribed)
+	node.publish(2)
+	assertCorrectValue(t, receive(t, heads).Number, hexutil.Uint64(2))
+}
+
+func TestSubscribeWithoutWebSocketProvider(t *testing.T) {
+	err := eth.NewClient("http://unused.invalid").SubscribeNewHeads(context.Background(), func(eth.Head) {})
+	assertError(t, err, "no WebSocket provider configured")
+}
+
+func TestSubscribeTimesOutOnUnresponsiveEndpoint(t *testing.T) {
+	// The endpoint accepts connections but never answers the WebSocket handshake.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	client := eth.NewClient("http://unused.invalid")
+	client.SetWebSocketProvider("ws://" + listener.Addr().String())
+	start := time.Now()
+	err = client.SubscribeNewHeads(context.Background(), func(eth.Head) {})
+	if err == nil || !strings.Contains(err.Error(), "failed to connect to") {
+		t.Fatalf("Expected a connection error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected the dial to time out, it took %v", elapsed)
+	}
+}
+
+// headsNode is a WebSocket node that only serves newHeads subscriptions, and whose
+// connections can be dropped.
+type headsNode struct {
+	url        string
+	subscribed chan struct{}
+
+	mu            sync.Mutex
+	conns         []net.Conn
+	subscriptions []headsSubscription
+}
+
+type headsSubscription struct {
+	notifier *rpc.Notifier
+	id       rpc.ID
+}
+
+func newHeadsNode(t testing.TB) *headsNode {
+	t.Helper()
+	node := &headsNode{subscribed: make(chan struct{}, 1)}
+	server := rpc.NewServer()
+	err := server.RegisterName("eth", &headsService{node: node})
+	if err != nil {
+		t.Fatalf("Failed to register service: %v", err)
+	}
+
+	httpServer := httptest.NewUnstartedServer(server.WebsocketHandler([]string{"*"}))
+	httpServer.Listener = &trackingListener{Listener: httpServer.Listener, node: node}
+	httpServer.Start()
+	t.Cleanup(func() {
+		node.dropConnections()
+		httpServer.Close()
+		server.Stop()
+	})
+
+	node.url = "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	return node
+}
+
+func (n *headsNode) publish(blockNumber uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, sub := range n.subscriptions {
+		_ = sub.notifier.Notify(sub.id, eth.Head{Number: hexutil.Uint64(blockNumber)})
+	}
+}
+
+func (n *headsNode) dropConnections() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, conn := range n.conns {
+		conn.Close()
+	}
+	n.conns = nil
+	n.subscriptions = nil
+}
+
+type headsService struct {
+	node *headsNode
+}
+
+func (s *headsService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+
+	sub := notifier.CreateSubscription()
+	s.node.mu.Lock()
+	s.node.subscriptions = append(s.node.subscriptions, headsSubscription{notifier: notifier, id: sub.ID})
+	s.node.mu.Unlock()
+	s.node.subscribed <- struct{}{}
+
+	return sub, nil
+}
+
+type trackingListener struct {
+	net.Listener
+	node *headsNode
+}
+
+func (l *trackingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.node.mu.Lock()
+		l.node.conns = append(l.node.conns, conn)
+		l.node.mu.Unlock()
+	}
+
+	return conn, err
+}
+
+func receive[T any](t testing.TB, ch <-chan T) T {
+	t.Helper()
+	select {
+	case value := <-ch:
+		return value
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for a notification")
+		var zero T
+		return zero
+	}
+}
@@ -0,0 +1,192 @@
+package eth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultIPFSGateway serves the ipfs:// URIs of token metadata and images over HTTP.
+const DefaultIPFSGateway = "https://ipfs.io/ipfs/"
+
+// maxMetadataSize bounds the metadata documents read from token URIs.
+const maxMetadataSize = 1 << 20
+
+// sharedAddressSpace is the carrier-grade NAT range, which netip does not count as private.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// metadataHTTPClient reads the metadata of token URIs. Anyone can deploy a collection
+// and pick its URIs, so it only connects to public addresses: a URI cannot make the
+// wallet reach the services of the machine it runs on or of its local network. The check
+// runs on the address being dialed, after DNS resolution and on each redirect.
+var metadataHTTPClient = &http.Client{
+	Timeout: defaultRequestTimeout,
+	Transport: &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: defaultRequestTimeout, Control: rejectNonPublicAddress}).DialContext,
+		TLSHandshakeTimeout: defaultRequestTimeout,
+	},
+}
+
+func rejectNonPublicAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid token metadata address %s: %w", address, err)
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("invalid token metadata address %s: %w", address, err)
+	}
+
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("token metadata host %s is not a public address", ip)
+	}
+
+	return nil
+}
+
+// TokenMetadata is the JSON document a token URI points to, as described by the ERC-721
+// and ERC-1155 metadata extensions. Image is rewritten to an HTTP URL when it is an
+// ipfs:// URI.
+type TokenMetadata struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Image       string `json:"image"`
+}
+
+// GatewayURL rewrites ipfs:// URIs to the IPFS gateway, and leaves other URIs unchanged.
+func GatewayURL(uri string) string {
+	path, ok := strings.CutPrefix(uri, "ipfs://")
+	if !ok {
+		return uri
+	}
+
+	return DefaultIPFSGateway + strings.TrimPrefix(path, "ipfs/")
+}
+
+// FetchTokenMetadata reads the metadata a token URI points to. Besides HTTP and IPFS
+// URIs, on-chain collections commonly return data: URIs holding the JSON document. HTTP
+// URIs are only read from public addresses, see metadataHTTPClient.
+func FetchTokenMetadata(ctx context.Context, uri string) (*TokenMetadata, error) {
+	var document []byte
+	if strings.HasPrefix(uri, "data:") {
+		data, err := decodeDataURI(uri)
+		if err != nil {
+			return nil, err
+		}
+		document = data
+	} else {
+		data, err := fetchMetadataDocument(ctx, GatewayURL(uri))
+		if err != nil {
+			return nil, err
+		}
+		document = data
+	}
+
+	var metadata TokenMetadata
+	err := json.Unmarshal(document, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token metadata at %s: %w", uri, err)
+	}
+
+	metadata.Image = GatewayURL(metadata.Image)
+	return &metadata, nil
+}
+
+// fetchCollectiblesMetadata fills in the metadata of the collectibles that have a URI,
+// fetching those missing from cache concurrently, and adding them to it. Metadata that
+// cannot be read is left out, since it does not change what the accounts hold.
+func fetchCollectiblesMetadata(ctx context.Context, collectibles []Collectible, cache *sync.Map) {
+	var wg sync.WaitGroup
+	for i := range collectibles {
+		if collectibles[i].URI == "" {
+			continue
+		}
+
+		if metadata, ok := cache.Load(collectibles[i].URI); ok {
+			collectibles[i].Metadata = metadata.(*TokenMetadata)
+			continue
+		}
+
+		wg.Add(1)
+		go func(collectible *Collectible) {
+			defer wg.Done()
+			fetchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			metadata, err := FetchTokenMetadata(fetchCtx, collectible.URI)
+			if err == nil {
+				collectible.Metadata = metadata
+				cache.Store(collectible.URI, metadata)
+			}
+		}(&collectibles[i])
+	}
+
+	wg.Wait()
+}
+
+func fetchMetadataDocument(ctx context.Context, uri string) ([]byte, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, fmt.Errorf("unsupported token URI %q", uri)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := metadataHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token metadata: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("token metadata returned HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token metadata: %w", err)
+	}
+
+	return body, nil
+}
+
+// decodeDataURI decodes the payload of a data: URI, either base64 or percent-encoded.
+func decodeDataURI(uri string) ([]byte, error) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok {
+		return nil, fmt.Errorf("invalid data URI")
+	}
+
+	if strings.HasSuffix(header, ";base64") {
+		data, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 data URI: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data URI: %w", err)
+	}
+
+	return []byte(data), nil
+}
@@ -0,0 +1,78 @@
+package eth_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet/internal/currencies/eth"
+)
+
+func TestFetchTokenMetadata(t *testing.T) {
+	// The server is on a loopback address, which token URIs are not allowed to reach.
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		_, _ = w.Write([]byte(`{"name": "Rocket #1"}`))
+	}))
+	defer server.Close()
+
+	document := `{"name": "Ticket #7", "image": "https://example.com/7.png"}`
+	rocket := `{"name": "Rocket #1", "description": "The first rocket", "image": "ipfs://QmRocket/1.png"}`
+	cases := []struct {
+		name    string
+		uri     string
+		want    eth.TokenMetadata
+		wantErr string
+	}{
+		{
+			name: "IPFS image",
+			uri:  "data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(rocket)),
+			want: eth.TokenMetadata{
+				Name:        "Rocket #1",
+				Description: "The first rocket",
+				Image:       eth.DefaultIPFSGateway + "QmRocket/1.png",
+			},
+		},
+		{
+			name: "Base64 data URI",
+			uri:  "data:application/json;base64," + base64.StdEncoding.EncodeToString([]byte(document)),
+			want: eth.TokenMetadata{Name: "Ticket #7", Image: "https://example.com/7.png"},
+		},
+		{
+			name: "Percent-encoded data URI",
+			uri:  `data:application/json,{"name":"Ticket%20%238"}`,
+			want: eth.TokenMetadata{Name: "Ticket #8"},
+		},
+		{name: "Loopback host", uri: server.URL + "/rockets/1.json", wantErr: "127.0.0.1 is not a public address"},
+		{name: "Private host", uri: "http://192.168.1.1/1.json", wantErr: "192.168.1.1 is not a public address"},
+		{name: "Link-local host", uri: "http://169.254.169.254/latest", wantErr: "169.254.169.254 is not a public address"},
+		{name: "IPv6 loopback host", uri: "http://[::1]:8545/1.json", wantErr: "::1 is not a public address"},
+		{name: "Unsupported scheme", uri: "ar://rocket", wantErr: "unsupported token URI"},
+		{name: "Not JSON", uri: "data:text/plain,rocket", wantErr: "invalid token metadata"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata, err := eth.FetchTokenMetadata(context.Background(), tc.uri)
+			if tc.wantErr != "" {
+				assertError(t, err, tc.wantErr)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to fetch metadata: %v", err)
+			}
+			assertCorrectValue(t, *metadata, tc.want)
+		})
+	}
+
+	assertCorrectValue(t, requested, false)
+}
+
+func TestGatewayURL(t *testing.T) {
+	assertCorrectValue(t, eth.GatewayURL("ipfs://QmRocket/1.json"), eth.DefaultIPFSGateway+"QmRocket/1.json")
+	assertCorrectValue(t, eth.GatewayURL("ipfs://ipfs/QmRocket"), eth.DefaultIPFSGateway+"QmRocket")
+	assertCorrectValue(t, eth.GatewayURL("https://example.com/1.json"), "https://example.com/1.json")
+}
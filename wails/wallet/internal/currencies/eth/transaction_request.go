@@ -0,0 +1,176 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TransactionRequest mirrors the transaction object accepted by eth_sendTransaction
+// and eth_signTransaction. Every field but From is optional and gets filled from the node.
+type TransactionRequest struct {
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to"`
+	Value        *hexutil.Big    `json:"value"`
+	Data         hexutil.Bytes   `json:"data"`
+	Input        hexutil.Bytes   `json:"input"`
+	Gas          *hexutil.Uint64 `json:"gas"`
+	GasPrice     *hexutil.Big    `json:"gasPrice"`
+	MaxFeePerGas *hexutil.Big    `json:"maxFeePerGas"`
+	Nonce        *hexutil.Uint64 `json:"nonce"`
+}
+
+// CallData returns the calldata of the request. Tools such as ethers and viem send it as
+// input, older ones as data, and input wins when both are set.
+func (r *TransactionRequest) CallData() hexutil.Bytes {
+	if len(r.Input) > 0 {
+		return r.Input
+	}
+
+	return r.Data
+}
+
+// MaxFee returns the most the request can pay in fees, its gas times its gas price, or nil
+// when the node picks either of them.
+func (r *TransactionRequest) MaxFee() *big.Int {
+	gasPrice := r.GasPrice
+	if gasPrice == nil {
+		gasPrice = r.MaxFeePerGas
+	}
+
+	if r.Gas == nil || gasPrice == nil {
+		return nil
+	}
+
+	return new(big.Int).Mul(gasPrice.ToInt(), new(big.Int).SetUint64(uint64(*r.Gas)))
+}
+
+// FillTransaction completes a transaction request with the pending nonce, gas price, gas
+// limit and chain ID reported by the node. The missing values are looked up in a single batch.
+// Only legacy transactions are produced, so a maxFeePerGas sent by EIP-1559 aware tools
+// is used as the gas price. A request without recipient deploys its data as a contract.
+func (c *Client) FillTransaction(ctx context.Context, req *TransactionRequest) (*UnsignedTransaction, error) {
+	from := req.From.Hex()
+	value := new(big.Int)
+	if req.Value != nil {
+		value = req.Value.ToInt()
+	}
+
+	unsignedTx := &UnsignedTransaction{
+		From:  req.From,
+		To:    req.To,
+		Value: (*hexutil.Big)(value),
+		Data:  req.CallData(),
+	}
+
+	var nonceHex, gasPriceHex, gasLimitHex, chainIDHex string
+	var lookups []transactionLookup
+	if req.Nonce != nil {
+		unsignedTx.Nonce = *req.Nonce
+	} else {
+		lookups = append(lookups, transactionLookup{
+			action: "retrieve nonce",
+			elem:   BatchElem{Method: "eth_getTransactionCount", Params: []interface{}{from, "pending"}},
+			result: &nonceHex,
+		})
+	}
+
+	switch {
+	case req.GasPrice != nil:
+		unsignedTx.GasPrice = req.GasPrice
+	case req.MaxFeePerGas != nil:
+		unsignedTx.GasPrice = req.MaxFeePerGas
+	default:
+		lookups = append(lookups, transactionLookup{
+			action: "retrieve gas price",
+			elem:   BatchElem{Method: "eth_gasPrice"},
+			result: &gasPriceHex,
+		})
+	}
+
+	if req.Gas != nil {
+		unsignedTx.GasLimit = *req.Gas
+	} else {
+		callObject := map[string]interface{}{
+			"from":  from,
+			"value": hexutil.EncodeBig(value),
+		}
+		if req.To != nil {
+			callObject["to"] = req.To.Hex()
+		}
+		if len(unsignedTx.Data) > 0 {
+			callObject["data"] = unsignedTx.Data.String()
+		}
+
+		lookups = append(lookups, transactionLookup{
+			action: "estimate gas",
+			elem:   BatchElem{Method: "eth_estimateGas", Params: []interface{}{callObject}},
+			result: &gasLimitHex,
+		})
+	}
+
+	lookups = append(lookups, transactionLookup{
+		action: "retrieve chain ID",
+		elem:   BatchElem{Method: "eth_chainId"},
+		result: &chainIDHex,
+	})
+
+	batch := make([]BatchElem, len(lookups))
+	for i, lookup := range lookups {
+		batch[i] = lookup.elem
+		batch[i].Result = lookup.result
+	}
+
+	err := c.BatchCall(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transaction parameters: %w", err)
+	}
+
+	for i, lookup := range lookups {
+		if batch[i].Error != nil {
+			return nil, fmt.Errorf("failed to %s: %w", lookup.action, batch[i].Error)
+		}
+	}
+
+	if req.Nonce == nil {
+		nonce, err := hexutil.DecodeUint64(nonceHex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve nonce: invalid nonce %q: %w", nonceHex, err)
+		}
+		unsignedTx.Nonce = hexutil.Uint64(nonce)
+	}
+
+	if unsignedTx.GasPrice == nil {
+		gasPrice, err := hexutil.DecodeBig(gasPriceHex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve gas price: invalid gas price %q: %w", gasPriceHex, err)
+		}
+		unsignedTx.GasPrice = (*hexutil.Big)(gasPrice)
+	}
+
+	if req.Gas == nil {
+		gasLimit, err := hexutil.DecodeUint64(gasLimitHex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: invalid gas limit %q: %w", gasLimitHex, err)
+		}
+		unsignedTx.GasLimit = hexutil.Uint64(gasLimit)
+	}
+
+	chainID, err := hexutil.DecodeBig(chainIDHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve chain ID: invalid chain ID %q: %w", chainIDHex, err)
+	}
+	unsignedTx.ChainID = (*hexutil.Big)(chainID)
+
+	return unsignedTx, nil
+}
+
+// transactionLookup is a value FillTransaction asks the node for.
+type transactionLookup struct {
+	action string
+	elem   BatchElem
+	result *string
+}
@@ -0,0 +1,262 @@
+package eth
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// TypedDataReview is a human readable view of an EIP-712 payload shown to the user
+// before signing it.
+type TypedDataReview struct {
+	PrimaryType       string            `json:"primaryType"`
+	DomainName        string            `json:"domainName"`
+	DomainVersion     string            `json:"domainVersion"`
+	VerifyingContract string            `json:"verifyingContract"`
+	ChainID           string            `json:"chainId"`
+	NetworkChainID    string            `json:"networkChainId"`
+	ChainIDMismatch   bool              `json:"chainIdMismatch"`
+	DomainSeparator   string            `json:"domainSeparator"`
+	StructHash        string            `json:"structHash"`
+	Digest            string            `json:"digest"`
+	Message           []*TypedDataField `json:"message"`
+}
+
+// TypedDataField is a decoded message field. Structs and arrays keep their members in Fields.
+type TypedDataField struct {
+	Name   string            `json:"name"`
+	Type   string            `json:"type"`
+	Value  string            `json:"value,omitempty"`
+	Fields []*TypedDataField `json:"fields,omitempty"`
+}
+
+func ParseTypedData(typedDataJSON []byte) (*apitypes.TypedData, error) {
+	var typedData apitypes.TypedData
+	err := json.Unmarshal(typedDataJSON, &typedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal typed data: %w", err)
+	}
+
+	if _, ok := typedData.Types["EIP712Domain"]; !ok {
+		return nil, fmt.Errorf("typed data does not declare the EIP712Domain type")
+	}
+
+	if _, ok := typedData.Types[typedData.PrimaryType]; !ok {
+		return nil, fmt.Errorf("primary type %q is not declared", typedData.PrimaryType)
+	}
+
+	return &typedData, nil
+}
+
+// HashTypedData returns the domain separator, the primary struct hash and the digest
+// that gets signed, as defined by EIP-712.
+func HashTypedData(typedData *apitypes.TypedData) ([]byte, []byte, []byte, error) {
+	domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to hash typed data domain: %w", err)
+	}
+
+	structHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to hash typed data message: %w", err)
+	}
+
+	rawData := append([]byte{0x19, 0x01}, domainSeparator...)
+	rawData = append(rawData, structHash...)
+	return domainSeparator, structHash, crypto.Keccak256(rawData), nil
+}
+
+// SignTypedData signs an EIP-712 payload as eth_signTypedData_v4 does.
+func SignTypedData(typedData *apitypes.TypedData, privateKey *ecdsa.PrivateKey) (string, error) {
+	_, _, digest, err := HashTypedData(typedData)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := crypto.Sign(digest, privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign typed data: %w", err)
+	}
+
+	signature[crypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(signature), nil
+}
+
+// ReviewTypedData decodes the payload for the approval UI and flags a domain chain ID
+// that differs from the chain ID of the active network.
+func ReviewTypedData(typedData *apitypes.TypedData, networkChainID int64) (*TypedDataReview, error) {
+	domainSeparator, structHash, digest, err := HashTypedData(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := describeTypedStruct(typedData, typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	network := big.NewInt(networkChainID)
+	review := &TypedDataReview{
+		PrimaryType:       typedData.PrimaryType,
+		DomainName:        typedData.Domain.Name,
+		DomainVersion:     typedData.Domain.Version,
+		VerifyingContract: typedData.Domain.VerifyingContract,
+		NetworkChainID:    network.String(),
+		DomainSeparator:   hexutil.Encode(domainSeparator),
+		StructHash:        hexutil.Encode(structHash),
+		Digest:            hexutil.Encode(digest),
+		Message:           message,
+	}
+
+	if typedData.Domain.ChainId != nil {
+		domainChainID := (*big.Int)(typedData.Domain.ChainId)
+		review.ChainID = domainChainID.String()
+		review.ChainIDMismatch = domainChainID.Cmp(network) != 0
+	}
+
+	return review, nil
+}
+
+func (r *TypedDataReview) String() string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s (%s v%s)", r.PrimaryType, r.DomainName, r.DomainVersion)
+	if r.VerifyingContract != "" {
+		fmt.Fprintf(&text, "\ncontract: %s", r.VerifyingContract)
+	}
+
+	if r.ChainID != "" {
+		fmt.Fprintf(&text, "\nchain ID: %s", r.ChainID)
+	}
+
+	if r.ChainIDMismatch {
+		fmt.Fprintf(
+			&text, "\nWARNING: the domain chain ID %s does not match the active network %s", r.ChainID, r.NetworkChainID)
+	}
+
+	for _, field := range r.Message {
+		writeTypedDataField(&text, field, 0)
+	}
+
+	return text.String()
+}
+
+func writeTypedDataField(text *strings.Builder, field *TypedDataField, depth int) {
+	fmt.Fprintf(text, "\n%s%s (%s)", strings.Repeat("  ", depth), field.Name, field.Type)
+	if field.Fields == nil {
+		fmt.Fprintf(text, ": %s", field.Value)
+		return
+	}
+
+	for _, member := range field.Fields {
+		writeTypedDataField(text, member, depth+1)
+	}
+}
+
+func describeTypedStruct(
+	typedData *apitypes.TypedData,
+	typeName string,
+	data map[string]interface{},
+) ([]*TypedDataField, error) {
+	fields := make([]*TypedDataField, 0, len(typedData.Types[typeName]))
+	for _, member := range typedData.Types[typeName] {
+		field, err := describeTypedValue(typedData, member.Name, member.Type, data[member.Name])
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func describeTypedValue(
+	typedData *apitypes.TypedData,
+	name, typeName string,
+	value interface{},
+) (*TypedDataField, error) {
+	field := &TypedDataField{Name: name, Type: typeName}
+	if open := strings.LastIndexByte(typeName, '['); open > 0 && strings.HasSuffix(typeName, "]") {
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("field %s: expected an array for type %s", name, typeName)
+		}
+
+		field.Fields = make([]*TypedDataField, 0, len(items))
+		for i, item := range items {
+			itemField, err := describeTypedValue(typedData, fmt.Sprintf("%s[%d]", name, i), typeName[:open], item)
+			if err != nil {
+				return nil, err
+			}
+			field.Fields = append(field.Fields, itemField)
+		}
+
+		return field, nil
+	}
+
+	if _, ok := typedData.Types[typeName]; ok {
+		members, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("field %s: expected an object for type %s", name, typeName)
+		}
+
+		fields, err := describeTypedStruct(typedData, typeName, members)
+		if err != nil {
+			return nil, err
+		}
+		field.Fields = fields
+
+		return field, nil
+	}
+
+	formatted, err := formatTypedPrimitive(typeName, value)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", name, err)
+	}
+	field.Value = formatted
+
+	return field, nil
+}
+
+func formatTypedPrimitive(typeName string, value interface{}) (string, error) {
+	switch {
+	case typeName == "address":
+		address, ok := value.(string)
+		if !ok || !common.IsHexAddress(address) {
+			return "", fmt.Errorf("invalid address %v", value)
+		}
+		return common.HexToAddress(address).Hex(), nil
+	case typeName == "bool":
+		boolean, ok := value.(bool)
+		if !ok {
+			return "", fmt.Errorf("invalid bool %v", value)
+		}
+		return fmt.Sprintf("%t", boolean), nil
+	case strings.HasPrefix(typeName, "uint"), strings.HasPrefix(typeName, "int"):
+		return formatTypedInteger(value)
+	default:
+		return fmt.Sprintf("%v", value), nil
+	}
+}
+
+func formatTypedInteger(value interface{}) (string, error) {
+	switch number := value.(type) {
+	case string:
+		parsed, ok := new(big.Int).SetString(number, 0)
+		if !ok {
+			return "", fmt.Errorf("invalid integer %q", number)
+		}
+		return parsed.String(), nil
+	case float64:
+		return big.NewFloat(number).Text('f', 0), nil
+	default:
+		return "", fmt.Errorf("invalid integer %v", value)
+	}
+}
@@ -0,0 +1,113 @@
+package eth_test
+
+import (
+	"testing"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// mailTypedData is the example used by the EIP-712 specification.
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+const (
+	mailDomainSeparator = "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"
+	mailStructHash      = "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"
+	mailDigest          = "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"
+)
+
+func TestTypedDataSigning(t *testing.T) {
+	typedData, err := eth.ParseTypedData([]byte(mailTypedData))
+	if err != nil {
+		t.Fatalf("Failed to parse typed data: %v", err)
+	}
+
+	t.Run("Hashes match the specification", func(t *testing.T) {
+		domainSeparator, structHash, digest, err := eth.HashTypedData(typedData)
+		if err != nil {
+			t.Fatalf("Failed to hash typed data: %v", err)
+		}
+
+		assertCorrectValue(t, hexutil.Encode(domainSeparator), mailDomainSeparator)
+		assertCorrectValue(t, hexutil.Encode(structHash), mailStructHash)
+		assertCorrectValue(t, hexutil.Encode(digest), mailDigest)
+	})
+
+	t.Run("Signature matches the specification", func(t *testing.T) {
+		privateKey, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+		if err != nil {
+			t.Fatalf("Failed to create private key: %v", err)
+		}
+
+		signature, err := eth.SignTypedData(typedData, privateKey)
+		if err != nil {
+			t.Fatalf("Failed to sign typed data: %v", err)
+		}
+
+		want := "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+			"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" + "1c"
+		assertCorrectValue(t, signature, want)
+	})
+
+	t.Run("Review decodes nested structs", func(t *testing.T) {
+		review, err := eth.ReviewTypedData(typedData, 1)
+		if err != nil {
+			t.Fatalf("Failed to review typed data: %v", err)
+		}
+
+		assertCorrectValue(t, review.ChainIDMismatch, false)
+		assertCorrectValue(t, review.Message[0].Name, "from")
+		assertCorrectValue(t, review.Message[0].Fields[0].Value, "Cow")
+		assertCorrectValue(t, review.Message[1].Fields[1].Value, "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")
+		assertCorrectValue(t, review.Message[2].Value, "Hello, Bob!")
+	})
+
+	t.Run("Review flags a chain ID different from the network", func(t *testing.T) {
+		review, err := eth.ReviewTypedData(typedData, 31337)
+		if err != nil {
+			t.Fatalf("Failed to review typed data: %v", err)
+		}
+
+		assertCorrectValue(t, review.ChainIDMismatch, true)
+		assertCorrectValue(t, review.ChainID, "1")
+		assertCorrectValue(t, review.NetworkChainID, "31337")
+	})
+
+	t.Run("Undeclared primary type is rejected", func(t *testing.T) {
+		_, err := eth.ParseTypedData([]byte(`{"types": {"EIP712Domain": []}, "primaryType": "Mail"}`))
+		if err == nil {
+			t.Errorf("Expected an error parsing typed data without the primary type")
+		}
+	})
+}
@@ -0,0 +1,428 @@
+package hdwallet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Contact is an entry of the address book. Addresses are stored checksummed.
+type Contact struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Token   string `json:"token"`
+	Network string `json:"network"`
+	Notes   string `json:"notes"`
+}
+
+// RecentRecipient is an address the wallet sent to that is not in the address book yet,
+// offered as a suggestion.
+type RecentRecipient struct {
+	Address  string `json:"address"`
+	Token    string `json:"token"`
+	LastSent string `json:"lastSent"`
+	Count    int    `json:"count"`
+}
+
+const contactColumns = "id, name, address, token, network, notes"
+
+func (ws *WalletStorage) SaveContact(ctx context.Context, contact *Contact) error {
+	if contact.ID != 0 {
+		result, err := ws.db.ExecContext(
+			ctx,
+			"UPDATE contacts SET name = ?, address = ?, token = ?, network = ?, notes = ? WHERE id = ?",
+			contact.Name,
+			contact.Address,
+			contact.Token,
+			contact.Network,
+			contact.Notes,
+			contact.ID,
+		)
+		if err != nil {
+			return contactError(contact, err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error updating contact: %w", err)
+		}
+
+		if rows == 0 {
+			return fmt.Errorf("contact %d not found", contact.ID)
+		}
+
+		return nil
+	}
+
+	result, err := ws.db.ExecContext(
+		ctx,
+		"INSERT INTO contacts (name, address, token, network, notes) VALUES (?, ?, ?, ?, ?)",
+		contact.Name,
+		contact.Address,
+		contact.Token,
+		contact.Network,
+		contact.Notes,
+	)
+	if err != nil {
+		return contactError(contact, err)
+	}
+
+	contact.ID, err = result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("error saving contact: %w", err)
+	}
+
+	return nil
+}
+
+func contactError(contact *Contact, err error) error {
+	if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return fmt.Errorf("a %s contact named %q already exists on %s", contact.Token, contact.Name, contact.Network)
+	}
+
+	return fmt.Errorf("error saving contact: %w", err)
+}
+
+func (ws *WalletStorage) DeleteContact(ctx context.Context, id int64) error {
+	result, err := ws.db.ExecContext(ctx, "DELETE FROM contacts WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("error deleting contact: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error deleting contact: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("contact %d not found", id)
+	}
+
+	return nil
+}
+
+func (ws *WalletStorage) GetContacts(ctx context.Context) ([]Contact, error) {
+	return ws.queryContacts(ctx, "SELECT "+contactColumns+" FROM contacts ORDER BY name")
+}
+
+// GetContactByName finds a contact by name, ignoring case.
+func (ws *WalletStorage) GetContactByName(ctx context.Context, name, token, network string) (*Contact, error) {
+	contacts, err := ws.queryContacts(
+		ctx,
+		"SELECT "+contactColumns+" FROM contacts WHERE name = ? AND token = ? AND network = ?",
+		strings.TrimSpace(name),
+		token,
+		network,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contacts) == 0 {
+		return nil, fmt.Errorf("no %s contact named %q on %s", token, name, network)
+	}
+
+	return &contacts[0], nil
+}
+
+func (ws *WalletStorage) queryContacts(ctx context.Context, query string, args ...interface{}) ([]Contact, error) {
+	rows, err := ws.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving contacts from db: %w", err)
+	}
+	defer rows.Close()
+
+	var contacts []Contact
+	for rows.Next() {
+		var contact Contact
+		err = rows.Scan(&contact.ID, &contact.Name, &contact.Address, &contact.Token, &contact.Network, &contact.Notes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing db contact data: %w", err)
+		}
+
+		contacts = append(contacts, contact)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving contact rows from db: %w", err)
+	}
+
+	return contacts, nil
+}
+
+// GetRecentRecipients returns the recipients of the transactions of a token, most recent
+// first, leaving out the addresses saved in the address book.
+func (ws *WalletStorage) GetRecentRecipients(ctx context.Context, token string, limit int) ([]RecentRecipient, error) {
+	rows, err := ws.db.QueryContext(
+		ctx,
+		`SELECT recipient, MAX(createdAt), COUNT(*) FROM transactions
+		WHERE token = ? AND lower(recipient) NOT IN (SELECT lower(address) FROM contacts WHERE token = ?)
+		GROUP BY lower(recipient)
+		ORDER BY MAX(createdAt) DESC
+		LIMIT ?`,
+		token,
+		token,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving recent recipients from db: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []RecentRecipient
+	for rows.Next() {
+		recipient := RecentRecipient{Token: token}
+		err = rows.Scan(&recipient.Address, &recipient.LastSent, &recipient.Count)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing db recipient data: %w", err)
+		}
+
+		recipients = append(recipients, recipient)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving recipient rows from db: %w", err)
+	}
+
+	return recipients, nil
+}
+
+// GetRecipients returns every address the wallet sent a token to.
+func (ws *WalletStorage) GetRecipients(ctx context.Context, token string) ([]string, error) {
+	rows, err := ws.db.QueryContext(
+		ctx,
+		"SELECT MIN(recipient) FROM transactions WHERE token = ? GROUP BY lower(recipient)",
+		token,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving recipients from db: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []string
+	for rows.Next() {
+		var recipient string
+		err = rows.Scan(&recipient)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing db recipient data: %w", err)
+		}
+
+		recipients = append(recipients, recipient)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving recipient rows from db: %w", err)
+	}
+
+	return recipients, nil
+}
+
+// SaveContact validates a contact and adds it to the address book, or updates it when
+// it has an ID. The network defaults to the one the accounts connect to.
+func (w *Wallet) SaveContact(contact Contact) (*Contact, error) {
+	contact.Name = strings.TrimSpace(contact.Name)
+	contact.Address = strings.TrimSpace(contact.Address)
+	contact.Notes = strings.TrimSpace(contact.Notes)
+	if contact.Name == "" {
+		return nil, fmt.Errorf("contact name is required")
+	}
+
+	if contact.Token != "ETH" {
+		return nil, fmt.Errorf("the address book does not support %s", contact.Token)
+	}
+
+	if !utils.ValidateETHAddress(contact.Address) {
+		return nil, fmt.Errorf("invalid ETH address: %s", contact.Address)
+	}
+	contact.Address = common.HexToAddress(contact.Address).Hex()
+
+	if contact.Network == "" {
+		contact.Network = eth.DefaultNetwork
+	}
+
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	err := w.walletDB.SaveContact(dbCtx, &contact)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contact, nil
+}
+
+func (w *Wallet) RemoveContact(id int64) error {
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	return w.walletDB.DeleteContact(dbCtx, id)
+}
+
+func (w *Wallet) GetContacts() ([]Contact, error) {
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	return w.walletDB.GetContacts(dbCtx)
+}
+
+// ResolveRecipient returns the address a recipient stands for: an address, the name of a
+// contact of the token on the default network, or else an ENS name.
+func (w *Wallet) ResolveRecipient(token, recipient string) (string, error) {
+	recipient = strings.TrimSpace(recipient)
+	if utils.ValidateAddress(recipient, token) {
+		return recipient, nil
+	}
+
+	if utils.ValidateETHAddressFormat(recipient) {
+		return "", fmt.Errorf("invalid %s address checksum: %s", token, recipient)
+	}
+
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	contact, err := w.walletDB.GetContactByName(dbCtx, recipient, token, eth.DefaultNetwork)
+	if err != nil {
+		if eth.IsENSName(recipient) {
+			return w.ResolveName(token, recipient)
+		}
+
+		return "", err
+	}
+
+	return contact.Address, nil
+}
+
+// GetRecentRecipients suggests up to limit addresses to save, among the recipients of
+// past transactions that are neither contacts nor accounts of the wallet.
+func (w *Wallet) GetRecentRecipients(token string, limit int) ([]RecentRecipient, error) {
+	accounts, err := w.GetAllAccounts(token)
+	if err != nil {
+		return nil, err
+	}
+
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	recipients, err := w.walletDB.GetRecentRecipients(dbCtx, token, limit+len(accounts))
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]RecentRecipient, 0, limit)
+	for _, recipient := range recipients {
+		if len(suggestions) == limit {
+			break
+		}
+
+		if !isAccount(accounts, recipient.Address) {
+			suggestions = append(suggestions, recipient)
+		}
+	}
+
+	return suggestions, nil
+}
+
+func isAccount(accounts map[int]string, address string) bool {
+	for _, account := range accounts {
+		if strings.EqualFold(account, address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// knownAddresses lists the addresses a recipient of token is compared with to detect
+// address poisoning: the contacts, the past recipients and the accounts of the wallet.
+func (w *Wallet) knownAddresses(token string) ([]eth.KnownAddress, error) {
+	accounts, err := w.GetAllAccounts(token)
+	if err != nil {
+		return nil, err
+	}
+
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	contacts, err := w.walletDB.GetContacts(dbCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, err := w.walletDB.GetRecipients(dbCtx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var known []eth.KnownAddress
+	for idx, address := range accounts {
+		known = append(known, eth.KnownAddress{Address: address, Label: fmt.Sprintf("account %d", idx)})
+	}
+
+	for _, contact := range contacts {
+		if contact.Token == token {
+			known = append(known, eth.KnownAddress{Address: contact.Address, Label: "contact " + contact.Name})
+		}
+	}
+
+	for _, recipient := range recipients {
+		known = append(known, eth.KnownAddress{Address: recipient, Label: "a past recipient"})
+	}
+
+	return known, nil
+}
+
+// CheckRecipient compares to with the addresses known to the wallet and returns the
+// warnings about it: a lookalike of a known address or a first-time recipient. Sending
+// to a recipient with warnings requires confirming it first with ConfirmRecipient.
+func (w *Wallet) CheckRecipient(token, to string) ([]eth.ReviewIssue, error) {
+	if !common.IsHexAddress(to) {
+		return nil, fmt.Errorf("invalid recipient address: %s", to)
+	}
+
+	known, err := w.knownAddresses(token)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving known %s addresses: %w", token, err)
+	}
+
+	review := &eth.TransactionReview{To: common.HexToAddress(to).Hex()}
+	review.CheckRecipient(known)
+	return review.Warnings, nil
+}
+
+// ConfirmRecipient records that the user checked the recipient address, which allows the
+// next send of token to it.
+func (w *Wallet) ConfirmRecipient(token, to string) {
+	w.confirmedMu.Lock()
+	defer w.confirmedMu.Unlock()
+	if w.confirmedRecipients == nil {
+		w.confirmedRecipients = make(map[string]bool)
+	}
+	w.confirmedRecipients[recipientKey(token, to)] = true
+}
+
+// requireConfirmedRecipient fails when to has warnings and was not confirmed. A
+// confirmation is only used once.
+func (w *Wallet) requireConfirmedRecipient(token, to string) error {
+	warnings, err := w.CheckRecipient(token, to)
+	if err != nil {
+		return err
+	}
+
+	w.confirmedMu.Lock()
+	defer w.confirmedMu.Unlock()
+	key := recipientKey(token, to)
+	confirmed := w.confirmedRecipients[key]
+	delete(w.confirmedRecipients, key)
+	if len(warnings) > 0 && !confirmed {
+		return fmt.Errorf("recipient %s must be confirmed: %s", to, warnings[0].Message)
+	}
+
+	return nil
+}
+
+func recipientKey(token, address string) string {
+	return token + ":" + strings.ToLower(address)
+}
@@ -0,0 +1,161 @@
+package hdwallet_test
+
+import (
+	"strings"
+	"testing"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethsim"
+	"wallet/internal/hdwallet"
+)
+
+func TestAddressBook(t *testing.T) {
+	backend, err := ethsim.NewBackend(map[string]int64{account0: 100})
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer backend.Close()
+
+	wallet := newSimulatedWallet(t, backend)
+	const burn = "0x000000000000000000000000000000000000dEaD"
+
+	t.Run("Contacts are validated and checksummed", func(t *testing.T) {
+		contact, err := wallet.SaveContact(hdwallet.Contact{
+			Name:    " Alice ",
+			Address: strings.ToLower(account1),
+			Token:   "ETH",
+			Notes:   "hardhat account 1",
+		})
+		if err != nil {
+			t.Fatalf("Failed to save contact: %v", err)
+		}
+
+		assertCorrectValue(t, *contact, hdwallet.Contact{
+			ID:      contact.ID,
+			Name:    "Alice",
+			Address: account1,
+			Token:   "ETH",
+			Network: eth.DefaultNetwork,
+			Notes:   "hardhat account 1",
+		})
+
+		badChecksum := "0x70997970c51812dc3a010c7d01b50e0d17dc79C8"
+		invalid := []struct {
+			contact hdwallet.Contact
+			wantErr string
+		}{
+			{contact: hdwallet.Contact{Name: "Bob", Address: badChecksum, Token: "ETH"}, wantErr: "invalid ETH address"},
+			{contact: hdwallet.Contact{Name: " ", Address: account1, Token: "ETH"}, wantErr: "name is required"},
+			{contact: hdwallet.Contact{Name: "Bob", Address: account1, Token: "BTC"}, wantErr: "does not support BTC"},
+			{contact: hdwallet.Contact{Name: "alice", Address: account0, Token: "ETH"}, wantErr: "already exists"},
+		}
+		for _, tc := range invalid {
+			_, err := wallet.SaveContact(tc.contact)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected %q saving %+v, got %v", tc.wantErr, tc.contact, err)
+			}
+		}
+	})
+
+	t.Run("Recipients are resolved by contact name", func(t *testing.T) {
+		address, err := wallet.ResolveRecipient("ETH", "ALICE")
+		if err != nil {
+			t.Fatalf("Failed to resolve contact: %v", err)
+		}
+		assertCorrectValue(t, address, account1)
+
+		address, err = wallet.ResolveRecipient("ETH", burn)
+		if err != nil {
+			t.Fatalf("Failed to resolve address: %v", err)
+		}
+		assertCorrectValue(t, address, burn)
+
+		_, err = wallet.ResolveRecipient("ETH", "Carol")
+		if err == nil || !strings.Contains(err.Error(), `no ETH contact named "Carol"`) {
+			t.Errorf("Expected an unknown contact error, got %v", err)
+		}
+
+		// Names that are not contacts are resolved with ENS, which the simulated chain lacks.
+		_, err = wallet.ResolveRecipient("ETH", "carol.eth")
+		if err == nil || !strings.Contains(err.Error(), "ENS name carol.eth does not resolve") {
+			t.Errorf("Expected an unresolved ENS name error, got %v", err)
+		}
+	})
+
+	t.Run("Recent recipients leave out contacts and accounts", func(t *testing.T) {
+		// The burn address was never sent to, so it has to be confirmed first.
+		_, err := wallet.SendTransaction("ETH", testPassword, burn, "1", 0)
+		if err == nil || !strings.Contains(err.Error(), "must be confirmed: You have never sent to this address") {
+			t.Fatalf("Expected the first-time recipient to need confirmation, got %v", err)
+		}
+		wallet.ConfirmRecipient("ETH", burn)
+
+		for _, to := range []string{account1, burn, strings.ToLower(burn)} {
+			_, err := wallet.SendTransaction("ETH", testPassword, to, "1", 0)
+			if err != nil {
+				t.Fatalf("Failed to send transaction: %v", err)
+			}
+		}
+		backend.Commit()
+
+		suggestions, err := wallet.GetRecentRecipients("ETH", 5)
+		if err != nil {
+			t.Fatalf("Failed to get recent recipients: %v", err)
+		}
+		assertCorrectValue(t, len(suggestions), 1)
+		assertCorrectValue(t, strings.ToLower(suggestions[0].Address), strings.ToLower(burn))
+		assertCorrectValue(t, suggestions[0].Count, 2)
+
+		// Account 1 is both a contact and an account of the wallet.
+		contacts, err := wallet.GetContacts()
+		if err != nil {
+			t.Fatalf("Failed to get contacts: %v", err)
+		}
+		assertCorrectValue(t, len(contacts), 1)
+
+		err = wallet.RemoveContact(contacts[0].ID)
+		if err != nil {
+			t.Fatalf("Failed to remove contact: %v", err)
+		}
+
+		err = wallet.RemoveContact(contacts[0].ID)
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("Expected removing a removed contact to fail, got %v", err)
+		}
+
+		suggestions, err = wallet.GetRecentRecipients("ETH", 5)
+		if err != nil {
+			t.Fatalf("Failed to get recent recipients: %v", err)
+		}
+		assertCorrectValue(t, len(suggestions), 1)
+	})
+	t.Run("Lookalikes of past recipients need confirmation", func(t *testing.T) {
+		review, err := wallet.ReviewTransaction("ETH", burn, "1", 0)
+		if err != nil {
+			t.Fatalf("Failed to review transaction: %v", err)
+		}
+		assertCorrectValue(t, review.ConfirmRecipient, false)
+		assertCorrectValue(t, len(review.Warnings), 0)
+
+		poisoned := "0x0000f00000000000000000000000000000c0dead"
+		review, err = wallet.ReviewTransaction("ETH", poisoned, "1", 0)
+		if err != nil {
+			t.Fatalf("Failed to review transaction: %v", err)
+		}
+		assertCorrectValue(t, review.ConfirmRecipient, true)
+		if !review.HasIssue(eth.IssueLookalikeAddress) || !review.HasIssue(eth.IssueFirstTimeRecipient) {
+			t.Errorf("Expected the lookalike and first time warnings, got %+v", review.Warnings)
+		}
+
+		// A confirmation allows a single send, and lookalikes stay flagged once sent to.
+		wallet.ConfirmRecipient("ETH", poisoned)
+		_, err = wallet.SendTransaction("ETH", testPassword, poisoned, "1", 0)
+		if err != nil {
+			t.Fatalf("Failed to send transaction: %v", err)
+		}
+
+		_, err = wallet.SendTransaction("ETH", testPassword, poisoned, "1", 0)
+		if err == nil || !strings.Contains(err.Error(), "looks like") {
+			t.Errorf("Expected the lookalike to need a new confirmation, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package hdwallet
+
+import (
+	"fmt"
+	"wallet/internal/currencies/eth"
+)
+
+// GetAllowances lists the ERC-20 allowances the accounts of the wallet still grant.
+func (w *Wallet) GetAllowances(token string) ([]eth.Allowance, error) {
+	allowanceAcc, err := w.allowanceAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	allowances, err := allowanceAcc.ScanAllowances()
+	if err != nil {
+		return nil, fmt.Errorf("error scanning %s allowances: %w", token, err)
+	}
+
+	return allowances, nil
+}
+
+// RevokeAllowance sets the allowance an account granted to spender on an ERC-20 contract
+// back to zero.
+func (w *Wallet) RevokeAllowance(token, password, contract, spender string, accountIndex int) (string, error) {
+	allowanceAcc, err := w.allowanceAccount(token)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := allowanceAcc.RevokeAllowanceRequest(contract, spender, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error building revoke transaction: %w", err)
+	}
+
+	return w.sendTransactionRequest(token, password, req)
+}
+
+func (w *Wallet) allowanceAccount(token string) (allowanceAccount, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	allowanceAcc, ok := masterAcc.(allowanceAccount)
+	if !ok {
+		return nil, fmt.Errorf("token %s does not support token allowances", token)
+	}
+
+	return allowanceAcc, nil
+}
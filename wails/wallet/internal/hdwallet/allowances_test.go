@@ -0,0 +1,100 @@
+package hdwallet_test
+
+import (
+	"math/big"
+	"testing"
+	"wallet/internal/contracts"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethsim"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestAllowances(t *testing.T) {
+	backend, err := ethsim.NewBackend(map[string]int64{account0: 100})
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer backend.Close()
+
+	wallet := newSimulatedWallet(t, backend)
+	token := deployDemoToken(t, backend)
+
+	const (
+		router = "0x00000000000000000000000000000000000A1100"
+		market = "0x00000000000000000000000000000000000a1200"
+	)
+
+	// Account 0 approves a router for unlimited spending and a market for 1.5 tokens.
+	privateKey, err := crypto.HexToECDSA(account0Key)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(ethsim.ChainID))
+	if err != nil {
+		t.Fatalf("Failed to create transactor: %v", err)
+	}
+
+	demoToken, err := contracts.NewDemoTokenTransactor(
+		common.HexToAddress(token), eth.NewContractBackend(eth.NewClientWithBackend(backend)))
+	if err != nil {
+		t.Fatalf("Failed to bind DemoToken: %v", err)
+	}
+
+	approvals := map[string]*big.Int{router: math.MaxBig256, market: big.NewInt(15e17)}
+	for spender, amount := range approvals {
+		_, err = demoToken.Approve(opts, common.HexToAddress(spender), amount)
+		if err != nil {
+			t.Fatalf("Failed to approve %s: %v", spender, err)
+		}
+		backend.Commit()
+	}
+
+	allowances, err := wallet.GetAllowances("ETH")
+	if err != nil {
+		t.Fatalf("Failed to get allowances: %v", err)
+	}
+
+	assertCorrectValue(t, len(allowances), 2)
+	assertCorrectValue(t, allowances[0].Spender, common.HexToAddress(router).Hex())
+	assertCorrectValue(t, allowances[0].Unlimited, true)
+	assertCorrectValue(t, allowances[1].Spender, common.HexToAddress(market).Hex())
+	assertCorrectValue(t, allowances[1].Amount, "1.5")
+	assertCorrectValue(t, allowances[1].Owner, account0)
+	assertCorrectValue(t, allowances[1].OwnerIndex, 0)
+
+	_, err = wallet.RevokeAllowance("ETH", testPassword, token, router, 0)
+	if err != nil {
+		t.Fatalf("Failed to revoke allowance: %v", err)
+	}
+	backend.Commit()
+
+	allowances, err = wallet.GetAllowances("ETH")
+	if err != nil {
+		t.Fatalf("Failed to get allowances: %v", err)
+	}
+
+	assertCorrectValue(t, len(allowances), 1)
+	assertCorrectValue(t, allowances[0].Spender, common.HexToAddress(market).Hex())
+
+	// Later scans only read the new blocks, and still list the approvals found before.
+	_, err = demoToken.Approve(opts, common.HexToAddress(router), big.NewInt(2e18))
+	if err != nil {
+		t.Fatalf("Failed to approve %s: %v", router, err)
+	}
+	backend.Commit()
+
+	allowances, err = wallet.GetAllowances("ETH")
+	if err != nil {
+		t.Fatalf("Failed to get allowances: %v", err)
+	}
+
+	assertCorrectValue(t, len(allowances), 2)
+	assertCorrectValue(t, allowances[0].Spender, common.HexToAddress(router).Hex())
+	assertCorrectValue(t, allowances[0].Amount, "2")
+	assertCorrectValue(t, allowances[1].Spender, common.HexToAddress(market).Hex())
+}
@@ -0,0 +1,52 @@
+package hdwallet
+
+import (
+	"fmt"
+	"wallet/internal/currencies/eth"
+)
+
+// DecodeCall describes what the data of a transaction to a contract does, with the risks
+// of the well-known token methods it calls.
+func (w *Wallet) DecodeCall(token, to string, data []byte) (*eth.DecodedCall, error) {
+	callAcc, err := w.callAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	call, err := callAcc.DecodeCall(to, data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s call data: %w", token, err)
+	}
+
+	return call, nil
+}
+
+// LoadSignatureDatabase loads the offline signature database used to decode calls to
+// contracts missing from the ABI registry, and returns the number of signatures it holds.
+func (w *Wallet) LoadSignatureDatabase(token, path string) (int, error) {
+	callAcc, err := w.callAccount(token)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := callAcc.LoadSignatureDatabase(path)
+	if err != nil {
+		return 0, fmt.Errorf("error loading %s signature database: %w", token, err)
+	}
+
+	return count, nil
+}
+
+func (w *Wallet) callAccount(token string) (callAccount, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	callAcc, ok := masterAcc.(callAccount)
+	if !ok {
+		return nil, fmt.Errorf("token %s does not support call decoding", token)
+	}
+
+	return callAcc, nil
+}
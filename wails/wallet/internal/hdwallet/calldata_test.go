@@ -0,0 +1,81 @@
+package hdwallet_test
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"wallet/internal/contracts"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethsim"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestDecodeCall(t *testing.T) {
+	backend, err := ethsim.NewBackend(map[string]int64{account0: 100})
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer backend.Close()
+
+	wallet := newSimulatedWallet(t, backend)
+	token := deployDemoToken(t, backend)
+	tokenABI, err := contracts.DemoTokenMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("Failed to parse ABI: %v", err)
+	}
+
+	t.Run("Token calls are decoded in the units of the token", func(t *testing.T) {
+		amount, _ := new(big.Int).SetString("2500000000000000000", 10)
+		data, err := tokenABI.Pack("transfer", common.HexToAddress(account1), amount)
+		if err != nil {
+			t.Fatalf("Failed to pack transfer: %v", err)
+		}
+
+		call, err := wallet.DecodeCall("ETH", token, data)
+		if err != nil {
+			t.Fatalf("Failed to decode call: %v", err)
+		}
+
+		assertCorrectValue(t, call.Source, eth.CallSourceKnown)
+		assertCorrectValue(t, call.Summary, "Send 2.5 DT to "+account1)
+	})
+
+	t.Run("Loaded signatures decode calls unknown to the registry", func(t *testing.T) {
+		data := hexutil.MustDecode("0x0121b93f000000000000000000000000000000000000000000000000000000000000000c")
+		call, err := wallet.DecodeCall("ETH", token, data)
+		if err != nil {
+			t.Fatalf("Failed to decode call: %v", err)
+		}
+		assertCorrectValue(t, call.HasRisk(eth.RiskUnknownCall), true)
+
+		path := filepath.Join(t.TempDir(), "signatures.txt")
+		err = os.WriteFile(path, []byte("0x0121b93f vote(uint256)\n"), 0o600)
+		if err != nil {
+			t.Fatalf("Failed to write signature database: %v", err)
+		}
+
+		count, err := wallet.LoadSignatureDatabase("ETH", path)
+		if err != nil {
+			t.Fatalf("Failed to load signature database: %v", err)
+		}
+		assertCorrectValue(t, count, 1)
+
+		call, err = wallet.DecodeCall("ETH", token, data)
+		if err != nil {
+			t.Fatalf("Failed to decode call: %v", err)
+		}
+		assertCorrectValue(t, call.Signature, "vote(uint256)")
+		assertCorrectValue(t, call.Args[0].Value, "12")
+	})
+
+	t.Run("Invalid contract addresses are rejected", func(t *testing.T) {
+		_, err := wallet.DecodeCall("ETH", "0x1234", nil)
+		if err == nil || !strings.Contains(err.Error(), "invalid contract address: 0x1234") {
+			t.Fatalf("Expected an invalid address error, got %v", err)
+		}
+	})
+}
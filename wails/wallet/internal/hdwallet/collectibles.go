@@ -0,0 +1,108 @@
+package hdwallet
+
+import (
+	"fmt"
+	"wallet/internal/currencies/eth"
+)
+
+// GetCollectibles lists the ERC-721 and ERC-1155 tokens held by the accounts of the wallet.
+func (w *Wallet) GetCollectibles(token string) ([]eth.Collectible, error) {
+	collectibleAcc, err := w.collectibleAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	collectibles, err := collectibleAcc.GetCollectibles()
+	if err != nil {
+		return nil, fmt.Errorf("error scanning %s collectibles: %w", token, err)
+	}
+
+	return collectibles, nil
+}
+
+// LastCollectibles returns the collectibles found by the last GetCollectibles scan of a
+// token, without reading the chain. It is empty before the first scan and for tokens
+// without collectibles.
+func (w *Wallet) LastCollectibles(token string) []eth.Collectible {
+	collectibleAcc, err := w.collectibleAccount(token)
+	if err != nil {
+		return nil
+	}
+
+	return collectibleAcc.LastCollectibles()
+}
+
+// TransferCollectible sends an ERC-721 token from an account. The recipient can be
+// an address, a contact name or an ENS name, and like for SendTransaction, a flagged
+// recipient must have been confirmed.
+func (w *Wallet) TransferCollectible(
+	token, password, contract, tokenID, recipient string,
+	accountIndex int,
+) (string, error) {
+	collectibleAcc, err := w.collectibleAccount(token)
+	if err != nil {
+		return "", err
+	}
+
+	to, err := w.ResolveRecipient(token, recipient)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := collectibleAcc.CollectibleTransferRequest(contract, tokenID, to, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error building collectible transfer: %w", err)
+	}
+
+	err = w.requireConfirmedRecipient(token, to)
+	if err != nil {
+		return "", err
+	}
+
+	return w.sendTransactionRequest(token, password, req)
+}
+
+// TransferMultiTokens sends units of one or more ERC-1155 token IDs of a contract from an
+// account, in a single transaction. The recipient can be an address, a contact name or
+// an ENS name, and a flagged recipient must have been confirmed.
+func (w *Wallet) TransferMultiTokens(
+	token, password, contract, recipient string,
+	ids, amounts []string,
+	accountIndex int,
+) (string, error) {
+	collectibleAcc, err := w.collectibleAccount(token)
+	if err != nil {
+		return "", err
+	}
+
+	to, err := w.ResolveRecipient(token, recipient)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := collectibleAcc.MultiTokenTransferRequest(contract, to, ids, amounts, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error building multi-token transfer: %w", err)
+	}
+
+	err = w.requireConfirmedRecipient(token, to)
+	if err != nil {
+		return "", err
+	}
+
+	return w.sendTransactionRequest(token, password, req)
+}
+
+func (w *Wallet) collectibleAccount(token string) (collectibleAccount, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	collectibleAcc, ok := masterAcc.(collectibleAccount)
+	if !ok {
+		return nil, fmt.Errorf("token %s does not support collectibles", token)
+	}
+
+	return collectibleAcc, nil
+}
@@ -0,0 +1,223 @@
+package hdwallet_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethsim"
+	"wallet/internal/hdwallet"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestCollectibles(t *testing.T) {
+	backend, err := ethsim.NewBackend(map[string]int64{account0: 100})
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer backend.Close()
+
+	wallet := newSimulatedWallet(t, backend)
+
+	// Minting the DemoToken supply to account 0 emits an ERC-20 Transfer event, which
+	// shares the signature of ERC-721 transfers.
+	token := deployDemoToken(t, backend)
+
+	collectibles, err := wallet.GetCollectibles("ETH")
+	if err != nil {
+		t.Fatalf("Failed to get collectibles: %v", err)
+	}
+	assertCorrectValue(t, len(collectibles), 0)
+
+	invalid := []struct {
+		contract, tokenID, recipient string
+		wantErr                      string
+	}{
+		{contract: token, tokenID: "one", recipient: account1, wantErr: `invalid token ID "one"`},
+		{contract: token, tokenID: "1", recipient: "carol", wantErr: `no ETH contact named "carol"`},
+		// DemoToken has no safeTransferFrom, so the transfer reverts in the preflight estimate.
+		{contract: token, tokenID: "1", recipient: account1, wantErr: "revert"},
+	}
+	for _, tc := range invalid {
+		_, err := wallet.TransferCollectible("ETH", testPassword, tc.contract, tc.tokenID, tc.recipient, 0)
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("Expected %q transferring %s #%s, got %v", tc.wantErr, tc.contract, tc.tokenID, err)
+		}
+	}
+
+	multiInvalid := []struct {
+		ids, amounts []string
+		wantErr      string
+	}{
+		{ids: []string{"1", "2"}, amounts: []string{"1"}, wantErr: "an amount for each of the 2 token IDs, got 1"},
+		{ids: []string{"1"}, amounts: []string{"0"}, wantErr: `invalid amount "0" of token 1`},
+		{ids: []string{"1", "2"}, amounts: []string{"1", "3"}, wantErr: "revert"},
+	}
+	for _, tc := range multiInvalid {
+		_, err := wallet.TransferMultiTokens("ETH", testPassword, token, account1, tc.ids, tc.amounts, 0)
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("Expected %q transferring %v x %v, got %v", tc.wantErr, tc.ids, tc.amounts, err)
+		}
+	}
+
+	// A lookalike of account 1 is refused before anything is sent.
+	const poisoned = "0x70990000000000000000000000000000000079C8"
+	_, err = wallet.TransferMultiTokens("ETH", testPassword, token, poisoned, []string{"1"}, []string{"1"}, 0)
+	if err == nil || !strings.Contains(err.Error(), "must be confirmed") || !strings.Contains(err.Error(), "looks like") {
+		t.Errorf("Expected the lookalike recipient to need confirmation, got %v", err)
+	}
+}
+
+func TestDemoCollection(t *testing.T) {
+	backend, err := ethsim.NewBackend(map[string]int64{account0: 100})
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer backend.Close()
+
+	wallet := newSimulatedWallet(t, backend)
+	collection := deployDemoCollection(t, backend, 3)
+	token := deployDemoToken(t, backend)
+	assertCorrectValue(t, len(wallet.LastCollectibles("ETH")), 0)
+
+	collectibles, err := wallet.GetCollectibles("ETH")
+	if err != nil {
+		t.Fatalf("Failed to get collectibles: %v", err)
+	}
+
+	// The last scan is kept for GetAssets.
+	assertCorrectValue(t, wallet.LastCollectibles("ETH"), collectibles)
+
+	// The metadata is served on chain as a data URI.
+	assertCorrectValue(t, len(collectibles), 3)
+	assertCorrectValue(t, strings.HasPrefix(collectibles[0].URI, "data:application/json;base64,"), true)
+	assertCorrectValue(t, collectibles[0], eth.Collectible{
+		Standard: eth.StandardERC721,
+		Contract: collection,
+		Name:     "DemoCollection",
+		Symbol:   "DC",
+		TokenID:  "1",
+		Owner:    account0,
+		Balance:  "1",
+		URI:      collectibles[0].URI,
+		Metadata: &eth.TokenMetadata{Name: "Demo #1", Description: "Token 1 of the DemoCollection"},
+	})
+
+	// A lookalike of account 1 is refused until it is confirmed.
+	const poisoned = "0x70990000000000000000000000000000000079C8"
+	_, err = wallet.TransferCollectible("ETH", testPassword, collection, "2", poisoned, 0)
+	if err == nil || !strings.Contains(err.Error(), "must be confirmed") || !strings.Contains(err.Error(), "looks like") {
+		t.Errorf("Expected the lookalike recipient to need confirmation, got %v", err)
+	}
+
+	// Token 2 goes to account 1 of the wallet, and token 3 out of it.
+	const market = "0x00000000000000000000000000000000000A1200"
+	wallet.ConfirmRecipient("ETH", market)
+	for tokenID, recipient := range map[string]string{"2": account1, "3": market} {
+		_, err = wallet.TransferCollectible("ETH", testPassword, collection, tokenID, recipient, 0)
+		if err != nil {
+			t.Fatalf("Failed to transfer token %s: %v", tokenID, err)
+		}
+		backend.Commit()
+	}
+
+	// Contracts that do not implement onERC721Received cannot receive the tokens.
+	wallet.ConfirmRecipient("ETH", token)
+	_, err = wallet.TransferCollectible("ETH", testPassword, collection, "1", token, 0)
+	if err == nil || !strings.Contains(err.Error(), "revert") {
+		t.Errorf("Expected the transfer to a non-receiver contract to revert, got %v", err)
+	}
+
+	collectibles, err = wallet.GetCollectibles("ETH")
+	if err != nil {
+		t.Fatalf("Failed to get collectibles: %v", err)
+	}
+
+	assertCorrectValue(t, len(collectibles), 2)
+	assertCorrectValue(t, collectibles[0].TokenID, "1")
+	assertCorrectValue(t, collectibles[0].OwnerIndex, 0)
+	assertCorrectValue(t, collectibles[1].TokenID, "2")
+	assertCorrectValue(t, collectibles[1].Owner, account1)
+	assertCorrectValue(t, collectibles[1].OwnerIndex, 1)
+	assertCorrectValue(t, wallet.LastCollectibles("ETH"), collectibles)
+}
+
+func TestWatchTokenTransfers(t *testing.T) {
+	backend, err := ethsim.NewBackend(map[string]int64{account0: 100})
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer backend.Close()
+
+	wallet := newSimulatedWallet(t, backend)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	transfers := make(chan eth.TokenTransfer, 10)
+	err = wallet.Watch(ctx, "ETH", hdwallet.WatchHandlers{
+		OnTokenTransfer: func(transfer eth.TokenTransfer) { transfers <- transfer },
+	})
+	if err != nil {
+		t.Fatalf("Failed to watch the chain: %v", err)
+	}
+
+	// The token minted to account 0 is pushed, and so is the one it then sends to account 1.
+	collection := deployDemoCollection(t, backend, 1)
+	transfer := receive(t, transfers)
+	assertCorrectValue(t, transfer.Contract, collection)
+	assertCorrectValue(t, transfer.To, account0)
+
+	_, err = wallet.TransferCollectible("ETH", testPassword, collection, "1", account1, 0)
+	if err != nil {
+		t.Fatalf("Failed to transfer the token: %v", err)
+	}
+	backend.Commit()
+
+	transfer = receive(t, transfers)
+	assertCorrectValue(t, transfer.Contract, collection)
+	assertCorrectValue(t, transfer.To, account1)
+}
+
+func deployDemoCollection(t testing.TB, backend *ethsim.Backend, initialTokens int64) string {
+	t.Helper()
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode string          `json:"bytecode"`
+	}
+	err := json.Unmarshal(readArtifact(t, "DemoCollection"), &artifact)
+	if err != nil {
+		t.Fatalf("Failed to parse DemoCollection artifact: %v", err)
+	}
+
+	parsed, err := abi.JSON(bytes.NewReader(artifact.ABI))
+	if err != nil {
+		t.Fatalf("Failed to parse DemoCollection ABI: %v", err)
+	}
+
+	privateKey, err := crypto.HexToECDSA(account0Key)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(ethsim.ChainID))
+	if err != nil {
+		t.Fatalf("Failed to create transactor: %v", err)
+	}
+
+	contractBackend := eth.NewContractBackend(eth.NewClientWithBackend(backend))
+	address, _, _, err := bind.DeployContract(
+		opts, parsed, hexutil.MustDecode(artifact.Bytecode), contractBackend, big.NewInt(initialTokens))
+	if err != nil {
+		t.Fatalf("Failed to deploy DemoCollection: %v", err)
+	}
+	backend.Commit()
+
+	return address.Hex()
+}
@@ -0,0 +1,124 @@
+package hdwallet_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"wallet/internal/contracts"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethsim"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestDemoContracts(t *testing.T) {
+	backend, err := ethsim.NewBackend(map[string]int64{account0: 100, account1: 100})
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer backend.Close()
+
+	wallet := newSimulatedWallet(t, backend)
+
+	t.Run("DemoToken balance is read by registered name", func(t *testing.T) {
+		token := deployDemoToken(t, backend)
+		_, err := wallet.RegisterContract("ETH", "DemoToken", token, readArtifact(t, "DemoToken"))
+		if err != nil {
+			t.Fatalf("Failed to register contract: %v", err)
+		}
+
+		balance, err := wallet.GetDemoTokenBalance("ETH", "DemoToken", 0)
+		if err != nil {
+			t.Fatalf("Failed to get token balance: %v", err)
+		}
+
+		assertCorrectValue(t, *balance, eth.TokenBalance{
+			Name:     "DemoToken",
+			Symbol:   "DT",
+			Decimals: 18,
+			Balance:  "1000000",
+		})
+
+		_, err = wallet.GetDemoTokenBalance("ETH", "Unknown", 0)
+		if err == nil {
+			t.Errorf("Expected unregistered contract names to be rejected")
+		}
+	})
+
+	t.Run("Proposals are created and voted on", func(t *testing.T) {
+		contract := deployVotingProposal(t, backend)
+		_, err := wallet.CreateProposal("ETH", testPassword, contract, "Fund the demo", 1, 0)
+		if err != nil {
+			t.Fatalf("Failed to create proposal: %v", err)
+		}
+		backend.Commit()
+
+		proposal, err := wallet.GetCurrentProposal("ETH", contract)
+		if err != nil {
+			t.Fatalf("Failed to read proposal: %v", err)
+		}
+		assertCorrectValue(t, proposal.Description, "Fund the demo")
+		assertCorrectValue(t, proposal.IsActive, true)
+
+		choice, err := eth.ParseVoteChoice(" Approve ")
+		if err != nil {
+			t.Fatalf("Failed to parse vote: %v", err)
+		}
+
+		_, err = wallet.VoteProposal("ETH", testPassword, contract, choice, 1)
+		if err != nil {
+			t.Fatalf("Failed to vote: %v", err)
+		}
+		backend.Commit()
+
+		proposal, err = wallet.GetCurrentProposal("ETH", contract)
+		if err != nil {
+			t.Fatalf("Failed to read proposal: %v", err)
+		}
+		assertCorrectValue(t, *proposal, eth.Proposal{
+			Description:    "Fund the demo",
+			Approve:        "1",
+			Reject:         "0",
+			Pass:           "0",
+			TotalVoteToEnd: "1",
+			CurrentState:   true,
+			IsActive:       false,
+		})
+
+		// The proposal ended with the single vote it needed.
+		_, err = wallet.VoteProposal("ETH", testPassword, contract, eth.VoteReject, 1)
+		if err == nil || !strings.Contains(err.Error(), "the proposal is not active") {
+			t.Errorf("Expected votes on ended proposals to revert, got %v", err)
+		}
+	})
+
+	t.Run("Invalid vote choices are rejected", func(t *testing.T) {
+		_, err := eth.ParseVoteChoice("maybe")
+		if err == nil || !strings.Contains(err.Error(), `invalid vote "maybe"`) {
+			t.Errorf("Expected an invalid vote error, got %v", err)
+		}
+	})
+}
+
+func deployVotingProposal(t testing.TB, backend *ethsim.Backend) string {
+	t.Helper()
+	privateKey, err := crypto.HexToECDSA(account0Key)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(ethsim.ChainID))
+	if err != nil {
+		t.Fatalf("Failed to create transactor: %v", err)
+	}
+
+	contractBackend := eth.NewContractBackend(eth.NewClientWithBackend(backend))
+	address, _, _, err := contracts.DeployVotingProposal(opts, contractBackend)
+	if err != nil {
+		t.Fatalf("Failed to deploy VotingProposal: %v", err)
+	}
+	backend.Commit()
+
+	return address.Hex()
+}
@@ -0,0 +1,83 @@
+package hdwallet
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"wallet/internal/currencies/eth"
+)
+
+// EstimateDeploymentGas returns the fee of deploying a contract artifact from an account.
+func (w *Wallet) EstimateDeploymentGas(
+	token string,
+	artifact []byte,
+	args []string,
+	value string,
+	accountIndex int,
+) (string, error) {
+	deploymentAcc, err := w.deploymentAccount(token)
+	if err != nil {
+		return "", err
+	}
+
+	cost, err := deploymentAcc.EstimateDeploymentGas(artifact, args, value, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error estimating %s deployment gas: %w", token, err)
+	}
+
+	return cost, nil
+}
+
+// DeployContract deploys a contract artifact from an account and waits for it to be mined.
+// The deployment is recorded in the transaction history, with the contract as recipient,
+// and the contract is registered under name, or the name of the artifact when empty.
+func (w *Wallet) DeployContract(
+	token, password, name string,
+	artifact []byte,
+	args []string,
+	value string,
+	accountIndex int,
+) (*eth.Deployment, error) {
+	deploymentAcc, err := w.deploymentAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := deploymentAcc.DeploymentRequest(artifact, args, value, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error building %s deployment: %w", token, err)
+	}
+
+	hash, err := w.sendTransactionRequest(token, password, req)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := deploymentAcc.WaitForDeployment(name, artifact, hash)
+	if err != nil {
+		return nil, fmt.Errorf("error deploying %s contract in transaction %s: %w", token, hash, err)
+	}
+
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	err = w.walletDB.UpdateTransactionStatus(dbCtx, hash, StatusCompleted)
+	if err != nil {
+		return deployment, err
+	}
+
+	return deployment, nil
+}
+
+func (w *Wallet) deploymentAccount(token string) (deploymentAccount, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	deploymentAcc, ok := masterAcc.(deploymentAccount)
+	if !ok {
+		return nil, fmt.Errorf("token %s does not support contract deployment", token)
+	}
+
+	return deploymentAcc, nil
+}
@@ -0,0 +1,89 @@
+package hdwallet_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"wallet/internal/currencies/eth/ethsim"
+	"wallet/internal/hdwallet"
+)
+
+func TestDeployContract(t *testing.T) {
+	backend, err := ethsim.NewBackend(map[string]int64{account0: 100})
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer backend.Close()
+
+	wallet := newSimulatedWallet(t, backend)
+	artifact := readArtifact(t, "Increment")
+
+	invalid := []struct {
+		args    []string
+		value   string
+		wantErr string
+	}{
+		{args: []string{"7"}, wantErr: "expects 2 arguments, got 1"},
+		{args: []string{"seven", "demo"}, wantErr: `invalid integer "seven"`},
+		{args: []string{"7", "demo"}, value: "1", wantErr: "constructor of Increment is not payable"},
+	}
+	for _, tc := range invalid {
+		_, err := wallet.EstimateDeploymentGas("ETH", artifact, tc.args, tc.value, 0)
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("Expected %q deploying with %v, got %v", tc.wantErr, tc.args, err)
+		}
+	}
+
+	cost, err := wallet.EstimateDeploymentGas("ETH", artifact, []string{"7", "demo"}, "", 0)
+	if err != nil {
+		t.Fatalf("Failed to estimate deployment gas: %v", err)
+	}
+	if cost == "0" {
+		t.Errorf("Expected a deployment fee")
+	}
+
+	// The simulated chain only mines on demand.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		for ctx.Err() == nil {
+			backend.Commit()
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
+
+	deployment, err := wallet.DeployContract("ETH", testPassword, "", artifact, []string{"7", "demo"}, "", 0)
+	if err != nil {
+		t.Fatalf("Failed to deploy contract: %v", err)
+	}
+	assertCorrectValue(t, deployment.Name, "Increment")
+
+	counter, err := wallet.CallContract("ETH", "Increment", "getCounter", nil, 0)
+	if err != nil {
+		t.Fatalf("Failed to call deployed contract: %v", err)
+	}
+	assertCorrectValue(t, counter[0].Value, "7")
+
+	transactions, err := wallet.GetTransactions()
+	if err != nil {
+		t.Fatalf("Failed to get transactions: %v", err)
+	}
+	assertCorrectValue(t, len(transactions), 1)
+	assertCorrectValue(t, transactions[0].Hash, deployment.Hash)
+	assertCorrectValue(t, transactions[0].Recipient, deployment.Address)
+	assertCorrectValue(t, transactions[0].Status, hdwallet.StatusCompleted)
+}
+
+func readArtifact(t testing.TB, name string) []byte {
+	t.Helper()
+	path := filepath.Join("..", "..", "..", "..", "hardhat", "artifacts", "contracts", name+".sol", name+".json")
+	artifact, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s artifact: %v", name, err)
+	}
+
+	return artifact
+}
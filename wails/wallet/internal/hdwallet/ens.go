@@ -0,0 +1,60 @@
+package hdwallet
+
+import (
+	"fmt"
+)
+
+// ResolveName returns the address an ENS name points to.
+func (w *Wallet) ResolveName(token, name string) (string, error) {
+	ensAcc, err := w.ensAccount(token)
+	if err != nil {
+		return "", err
+	}
+
+	address, err := ensAcc.ResolveName(name)
+	if err != nil {
+		return "", fmt.Errorf("error resolving %s: %w", name, err)
+	}
+
+	return address, nil
+}
+
+// LookupAddresses returns the ENS names of the addresses that have one, such as the
+// counterparties of the transaction history.
+func (w *Wallet) LookupAddresses(token string, addresses []string) (map[string]string, error) {
+	ensAcc, err := w.ensAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := ensAcc.LookupAddresses(addresses)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up %s names: %w", token, err)
+	}
+
+	return names, nil
+}
+
+// SetENSRegistry sets the ENS registry the names of a token are resolved with.
+func (w *Wallet) SetENSRegistry(token, registry string) error {
+	ensAcc, err := w.ensAccount(token)
+	if err != nil {
+		return err
+	}
+
+	return ensAcc.SetENSRegistry(registry)
+}
+
+func (w *Wallet) ensAccount(token string) (ensAccount, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	ensAcc, ok := masterAcc.(ensAccount)
+	if !ok {
+		return nil, fmt.Errorf("token %s does not support ENS names", token)
+	}
+
+	return ensAcc, nil
+}
@@ -0,0 +1,71 @@
+package hdwallet
+
+import (
+	"fmt"
+	"wallet/internal/currencies/eth"
+)
+
+// QueryContractEvents looks up the events of a registered contract between two blocks.
+// A toBlock of 0 stands for the latest block. args filter the indexed inputs of event.
+func (w *Wallet) QueryContractEvents(
+	token, contract, event string,
+	args []string,
+	fromBlock, toBlock uint64,
+) ([]eth.EventLog, error) {
+	eventAcc, err := w.eventAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := eventAcc.QueryContractEvents(contract, event, args, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error querying %s contract events: %w", token, err)
+	}
+
+	return events, nil
+}
+
+// SyncContractEvents stores the events a registered contract emitted since its last sync
+// and returns them.
+func (w *Wallet) SyncContractEvents(token, contract string) ([]eth.EventLog, error) {
+	eventAcc, err := w.eventAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := eventAcc.SyncContractEvents(contract)
+	if err != nil {
+		return events, fmt.Errorf("error syncing %s contract events: %w", token, err)
+	}
+
+	return events, nil
+}
+
+// GetContractActivity returns the stored events of a registered contract, newest first.
+func (w *Wallet) GetContractActivity(token, contract, event string, limit int) ([]eth.EventLog, error) {
+	eventAcc, err := w.eventAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := eventAcc.GetContractEvents(contract, event, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s contract activity: %w", token, err)
+	}
+
+	return events, nil
+}
+
+func (w *Wallet) eventAccount(token string) (eventAccount, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	eventAcc, ok := masterAcc.(eventAccount)
+	if !ok {
+		return nil, fmt.Errorf("token %s does not support contract events", token)
+	}
+
+	return eventAcc, nil
+}
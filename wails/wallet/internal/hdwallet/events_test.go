@@ -0,0 +1,114 @@
+package hdwallet_test
+
+import (
+	"math/big"
+	"testing"
+	"wallet/internal/contracts"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethsim"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestContractActivity(t *testing.T) {
+	backend, err := ethsim.NewBackend(map[string]int64{account0: 100})
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer backend.Close()
+
+	wallet := newSimulatedWallet(t, backend)
+	token := deployDemoToken(t, backend)
+	_, err = wallet.RegisterContract("ETH", "DemoToken", token, readArtifact(t, "DemoToken"))
+	if err != nil {
+		t.Fatalf("Failed to register DemoToken: %v", err)
+	}
+
+	// The supply minted to account 0 on deployment.
+	events, err := wallet.SyncContractEvents("ETH", "DemoToken")
+	if err != nil {
+		t.Fatalf("Failed to sync events: %v", err)
+	}
+	assertCorrectValue(t, len(events), 1)
+	assertCorrectValue(t, events[0].Event, "Transfer")
+	assertCorrectValue(t, events[0].Fields[1], eth.ContractParam{
+		Name: "to", Type: "address", Value: account0, Indexed: true,
+	})
+
+	privateKey, err := crypto.HexToECDSA(account0Key)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(ethsim.ChainID))
+	if err != nil {
+		t.Fatalf("Failed to create transactor: %v", err)
+	}
+
+	demoToken, err := contracts.NewDemoTokenTransactor(
+		common.HexToAddress(token), eth.NewContractBackend(eth.NewClientWithBackend(backend)))
+	if err != nil {
+		t.Fatalf("Failed to bind DemoToken: %v", err)
+	}
+
+	parent := backend.Commit()
+	_, err = demoToken.Transfer(opts, common.HexToAddress(account1), big.NewInt(25))
+	if err != nil {
+		t.Fatalf("Failed to transfer DemoToken: %v", err)
+	}
+	backend.Commit()
+
+	// Only the blocks mined since the last sync are read.
+	events, err = wallet.SyncContractEvents("ETH", "DemoToken")
+	if err != nil {
+		t.Fatalf("Failed to sync events: %v", err)
+	}
+	assertCorrectValue(t, len(events), 1)
+	assertCorrectValue(t, events[0].Fields[2].Value, "25")
+
+	activity, err := wallet.GetContractActivity("ETH", "DemoToken", "", 0)
+	if err != nil {
+		t.Fatalf("Failed to get contract activity: %v", err)
+	}
+	assertCorrectValue(t, len(activity), 2)
+	assertCorrectValue(t, activity[0].TxHash, events[0].TxHash)
+
+	approvals, err := wallet.GetContractActivity("ETH", "DemoToken", "Approval", 0)
+	if err != nil {
+		t.Fatalf("Failed to get contract activity: %v", err)
+	}
+	assertCorrectValue(t, len(approvals), 0)
+
+	received, err := wallet.QueryContractEvents("ETH", "DemoToken", "Transfer", []string{"", account1}, 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to query events: %v", err)
+	}
+	assertCorrectValue(t, len(received), 1)
+	assertCorrectValue(t, received[0].Fields[1].Value, account1)
+
+	// A competing chain replaces the block of the transfer, so the last blocks are synced
+	// again, from the mint on, and their stored events replaced.
+	err = backend.Fork(parent)
+	if err != nil {
+		t.Fatalf("Failed to fork chain: %v", err)
+	}
+	backend.Commit()
+	backend.Commit()
+
+	events, err = wallet.SyncContractEvents("ETH", "DemoToken")
+	if err != nil {
+		t.Fatalf("Failed to sync events: %v", err)
+	}
+	if len(events) == 0 {
+		t.Fatalf("Expected the replaced blocks to be synced again")
+	}
+	assertCorrectValue(t, events[0].Fields[1].Value, account0)
+
+	activity, err = wallet.GetContractActivity("ETH", "DemoToken", "", 0)
+	if err != nil {
+		t.Fatalf("Failed to get contract activity: %v", err)
+	}
+	assertCorrectValue(t, len(activity), len(events))
+}
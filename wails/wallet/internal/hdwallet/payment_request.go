@@ -0,0 +1,116 @@
+package hdwallet
+
+import (
+	"fmt"
+	"wallet/internal/currencies/eth"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GetReceiveRequest creates a payment request to an account of the wallet, with an
+// optional amount.
+func (w *Wallet) GetReceiveRequest(token string, accountIndex int, amount string) (*eth.PaymentRequest, error) {
+	paymentAcc, err := w.paymentAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := paymentAcc.ReceiveRequest(accountIndex, amount)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s payment request: %w", token, err)
+	}
+
+	return request, nil
+}
+
+// ReadPaymentRequest parses a payment URI to pre-fill a send.
+func (w *Wallet) ReadPaymentRequest(token, uri string) (*eth.PaymentRequest, error) {
+	paymentAcc, err := w.paymentAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := paymentAcc.ReadPaymentRequest(uri)
+	if err != nil {
+		return nil, fmt.Errorf("error reading payment request: %w", err)
+	}
+
+	return request, nil
+}
+
+// ReviewPaymentRequest checks the transaction paying a payment URI from an account, like
+// ReviewTransaction does for transfers. The payee is compared with the known addresses.
+func (w *Wallet) ReviewPaymentRequest(token, uri string, accountIndex int) (*eth.TransactionReview, error) {
+	paymentAcc, err := w.paymentAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := w.ReadPaymentRequest(token, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := paymentAcc.ReviewPaymentRequest(request, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing %s payment request: %w", token, err)
+	}
+
+	known, err := w.knownAddresses(token)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving known %s addresses: %w", token, err)
+	}
+	review.CheckRecipient(known)
+
+	return review, nil
+}
+
+// SendPaymentRequest pays a payment URI from an account, either in ether or with an
+// ERC-20 transfer. Payments that would fail are refused, and a flagged payee must have
+// been confirmed with ConfirmRecipient.
+func (w *Wallet) SendPaymentRequest(token, password, uri string, accountIndex int) (string, error) {
+	request, err := w.ReadPaymentRequest(token, uri)
+	if err != nil {
+		return "", err
+	}
+
+	review, err := w.ReviewPaymentRequest(token, uri, accountIndex)
+	if err != nil {
+		return "", err
+	}
+
+	if len(review.Errors) > 0 {
+		return "", fmt.Errorf("the payment would fail: %s", review.Errors[0].Message)
+	}
+
+	err = w.requireConfirmedRecipient(token, request.Payee())
+	if err != nil {
+		return "", err
+	}
+
+	from, err := w.GetAccountAddress(token, accountIndex)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := request.TransactionRequest(common.HexToAddress(from))
+	if err != nil {
+		return "", err
+	}
+
+	return w.sendTransactionRequest(token, password, req)
+}
+
+func (w *Wallet) paymentAccount(token string) (paymentAccount, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	paymentAcc, ok := masterAcc.(paymentAccount)
+	if !ok {
+		return nil, fmt.Errorf("token %s does not support payment requests", token)
+	}
+
+	return paymentAcc, nil
+}
@@ -0,0 +1,146 @@
+package hdwallet_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"wallet/internal/contracts"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethsim"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestPaymentRequests(t *testing.T) {
+	backend, err := ethsim.NewBackend(map[string]int64{account0: 100})
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer backend.Close()
+
+	wallet := newSimulatedWallet(t, backend)
+
+	t.Run("Ether requests are paid", func(t *testing.T) {
+		request, err := wallet.GetReceiveRequest("ETH", 1, "1.50")
+		if err != nil {
+			t.Fatalf("Failed to create receive request: %v", err)
+		}
+		assertCorrectValue(t, request.URI(), "ethereum:"+account1+"@1337?value=1500000000000000000")
+		assertCorrectValue(t, request.Amount, "1.5")
+
+		prefill, err := wallet.ReadPaymentRequest("ETH", request.URI())
+		if err != nil {
+			t.Fatalf("Failed to read payment request: %v", err)
+		}
+		assertCorrectValue(t, prefill.Amount, "1.5")
+		assertCorrectValue(t, prefill.Symbol, "ETH")
+
+		_, err = wallet.SendPaymentRequest("ETH", testPassword, request.URI(), 0)
+		if err != nil {
+			t.Fatalf("Failed to pay request: %v", err)
+		}
+		backend.Commit()
+
+		assertWalletBalance(t, wallet, 1, "1.5")
+	})
+
+	t.Run("Request fees are estimated and new payees confirmed", func(t *testing.T) {
+		payee := "0x000000000000000000000000000000000000dEaD"
+		uri := "ethereum:" + payee + "@1337?value=1e18&gasLimit=10000000&gasPrice=1e18"
+		review, err := wallet.ReviewPaymentRequest("ETH", uri, 0)
+		if err != nil {
+			t.Fatalf("Failed to review payment request: %v", err)
+		}
+		assertCorrectValue(t, review.GasLimit, uint64(21000))
+		assertCorrectValue(t, review.ConfirmRecipient, true)
+
+		_, err = wallet.SendPaymentRequest("ETH", testPassword, uri, 0)
+		if err == nil || !strings.Contains(err.Error(), "must be confirmed") {
+			t.Fatalf("Expected the new payee to need confirmation, got %v", err)
+		}
+
+		wallet.ConfirmRecipient("ETH", payee)
+		_, err = wallet.SendPaymentRequest("ETH", testPassword, uri, 0)
+		if err != nil {
+			t.Fatalf("Failed to pay request: %v", err)
+		}
+		backend.Commit()
+
+		// Account 2 holds no ether to pay with.
+		_, err = wallet.SendPaymentRequest("ETH", testPassword, "ethereum:"+account1+"@1337?value=1e18", 2)
+		if err == nil || !strings.Contains(err.Error(), "the payment would fail") {
+			t.Errorf("Expected the payment to be refused, got %v", err)
+		}
+	})
+
+	t.Run("Invalid requests are rejected", func(t *testing.T) {
+		_, err := wallet.ReadPaymentRequest("ETH", "ethereum:"+account1+"@1?value=1")
+		if err == nil || !strings.Contains(err.Error(), "for chain 1, but ETH is on chain 1337") {
+			t.Errorf("Expected a chain mismatch error, got %v", err)
+		}
+
+		_, err = wallet.GetReceiveRequest("ETH", 0, "0.1e1")
+		if err == nil || !strings.Contains(err.Error(), "invalid amount") {
+			t.Errorf("Expected an invalid amount error, got %v", err)
+		}
+	})
+
+	t.Run("Token transfer requests are paid", func(t *testing.T) {
+		token := deployDemoToken(t, backend)
+		uri := "ethereum:" + token + "@1337/transfer?address=" + account1 + "&uint256=2.5e18"
+
+		prefill, err := wallet.ReadPaymentRequest("ETH", uri)
+		if err != nil {
+			t.Fatalf("Failed to read payment request: %v", err)
+		}
+		assertCorrectValue(t, prefill.Amount, "2.5")
+		assertCorrectValue(t, prefill.Recipient, account1)
+
+		review, err := wallet.ReviewPaymentRequest("ETH", uri, 0)
+		if err != nil {
+			t.Fatalf("Failed to review payment request: %v", err)
+		}
+		assertCorrectValue(t, review.To, account1)
+		assertCorrectValue(t, len(review.Warnings), 0)
+		assertCorrectValue(t, review.ConfirmRecipient, false)
+		if review.GasLimit == 0 || review.MaxFee == "0" {
+			t.Errorf("Expected the fee of the token transfer to be estimated, got %+v", review)
+		}
+
+		_, err = wallet.SendPaymentRequest("ETH", testPassword, uri, 0)
+		if err != nil {
+			t.Fatalf("Failed to pay request: %v", err)
+		}
+		backend.Commit()
+
+		balance, err := wallet.GetDemoTokenBalance("ETH", token, 1)
+		if err != nil {
+			t.Fatalf("Failed to get token balance: %v", err)
+		}
+		assertCorrectValue(t, balance.Balance, "2.5")
+		assertCorrectValue(t, prefill.Symbol, balance.Symbol)
+	})
+}
+
+func deployDemoToken(t testing.TB, backend *ethsim.Backend) string {
+	t.Helper()
+	privateKey, err := crypto.HexToECDSA(account0Key)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(ethsim.ChainID))
+	if err != nil {
+		t.Fatalf("Failed to create transactor: %v", err)
+	}
+
+	contractBackend := eth.NewContractBackend(eth.NewClientWithBackend(backend))
+	address, _, _, err := contracts.DeployDemoToken(opts, contractBackend)
+	if err != nil {
+		t.Fatalf("Failed to deploy DemoToken: %v", err)
+	}
+	backend.Commit()
+
+	return address.Hex()
+}
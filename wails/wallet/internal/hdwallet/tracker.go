@@ -0,0 +1,194 @@
+package hdwallet
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+	"wallet/internal/currencies/eth"
+)
+
+// blockPollInterval is how often the block number is polled when the node cannot
+// push new blocks.
+const blockPollInterval = 4 * time.Second
+
+// chainAccount is implemented by master accounts that can follow the chain.
+type chainAccount interface {
+	SubscribeNewHeads(ctx context.Context, onHead func(blockNumber uint64)) error
+	SubscribeTokenTransfers(ctx context.Context, onTransfer func(eth.TokenTransfer)) error
+	BlockNumber() (uint64, error)
+	TransactionReceipts(hashes []string) ([]*eth.Receipt, error)
+}
+
+// BalanceUpdate carries the balances of every account of a token.
+type BalanceUpdate struct {
+	Token string `json:"token"`
+	Block uint64 `json:"block"`
+	// Balances are exact decimal amounts of ether, by account index.
+	Balances map[int]string `json:"balances"`
+}
+
+// WatchHandlers are called with the changes found on new blocks. Any of them can be nil.
+type WatchHandlers struct {
+	OnBalances     func(BalanceUpdate)
+	OnTransactions func([]WalletTransaction)
+	// OnTokenTransfer is called with the token transfers to the accounts. It needs a
+	// node that can push logs, and is never called when blocks are polled.
+	OnTokenTransfer func(eth.TokenTransfer)
+	OnError         func(error)
+}
+
+// Watch follows the chain of a token until ctx is done. On every new block the receipts
+// of pending transactions are checked and the balances refreshed, and the changes are
+// reported to handlers. Blocks come from a newHeads subscription, or from polling the
+// block number when the node has no WebSocket endpoint. Token transfers to the accounts
+// come from a logs subscription on the same endpoint.
+func (w *Wallet) Watch(ctx context.Context, token string, handlers WatchHandlers) error {
+	chainAcc, err := w.chainAccount(token)
+	if err != nil {
+		return err
+	}
+
+	// Blocks that arrive while a refresh is running are coalesced into the next one.
+	blocks := make(chan uint64, 1)
+	onHead := func(blockNumber uint64) {
+		select {
+		case blocks <- blockNumber:
+		default:
+		}
+	}
+
+	err = chainAcc.SubscribeNewHeads(ctx, onHead)
+	if err != nil {
+		go pollBlocks(ctx, chainAcc, onHead)
+	} else if handlers.OnTokenTransfer != nil {
+		err = chainAcc.SubscribeTokenTransfers(ctx, handlers.OnTokenTransfer)
+		if err != nil {
+			handlers.reportError(err)
+		}
+	}
+
+	go w.track(ctx, token, blocks, handlers)
+	onHead(0)
+	return nil
+}
+
+// UpdatePendingTransactions looks up the receipts of the pending transactions of a
+// token and returns the ones that were mined, with their new status, along with the
+// ones that were replaced by a mined transaction.
+func (w *Wallet) UpdatePendingTransactions(token string) ([]WalletTransaction, error) {
+	chainAcc, err := w.chainAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	pending, err := w.walletDB.GetPendingTransactions(dbCtx, token)
+	if err != nil || len(pending) == 0 {
+		return nil, err
+	}
+
+	hashes := make([]string, len(pending))
+	for i, transaction := range pending {
+		hashes[i] = transaction.Hash
+	}
+
+	receipts, err := chainAcc.TransactionReceipts(hashes)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s receipts: %w", token, err)
+	}
+
+	var updated []WalletTransaction
+	for i, receipt := range receipts {
+		if receipt == nil {
+			continue
+		}
+
+		transaction := pending[i]
+		transaction.Status = StatusCompleted
+		if !receipt.Succeeded() {
+			transaction.Status = StatusFailed
+		}
+
+		err = w.walletDB.UpdateTransactionStatus(dbCtx, transaction.Hash, transaction.Status)
+		if err != nil {
+			return updated, err
+		}
+		updated = append(updated, transaction)
+
+		replaced, err := w.walletDB.ResolveReplacements(dbCtx, transaction.Hash)
+		updated = append(updated, replaced...)
+		if err != nil {
+			return updated, err
+		}
+	}
+
+	return updated, nil
+}
+
+func (w *Wallet) track(ctx context.Context, token string, blocks <-chan uint64, handlers WatchHandlers) {
+	var lastBalances map[int]string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case blockNumber := <-blocks:
+			updated, err := w.UpdatePendingTransactions(token)
+			if err != nil {
+				handlers.reportError(err)
+			} else if len(updated) > 0 && handlers.OnTransactions != nil {
+				handlers.OnTransactions(updated)
+			}
+
+			balances, err := w.GetBalances(token)
+			if err != nil {
+				handlers.reportError(err)
+				continue
+			}
+
+			if !maps.Equal(balances, lastBalances) && handlers.OnBalances != nil {
+				handlers.OnBalances(BalanceUpdate{Token: token, Block: blockNumber, Balances: balances})
+			}
+			lastBalances = balances
+		}
+	}
+}
+
+func (h WatchHandlers) reportError(err error) {
+	if h.OnError != nil {
+		h.OnError(err)
+	}
+}
+
+func pollBlocks(ctx context.Context, chainAcc chainAccount, onHead func(uint64)) {
+	ticker := time.NewTicker(blockPollInterval)
+	defer ticker.Stop()
+	var lastBlock uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			blockNumber, err := chainAcc.BlockNumber()
+			if err == nil && blockNumber > lastBlock {
+				lastBlock = blockNumber
+				onHead(blockNumber)
+			}
+		}
+	}
+}
+
+func (w *Wallet) chainAccount(token string) (chainAccount, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	chainAcc, ok := masterAcc.(chainAccount)
+	if !ok {
+		return nil, fmt.Errorf("token %s cannot be watched", token)
+	}
+
+	return chainAcc, nil
+}
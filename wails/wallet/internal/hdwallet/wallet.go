@@ -5,7 +5,7 @@ import (
 	"database/sql"
 	"encoding/hex"
 	"fmt"
-	"strconv"
+	"sync"
 	"time"
 	"wallet/internal/currencies/eth"
 	"wallet/internal/utils"
@@ -19,14 +19,126 @@ type Wallet struct {
 	Accounts  map[string]masterAccount
 	walletDB  *WalletStorage
 	ctx       context.Context
+
+	// confirmedRecipients holds the recipients confirmed for the next send to them.
+	confirmedMu         sync.Mutex
+	confirmedRecipients map[string]bool
 }
 
 type masterAccount interface {
 	GetAddress(accountIndex int) (string, error)
 	RetrieveBalance(accountIndex int) (string, error)
+	RetrieveBalances() (map[int]string, error)
 	EstimateGas(from, value string, accountIndex int) (string, error)
+	ReviewTransaction(to, value string, accountIndex int) (*eth.TransactionReview, error)
 	SendTransaction(to, value string, privateKey *bip32.Key, accountIndex int) (string, error)
 	GetAllAccounts() (map[int]string, error)
+	GetAccountIndex(address string) (int, error)
+	BuildTransaction(to, value string, accountIndex int) (string, error)
+	SignTransaction(payload string, privateKey *bip32.Key, accountIndex int) (string, error)
+	BroadcastTransaction(rawTx string) (*eth.SentTransaction, error)
+	FillTransaction(req *eth.TransactionRequest) (string, error)
+	SendTransactionRequest(req *eth.TransactionRequest, privateKey *bip32.Key, idx int) (*eth.SentTransaction, error)
+	SignMessage(message []byte, privateKey *bip32.Key, accountIndex int) (string, error)
+	SignTypedData(typedData []byte, privateKey *bip32.Key, accountIndex int) (string, error)
+	ReviewTypedData(typedData []byte) (*eth.TypedDataReview, error)
+}
+
+// contractAccount is implemented by master accounts of chains that run smart contracts.
+type contractAccount interface {
+	RegisterContract(name, address string, abiJSON []byte) (*eth.Contract, error)
+	GetContracts() ([]*eth.Contract, error)
+	RemoveContract(name string) error
+	CallContract(contract, method string, args []string, accountIndex int) ([]eth.ContractParam, error)
+	ContractTransaction(contract, method string, args []string, value string, idx int) (*eth.TransactionRequest, error)
+	EstimateContractGas(contract, method string, args []string, value string, idx int) (string, error)
+	GetDemoTokenBalance(contract string, accountIndex int) (*eth.TokenBalance, error)
+	GetCurrentProposal(contract string) (*eth.Proposal, error)
+	CreateProposal(
+		contract, description string,
+		votesToEnd uint64,
+		masterKey *bip32.Key,
+		idx int,
+	) (*eth.SentTransaction, error)
+	VoteProposal(contract string, choice uint8, masterKey *bip32.Key, idx int) (*eth.SentTransaction, error)
+	GetCounter(contract string) (string, error)
+	IncrementCounter(contract string, masterKey *bip32.Key, accountIndex int) (*eth.SentTransaction, error)
+}
+
+// backendAccount is implemented by master accounts whose node connection can be replaced.
+type backendAccount interface {
+	SetBackend(backend eth.Backend)
+}
+
+// providerAccount is implemented by master accounts that fail over between several
+// RPC endpoints.
+type providerAccount interface {
+	SetProviders(urls []string) error
+	ProviderStatus() ([]eth.ProviderStatus, error)
+}
+
+// nonceAccount is implemented by master accounts that track the nonces of the
+// transactions they send, and can replace the pending ones.
+type nonceAccount interface {
+	NonceGaps() ([]eth.NonceGap, error)
+	ResetNonceGap(address string) error
+	SpeedUpTransaction(hash string, masterKey *bip32.Key, accountIndex int) (*eth.SentTransaction, error)
+	CancelTransaction(hash string, masterKey *bip32.Key, accountIndex int) (*eth.SentTransaction, error)
+}
+
+// paymentAccount is implemented by master accounts that create and read payment request URIs.
+type paymentAccount interface {
+	ReceiveRequest(accountIndex int, amount string) (*eth.PaymentRequest, error)
+	ReadPaymentRequest(uri string) (*eth.PaymentRequest, error)
+	ReviewPaymentRequest(request *eth.PaymentRequest, accountIndex int) (*eth.TransactionReview, error)
+}
+
+// allowanceAccount is implemented by master accounts of chains with ERC-20 tokens, whose
+// allowances can be listed and revoked.
+type allowanceAccount interface {
+	ScanAllowances() ([]eth.Allowance, error)
+	RevokeAllowanceRequest(contract, spender string, accountIndex int) (*eth.TransactionRequest, error)
+}
+
+// deploymentAccount is implemented by master accounts of chains that can deploy contracts
+// from compiled artifacts.
+type deploymentAccount interface {
+	DeploymentRequest(artifact []byte, args []string, value string, accountIndex int) (*eth.TransactionRequest, error)
+	EstimateDeploymentGas(artifact []byte, args []string, value string, accountIndex int) (string, error)
+	WaitForDeployment(name string, artifact []byte, hash string) (*eth.Deployment, error)
+}
+
+// eventAccount is implemented by master accounts that read and store the events of
+// registered contracts.
+type eventAccount interface {
+	QueryContractEvents(contract, event string, args []string, fromBlock, toBlock uint64) ([]eth.EventLog, error)
+	SyncContractEvents(contract string) ([]eth.EventLog, error)
+	GetContractEvents(contract, event string, limit int) ([]eth.EventLog, error)
+}
+
+// callAccount is implemented by master accounts that decode the data of contract calls.
+type callAccount interface {
+	DecodeCall(to string, data []byte) (*eth.DecodedCall, error)
+	LoadSignatureDatabase(path string) (int, error)
+}
+
+// collectibleAccount is implemented by master accounts of chains with ERC-721 and ERC-1155 tokens.
+type collectibleAccount interface {
+	GetCollectibles() ([]eth.Collectible, error)
+	LastCollectibles() []eth.Collectible
+	CollectibleTransferRequest(contract, tokenID, to string, accountIndex int) (*eth.TransactionRequest, error)
+	MultiTokenTransferRequest(
+		contract, to string,
+		ids, amounts []string,
+		accountIndex int,
+	) (*eth.TransactionRequest, error)
+}
+
+// ensAccount is implemented by master accounts of chains with a name service.
+type ensAccount interface {
+	ResolveName(name string) (string, error)
+	LookupAddresses(addresses []string) (map[string]string, error)
+	SetENSRegistry(registry string) error
 }
 
 type masterAccountFactory func(ctx context.Context, masterKey *bip32.Key, db *sql.DB) (masterAccount, error)
@@ -187,6 +299,176 @@ func createETHAccount(ctx context.Context, masterKey *bip32.Key, db *sql.DB) (ma
 	return ethAccount, nil
 }
 
+// SetBackend sends the node requests of a token through backend, for instance an
+// in-process chain in tests.
+func (w *Wallet) SetBackend(token string, backend eth.Backend) error {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return fmt.Errorf("token not found: %s", token)
+	}
+
+	backendAcc, ok := masterAcc.(backendAccount)
+	if !ok {
+		return fmt.Errorf("token %s does not support custom backends", token)
+	}
+
+	backendAcc.SetBackend(backend)
+	return nil
+}
+
+func (w *Wallet) SetProviders(token string, urls []string) error {
+	providerAcc, err := w.providerAccount(token)
+	if err != nil {
+		return err
+	}
+
+	err = providerAcc.SetProviders(urls)
+	if err != nil {
+		return fmt.Errorf("error setting %s providers: %w", token, err)
+	}
+
+	return nil
+}
+
+// GetProviderStatus checks the health of the RPC endpoints used for a token.
+func (w *Wallet) GetProviderStatus(token string) ([]eth.ProviderStatus, error) {
+	providerAcc, err := w.providerAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := providerAcc.ProviderStatus()
+	if err != nil {
+		return nil, fmt.Errorf("error checking %s providers: %w", token, err)
+	}
+
+	return status, nil
+}
+
+// GetNonceGaps reports the accounts of a token whose sent transactions the node lost.
+// Later transactions of these accounts stay queued until the gap is filled.
+func (w *Wallet) GetNonceGaps(token string) ([]eth.NonceGap, error) {
+	nonceAcc, err := w.nonceAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	gaps, err := nonceAcc.NonceGaps()
+	if err != nil {
+		return nil, fmt.Errorf("error checking %s nonces: %w", token, err)
+	}
+
+	return gaps, nil
+}
+
+// ResetNonceGap forgets the lost transactions of an address, so its next transaction
+// fills the gap.
+func (w *Wallet) ResetNonceGap(token, address string) error {
+	nonceAcc, err := w.nonceAccount(token)
+	if err != nil {
+		return err
+	}
+
+	err = nonceAcc.ResetNonceGap(address)
+	if err != nil {
+		return fmt.Errorf("error resetting %s nonces of %s: %w", token, address, err)
+	}
+
+	return nil
+}
+
+// SpeedUpTransaction sends a pending transaction of the history again with higher fees.
+// The replacement is recorded and linked to it, and its hash is returned.
+func (w *Wallet) SpeedUpTransaction(password, hash string) (string, error) {
+	return w.replaceTransaction(password, hash, nonceAccount.SpeedUpTransaction)
+}
+
+// CancelTransaction replaces a pending transaction of the history with an empty transfer
+// to its sender. The cancellation is recorded and linked to it, and its hash is returned.
+func (w *Wallet) CancelTransaction(password, hash string) (string, error) {
+	return w.replaceTransaction(password, hash, nonceAccount.CancelTransaction)
+}
+
+func (w *Wallet) replaceTransaction(
+	password, hash string,
+	replace func(nonceAccount, string, *bip32.Key, int) (*eth.SentTransaction, error),
+) (string, error) {
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	transaction, err := w.walletDB.GetTransaction(dbCtx, hash)
+	if err != nil {
+		return "", err
+	}
+
+	if transaction.Status != StatusPending {
+		return "", fmt.Errorf("transaction %s is %s, only pending transactions can be replaced", hash, transaction.Status)
+	}
+
+	nonceAcc, err := w.nonceAccount(transaction.Token)
+	if err != nil {
+		return "", err
+	}
+
+	accountIndex, err := w.GetAccountIndex(transaction.Token, transaction.Sender)
+	if err != nil {
+		return "", err
+	}
+
+	masterKey, err := w.retrieveMasterKey(dbCtx, password)
+	if err != nil {
+		return "", err
+	}
+
+	sentTx, err := replace(nonceAcc, transaction.Hash, masterKey, accountIndex)
+	if err != nil {
+		return "", err
+	}
+
+	err = w.walletDB.SaveReplacementInDB(
+		dbCtx,
+		transaction.Hash,
+		sentTx.Hash,
+		sentTx.From,
+		sentTx.To,
+		sentTx.Value,
+		transaction.Token,
+		time.Now().UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return sentTx.Hash, fmt.Errorf("error saving transaction into DB: %w", err)
+	}
+
+	return sentTx.Hash, nil
+}
+
+func (w *Wallet) nonceAccount(token string) (nonceAccount, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	nonceAcc, ok := masterAcc.(nonceAccount)
+	if !ok {
+		return nil, fmt.Errorf("token %s does not track nonces", token)
+	}
+
+	return nonceAcc, nil
+}
+
+func (w *Wallet) providerAccount(token string) (providerAccount, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	providerAcc, ok := masterAcc.(providerAccount)
+	if !ok {
+		return nil, fmt.Errorf("token %s does not support multiple providers", token)
+	}
+
+	return providerAcc, nil
+}
+
 func (w *Wallet) GetAccountAddress(token string, accountIndex int) (string, error) {
 	masterAcc, ok := w.Accounts[token]
 	if !ok {
@@ -215,23 +497,47 @@ func (w *Wallet) GetAllAccounts(token string) (map[int]string, error) {
 	return accounts, nil
 }
 
-func (w *Wallet) GetBalance(token string, accountIndex int) (float64, error) {
+// GetBalance returns the balance of an account in ether, e.g. "1.5", as an exact decimal.
+func (w *Wallet) GetBalance(token string, accountIndex int) (string, error) {
 	masterAcc, ok := w.Accounts[token]
 	if !ok {
-		return 0, fmt.Errorf("token not found: %s", token)
+		return "", fmt.Errorf("token not found: %s", token)
 	}
 
 	hexBalance, err := masterAcc.RetrieveBalance(accountIndex)
 	if err != nil {
-		return 0, fmt.Errorf("error retrieving balance: %w", err)
+		return "", fmt.Errorf("error retrieving balance: %w", err)
 	}
 
 	balance, err := eth.HexToEther(hexBalance)
 	if err != nil {
-		return 0, fmt.Errorf("error converting balance: %w", err)
+		return "", fmt.Errorf("error converting balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+// GetBalances returns the balance of every account of a token with one node round trip.
+func (w *Wallet) GetBalances(token string) (map[int]string, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	hexBalances, err := masterAcc.RetrieveBalances()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving balances: %w", err)
 	}
 
-	return strconv.ParseFloat(balance, 64)
+	balances := make(map[int]string, len(hexBalances))
+	for idx, hexBalance := range hexBalances {
+		balances[idx], err = eth.HexToEther(hexBalance)
+		if err != nil {
+			return nil, fmt.Errorf("error converting balance of account %d: %w", idx, err)
+		}
+	}
+
+	return balances, nil
 }
 
 func (w *Wallet) EstimateGas(token, to, value string, accountIndex int) (string, error) {
@@ -247,23 +553,47 @@ func (w *Wallet) EstimateGas(token, to, value string, accountIndex int) (string,
 	return gasPrice, nil
 }
 
+// ReviewTransaction checks a transfer before it is sent: the recipient address, whether the
+// balance covers the value and fees, and whether the transfer would revert. The recipient
+// is compared with the address book and the history to detect address poisoning.
+func (w *Wallet) ReviewTransaction(token, to, value string, accountIndex int) (*eth.TransactionReview, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	review, err := masterAcc.ReviewTransaction(to, value, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing %s transaction: %w", token, err)
+	}
+
+	known, err := w.knownAddresses(token)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving known %s addresses: %w", token, err)
+	}
+	review.CheckRecipient(known)
+
+	return review, nil
+}
+
+// SendTransaction transfers value to the recipient. A recipient flagged by CheckRecipient
+// must have been confirmed with ConfirmRecipient.
 func (w *Wallet) SendTransaction(token, password, to, value string, accountIndex int) (bool, error) {
 	masterAcc, ok := w.Accounts[token]
 	if !ok {
 		return false, fmt.Errorf("token not found: %s", token)
 	}
 
-	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
-	defer cancel()
-	pubKeyData, err := w.publicKey.Serialize()
+	err := w.requireConfirmedRecipient(token, to)
 	if err != nil {
-		return false, fmt.Errorf("error serializing master public key: %w", err)
+		return false, err
 	}
 
-	pubKeyHex := hex.EncodeToString(pubKeyData)
-	masterKey, err := w.walletDB.RetrieveRootKeyFromDB(dbCtx, password, pubKeyHex)
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	masterKey, err := w.retrieveMasterKey(dbCtx, password)
 	if err != nil {
-		return false, fmt.Errorf("error retrieving key from DB: %w", err)
+		return false, err
 	}
 
 	from, err := masterAcc.GetAddress(accountIndex)
@@ -276,17 +606,10 @@ func (w *Wallet) SendTransaction(token, password, to, value string, accountIndex
 		return false, fmt.Errorf("failed to process %s transaction %w", token, err)
 	}
 
-	var status string
-	if transactionHash == "0x0000000000000000000000000000000000000000000000000000000000000000" {
-		status = "PENDING"
-	} else {
-		status = "COMPLETED"
-	}
-
 	now := time.Now().UTC()
 	isoDate := now.Format(time.RFC3339)
 
-	err = w.walletDB.SaveTransactionInDB(dbCtx, from, to, value, status, token, isoDate)
+	err = w.walletDB.SaveTransactionInDB(dbCtx, transactionHash, from, to, value, StatusPending, token, isoDate)
 	if err != nil {
 		return true, fmt.Errorf("error saving transaction into DB: %w", err)
 	}
@@ -294,6 +617,444 @@ func (w *Wallet) SendTransaction(token, password, to, value string, accountIndex
 	return true, nil
 }
 
+// BuildTransaction prepares an unsigned transaction that can be exported to an offline
+// machine. It only needs the stored account addresses, so no password is required.
+func (w *Wallet) BuildTransaction(token, to, value string, accountIndex int) (string, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return "", fmt.Errorf("token not found: %s", token)
+	}
+
+	payload, err := masterAcc.BuildTransaction(to, value, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error building %s transaction: %w", token, err)
+	}
+
+	return payload, nil
+}
+
+// SignTransaction signs an exported unsigned transaction without contacting any node.
+func (w *Wallet) SignTransaction(token, password, payload string, accountIndex int) (string, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return "", fmt.Errorf("token not found: %s", token)
+	}
+
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	masterKey, err := w.retrieveMasterKey(dbCtx, password)
+	if err != nil {
+		return "", err
+	}
+
+	rawTx, err := masterAcc.SignTransaction(payload, masterKey, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error signing %s transaction: %w", token, err)
+	}
+
+	return rawTx, nil
+}
+
+func (w *Wallet) BroadcastTransaction(token, rawTx string) (string, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return "", fmt.Errorf("token not found: %s", token)
+	}
+
+	sentTx, err := masterAcc.BroadcastTransaction(rawTx)
+	if err != nil {
+		return "", fmt.Errorf("failed to broadcast %s transaction %w", token, err)
+	}
+
+	return w.saveSentTransaction(token, sentTx)
+}
+
+// saveSentTransaction records a broadcast transaction as pending in the history and
+// returns its hash.
+func (w *Wallet) saveSentTransaction(token string, sentTx *eth.SentTransaction) (string, error) {
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	isoDate := time.Now().UTC().Format(time.RFC3339)
+	err := w.walletDB.SaveTransactionInDB(
+		dbCtx,
+		sentTx.Hash,
+		sentTx.From,
+		sentTx.To,
+		sentTx.Value,
+		StatusPending,
+		token,
+		isoDate,
+	)
+	if err != nil {
+		return sentTx.Hash, fmt.Errorf("error saving transaction into DB: %w", err)
+	}
+
+	return sentTx.Hash, nil
+}
+
+// GetAccountIndex returns the derivation index of one of the wallet addresses.
+func (w *Wallet) GetAccountIndex(token, address string) (int, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return -1, fmt.Errorf("token not found: %s", token)
+	}
+
+	return masterAcc.GetAccountIndex(address)
+}
+
+// SignTransactionRequest fills the missing fields of a dApp transaction request and signs
+// it with the account matching its sender. Like for SendTransaction, a flagged recipient
+// must have been confirmed.
+func (w *Wallet) SignTransactionRequest(token, password string, req *eth.TransactionRequest) (string, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return "", fmt.Errorf("token not found: %s", token)
+	}
+
+	accountIndex, err := w.GetAccountIndex(token, req.From.Hex())
+	if err != nil {
+		return "", err
+	}
+
+	if req.To != nil {
+		err = w.requireConfirmedRecipient(token, req.To.Hex())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	payload, err := masterAcc.FillTransaction(req)
+	if err != nil {
+		return "", fmt.Errorf("error filling %s transaction: %w", token, err)
+	}
+
+	return w.SignTransaction(token, password, payload, accountIndex)
+}
+
+// SendTransactionRequest fills, signs and broadcasts a dApp transaction request, recording
+// it in the transaction history. Like for SendTransaction, a flagged recipient must have
+// been confirmed.
+func (w *Wallet) SendTransactionRequest(token, password string, req *eth.TransactionRequest) (string, error) {
+	if req.To != nil {
+		err := w.requireConfirmedRecipient(token, req.To.Hex())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return w.sendTransactionRequest(token, password, req)
+}
+
+// sendTransactionRequest sends the transactions the wallet builds itself, to contracts
+// the user picked or that are known, without asking to confirm the recipient.
+func (w *Wallet) sendTransactionRequest(token, password string, req *eth.TransactionRequest) (string, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return "", fmt.Errorf("token not found: %s", token)
+	}
+
+	accountIndex, err := w.GetAccountIndex(token, req.From.Hex())
+	if err != nil {
+		return "", err
+	}
+
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	masterKey, err := w.retrieveMasterKey(dbCtx, password)
+	if err != nil {
+		return "", err
+	}
+
+	sentTx, err := masterAcc.SendTransactionRequest(req, masterKey, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("failed to send %s transaction: %w", token, err)
+	}
+
+	return w.saveSentTransaction(token, sentTx)
+}
+
+func (w *Wallet) SignMessage(token, password string, accountIndex int, message []byte) (string, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return "", fmt.Errorf("token not found: %s", token)
+	}
+
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	masterKey, err := w.retrieveMasterKey(dbCtx, password)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := masterAcc.SignMessage(message, masterKey, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error signing %s message: %w", token, err)
+	}
+
+	return signature, nil
+}
+
+func (w *Wallet) SignTypedData(token, password string, accountIndex int, typedData []byte) (string, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return "", fmt.Errorf("token not found: %s", token)
+	}
+
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	masterKey, err := w.retrieveMasterKey(dbCtx, password)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := masterAcc.SignTypedData(typedData, masterKey, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error signing %s typed data: %w", token, err)
+	}
+
+	return signature, nil
+}
+
+// ReviewTypedData decodes an EIP-712 payload and checks its domain against the active network.
+func (w *Wallet) ReviewTypedData(token string, typedData []byte) (*eth.TypedDataReview, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	review, err := masterAcc.ReviewTypedData(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing %s typed data: %w", token, err)
+	}
+
+	return review, nil
+}
+
+func (w *Wallet) contractAccount(token string) (contractAccount, error) {
+	masterAcc, ok := w.Accounts[token]
+	if !ok {
+		return nil, fmt.Errorf("token not found: %s", token)
+	}
+
+	contractAcc, ok := masterAcc.(contractAccount)
+	if !ok {
+		return nil, fmt.Errorf("token %s does not support smart contracts", token)
+	}
+
+	return contractAcc, nil
+}
+
+// RegisterContract adds a contract ABI, plain or inside a Hardhat artifact, to the registry.
+func (w *Wallet) RegisterContract(token, name, address string, abiJSON []byte) (*eth.Contract, error) {
+	contractAcc, err := w.contractAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := contractAcc.RegisterContract(name, address, abiJSON)
+	if err != nil {
+		return nil, fmt.Errorf("error registering %s contract %s: %w", token, name, err)
+	}
+
+	return contract, nil
+}
+
+func (w *Wallet) GetContracts(token string) ([]*eth.Contract, error) {
+	contractAcc, err := w.contractAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := contractAcc.GetContracts()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s contracts: %w", token, err)
+	}
+
+	return contracts, nil
+}
+
+func (w *Wallet) RemoveContract(token, name string) error {
+	contractAcc, err := w.contractAccount(token)
+	if err != nil {
+		return err
+	}
+
+	err = contractAcc.RemoveContract(name)
+	if err != nil {
+		return fmt.Errorf("error removing %s contract %s: %w", token, name, err)
+	}
+
+	return nil
+}
+
+func (w *Wallet) CallContract(
+	token, contract, method string,
+	args []string,
+	accountIndex int,
+) ([]eth.ContractParam, error) {
+	contractAcc, err := w.contractAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := contractAcc.CallContract(contract, method, args, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error calling %s contract: %w", token, err)
+	}
+
+	return results, nil
+}
+
+func (w *Wallet) EstimateContractGas(
+	token, contract, method string,
+	args []string,
+	value string,
+	accountIndex int,
+) (string, error) {
+	contractAcc, err := w.contractAccount(token)
+	if err != nil {
+		return "", err
+	}
+
+	cost, err := contractAcc.EstimateContractGas(contract, method, args, value, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error estimating %s contract call gas: %w", token, err)
+	}
+
+	return cost, nil
+}
+
+// SendContractTransaction signs and broadcasts a state-changing method call and returns its hash.
+func (w *Wallet) SendContractTransaction(
+	token, password, contract, method string,
+	args []string,
+	value string,
+	accountIndex int,
+) (string, error) {
+	contractAcc, err := w.contractAccount(token)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := contractAcc.ContractTransaction(contract, method, args, value, accountIndex)
+	if err != nil {
+		return "", fmt.Errorf("error building %s contract transaction: %w", token, err)
+	}
+
+	return w.sendTransactionRequest(token, password, req)
+}
+
+func (w *Wallet) GetDemoTokenBalance(token, contract string, accountIndex int) (*eth.TokenBalance, error) {
+	contractAcc, err := w.contractAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := contractAcc.GetDemoTokenBalance(contract, accountIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s DemoToken balance: %w", token, err)
+	}
+
+	return balance, nil
+}
+
+func (w *Wallet) GetCurrentProposal(token, contract string) (*eth.Proposal, error) {
+	contractAcc, err := w.contractAccount(token)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal, err := contractAcc.GetCurrentProposal(contract)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s proposal: %w", token, err)
+	}
+
+	return proposal, nil
+}
+
+func (w *Wallet) CreateProposal(
+	token, password, contract, description string,
+	votesToEnd uint64,
+	accountIndex int,
+) (string, error) {
+	send := func(contractAcc contractAccount, masterKey *bip32.Key) (*eth.SentTransaction, error) {
+		return contractAcc.CreateProposal(contract, description, votesToEnd, masterKey, accountIndex)
+	}
+
+	return w.sendContractAction(token, password, send)
+}
+
+func (w *Wallet) VoteProposal(token, password, contract string, choice uint8, accountIndex int) (string, error) {
+	send := func(contractAcc contractAccount, masterKey *bip32.Key) (*eth.SentTransaction, error) {
+		return contractAcc.VoteProposal(contract, choice, masterKey, accountIndex)
+	}
+
+	return w.sendContractAction(token, password, send)
+}
+
+func (w *Wallet) GetCounter(token, contract string) (string, error) {
+	contractAcc, err := w.contractAccount(token)
+	if err != nil {
+		return "", err
+	}
+
+	counter, err := contractAcc.GetCounter(contract)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving %s counter: %w", token, err)
+	}
+
+	return counter, nil
+}
+
+func (w *Wallet) IncrementCounter(token, password, contract string, accountIndex int) (string, error) {
+	send := func(contractAcc contractAccount, masterKey *bip32.Key) (*eth.SentTransaction, error) {
+		return contractAcc.IncrementCounter(contract, masterKey, accountIndex)
+	}
+
+	return w.sendContractAction(token, password, send)
+}
+
+// sendContractAction sends a contract transaction through send and records it in the
+// transaction history.
+func (w *Wallet) sendContractAction(
+	token, password string,
+	send func(contractAcc contractAccount, masterKey *bip32.Key) (*eth.SentTransaction, error),
+) (string, error) {
+	contractAcc, err := w.contractAccount(token)
+	if err != nil {
+		return "", err
+	}
+
+	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
+	defer cancel()
+	masterKey, err := w.retrieveMasterKey(dbCtx, password)
+	if err != nil {
+		return "", err
+	}
+
+	sentTx, err := send(contractAcc, masterKey)
+	if err != nil {
+		return "", fmt.Errorf("error sending %s contract transaction: %w", token, err)
+	}
+
+	return w.saveSentTransaction(token, sentTx)
+}
+
+func (w *Wallet) retrieveMasterKey(ctx context.Context, password string) (*bip32.Key, error) {
+	pubKeyData, err := w.publicKey.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("error serializing master public key: %w", err)
+	}
+
+	pubKeyHex := hex.EncodeToString(pubKeyData)
+	masterKey, err := w.walletDB.RetrieveRootKeyFromDB(ctx, password, pubKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving key from DB: %w", err)
+	}
+
+	return masterKey, nil
+}
+
 func (w *Wallet) GetTransactions() ([]WalletTransaction, error) {
 	dbCtx, cancel := context.WithTimeout(w.ctx, 5*time.Second)
 	defer cancel()
@@ -15,13 +15,28 @@ type WalletStorage struct {
 	db *sql.DB
 }
 
+// Statuses of the transactions in the history. Sent transactions stay pending until
+// their receipt is found. A transaction is replaced when another one with the same
+// nonce, sped up or cancelling it, is mined instead.
+const (
+	StatusPending   = "PENDING"
+	StatusCompleted = "COMPLETED"
+	StatusFailed    = "FAILED"
+	StatusReplaced  = "REPLACED"
+)
+
 type WalletTransaction struct {
+	Hash      string `json:"hash"`
 	Sender    string `json:"sender"`
 	Recipient string `json:"recipient"`
 	Status    string `json:"status"`
 	Value     string `json:"value"`
 	Token     string `json:"token"`
 	CreatedAt string `json:"createdAt"`
+	// Replaces and ReplacedBy link a transaction with the ones sent with the same nonce
+	// to speed it up or cancel it.
+	Replaces   string `json:"replaces"`
+	ReplacedBy string `json:"replacedBy"`
 }
 
 func NewWalletStorage(ctx context.Context, filePath string) (*WalletStorage, error) {
@@ -48,15 +63,62 @@ func NewWalletStorage(ctx context.Context, filePath string) (*WalletStorage, err
 		value TEXT,
 		status TEXT,
 		token TEXT,
-		createdAt TEXT
+		createdAt TEXT,
+		hash TEXT DEFAULT '',
+		replaces TEXT DEFAULT '',
+		replacedBy TEXT DEFAULT ''
 	)`)
 	if err != nil {
 		return nil, fmt.Errorf("error creating wallets table: %w", err)
 	}
 
+	// Contact names are unique per token and network, regardless of case.
+	_, err = db.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS contacts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL COLLATE NOCASE,
+		address TEXT NOT NULL,
+		token TEXT NOT NULL,
+		network TEXT NOT NULL,
+		notes TEXT DEFAULT '',
+		UNIQUE (name, token, network)
+	)`)
+	if err != nil {
+		return nil, fmt.Errorf("error creating contacts table: %w", err)
+	}
+
+	for _, column := range []string{"hash", "replaces", "replacedBy"} {
+		err = addColumnIfMissing(ctx, db, "transactions", column, "TEXT DEFAULT ''")
+		if err != nil {
+			return nil, fmt.Errorf("error migrating transactions table: %w", err)
+		}
+	}
+
 	return &WalletStorage{db: db}, nil
 }
 
+// addColumnIfMissing upgrades tables created by older versions of the wallet.
+func addColumnIfMissing(ctx context.Context, db *sql.DB, table, column, definition string) error {
+	var count int
+	err := db.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?",
+		table,
+		column,
+	).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	_, err = db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
 func (ws *WalletStorage) WalletExists(ctx context.Context) (bool, error) {
 	var count int
 	err := ws.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM wallets").Scan(&count)
@@ -68,8 +130,47 @@ func (ws *WalletStorage) WalletExists(ctx context.Context) (bool, error) {
 }
 
 func (ws *WalletStorage) GetTransactions(ctx context.Context) ([]WalletTransaction, error) {
+	return ws.queryTransactions(ctx, "SELECT "+transactionColumns+" FROM transactions ORDER BY createdAt DESC")
+}
+
+// GetPendingTransactions returns the pending transactions of a token that were sent
+// with a known hash.
+func (ws *WalletStorage) GetPendingTransactions(ctx context.Context, token string) ([]WalletTransaction, error) {
+	return ws.queryTransactions(
+		ctx,
+		"SELECT "+transactionColumns+" FROM transactions WHERE token = ? AND status = ? AND hash != ''",
+		token,
+		StatusPending,
+	)
+}
+
+// GetTransaction finds a transaction of the history by its hash.
+func (ws *WalletStorage) GetTransaction(ctx context.Context, hash string) (*WalletTransaction, error) {
+	transactions, err := ws.queryTransactions(
+		ctx,
+		"SELECT "+transactionColumns+" FROM transactions WHERE lower(hash) = lower(?)",
+		hash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transactions) == 0 {
+		return nil, fmt.Errorf("transaction %s not found", hash)
+	}
+
+	return &transactions[0], nil
+}
+
+const transactionColumns = "hash, sender, recipient, value, status, token, createdAt, replaces, replacedBy"
+
+func (ws *WalletStorage) queryTransactions(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) ([]WalletTransaction, error) {
 	var transactions []WalletTransaction
-	rows, err := ws.db.QueryContext(ctx, "SELECT * FROM transactions ORDER BY createdAt DESC")
+	rows, err := ws.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving transactions from db: %w", err)
 	}
@@ -79,12 +180,15 @@ func (ws *WalletStorage) GetTransactions(ctx context.Context) ([]WalletTransacti
 	for rows.Next() {
 		var transaction WalletTransaction
 		err = rows.Scan(
+			&transaction.Hash,
 			&transaction.Sender,
 			&transaction.Recipient,
 			&transaction.Value,
 			&transaction.Status,
 			&transaction.Token,
-			&transaction.CreatedAt)
+			&transaction.CreatedAt,
+			&transaction.Replaces,
+			&transaction.ReplacedBy)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing db transaction data: %w", err)
 		}
@@ -192,12 +296,16 @@ func (ws *WalletStorage) RetrievePublicKeyFromDB(ctx context.Context) (*bip32.Ke
 	return pubKey, nil
 }
 
-func (ws *WalletStorage) SaveTransactionInDB(ctx context.Context, from, to, value, status, token, date string) error {
+func (ws *WalletStorage) SaveTransactionInDB(
+	ctx context.Context,
+	hash, from, to, value, status, token, date string,
+) error {
 	result, err := ws.db.ExecContext(
 		ctx,
 		`INSERT INTO transactions 
-		(sender, recipient, value, status, token, createdAt) 
-	 VALUES (?, ?, ?, ?, ?, ?)`,
+		(hash, sender, recipient, value, status, token, createdAt) 
+	 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		hash,
 		from,
 		to,
 		value,
@@ -221,6 +329,94 @@ func (ws *WalletStorage) SaveTransactionInDB(ctx context.Context, from, to, valu
 	return nil
 }
 
+// SaveReplacementInDB records a pending transaction sent to replace the one with hash
+// replaced, and links both.
+func (ws *WalletStorage) SaveReplacementInDB(
+	ctx context.Context,
+	replaced, hash, from, to, value, token, date string,
+) error {
+	tx, err := ws.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO transactions
+		(hash, sender, recipient, value, status, token, createdAt, replaces)
+	 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		hash,
+		from,
+		to,
+		value,
+		StatusPending,
+		token,
+		date,
+		replaced,
+	)
+	if err != nil {
+		return fmt.Errorf("error saving transaction into DB: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE transactions SET replacedBy = ? WHERE lower(hash) = lower(?)", hash, replaced)
+	if err != nil {
+		return fmt.Errorf("error linking replaced transaction: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ResolveReplacements marks as replaced the pending transactions sent with the same nonce
+// as the mined transaction hash, and returns them.
+func (ws *WalletStorage) ResolveReplacements(ctx context.Context, hash string) ([]WalletTransaction, error) {
+	mined, err := ws.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replacements form a chain: walk back to the first transaction, then forward.
+	first := mined
+	for first.Replaces != "" {
+		first, err = ws.GetTransaction(ctx, first.Replaces)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var replaced []WalletTransaction
+	current := first
+	for {
+		if current.Hash != mined.Hash && current.Status == StatusPending {
+			err = ws.UpdateTransactionStatus(ctx, current.Hash, StatusReplaced)
+			if err != nil {
+				return replaced, err
+			}
+
+			current.Status = StatusReplaced
+			replaced = append(replaced, *current)
+		}
+
+		if current.ReplacedBy == "" {
+			return replaced, nil
+		}
+
+		current, err = ws.GetTransaction(ctx, current.ReplacedBy)
+		if err != nil {
+			return replaced, err
+		}
+	}
+}
+
+func (ws *WalletStorage) UpdateTransactionStatus(ctx context.Context, hash, status string) error {
+	_, err := ws.db.ExecContext(ctx, "UPDATE transactions SET status = ? WHERE hash = ?", status, hash)
+	if err != nil {
+		return fmt.Errorf("error updating transaction status: %w", err)
+	}
+
+	return nil
+}
+
 func (ws *WalletStorage) Close() error {
 	if ws.db != nil {
 		return ws.db.Close()
@@ -11,6 +11,7 @@ import (
 
 	"github.com/tyler-smith/go-bip32"
 	"github.com/tyler-smith/go-bip39"
+	_ "modernc.org/sqlite"
 )
 
 func TestWalletStorageOperations(t *testing.T) {
@@ -0,0 +1,380 @@
+package hdwallet_test
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"wallet/internal/contracts"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/currencies/eth/ethsim"
+	"wallet/internal/hdwallet"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The wallet is restored from the default hardhat mnemonic, so its first accounts
+// are the well known hardhat accounts.
+const (
+	testMnemonic = "test test test test test test test test test test test junk"
+	testPassword = "password"
+	account0     = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	account1     = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+	account15    = "0xcd3B766CCDd6AE721141F452C550Ca635964ce71"
+	account0Key  = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+)
+
+func TestWalletWithSimulatedChain(t *testing.T) {
+	backend, err := ethsim.NewBackend(map[string]int64{account0: 100, account15: 7})
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer backend.Close()
+
+	wallet := newSimulatedWallet(t, backend)
+
+	t.Run("Balance comes from the chain", func(t *testing.T) {
+		assertWalletBalance(t, wallet, 0, "100")
+		assertWalletBalance(t, wallet, 1, "0")
+	})
+
+	t.Run("Balances of every account come from one batch", func(t *testing.T) {
+		balances, err := wallet.GetBalances("ETH")
+		if err != nil {
+			t.Fatalf("Failed to get balances: %v", err)
+		}
+
+		accounts, err := wallet.GetAllAccounts("ETH")
+		if err != nil {
+			t.Fatalf("Failed to get accounts: %v", err)
+		}
+
+		assertCorrectValue(t, len(balances), len(accounts))
+		assertCorrectValue(t, len(accounts), 21)
+		assertCorrectValue(t, accounts[15], account15)
+		assertCorrectValue(t, balances[0], "100")
+		assertCorrectValue(t, balances[1], "0")
+		assertCorrectValue(t, balances[15], "7")
+	})
+
+	t.Run("Sent transaction moves funds and is recorded", func(t *testing.T) {
+		ok, err := wallet.SendTransaction("ETH", testPassword, account1, "1.5", 0)
+		if err != nil || !ok {
+			t.Fatalf("Failed to send transaction: %v", err)
+		}
+		backend.Commit()
+
+		assertWalletBalance(t, wallet, 1, "1.5")
+		balance, err := wallet.GetBalance("ETH", 0)
+		if err != nil {
+			t.Fatalf("Failed to get balance: %v", err)
+		}
+
+		wei, err := eth.EtherToWei(balance)
+		if err != nil {
+			t.Fatalf("Failed to parse balance: %v", err)
+		}
+
+		if maxWei, _ := eth.EtherToWei("98.5"); wei.Cmp(maxWei) >= 0 {
+			t.Errorf("expected the sender to pay the transfer and its fee, got a balance of %v", balance)
+		}
+
+		transactions, err := wallet.GetTransactions()
+		if err != nil {
+			t.Fatalf("Failed to get transactions: %v", err)
+		}
+
+		assertCorrectValue(t, len(transactions), 1)
+		assertCorrectValue(t, transactions[0].Sender, account0)
+		assertCorrectValue(t, transactions[0].Recipient, account1)
+		assertCorrectValue(t, transactions[0].Value, "1.5")
+		assertCorrectValue(t, transactions[0].Status, hdwallet.StatusPending)
+
+		mined, err := wallet.UpdatePendingTransactions("ETH")
+		if err != nil {
+			t.Fatalf("Failed to update pending transactions: %v", err)
+		}
+
+		assertCorrectValue(t, len(mined), 1)
+		assertCorrectValue(t, mined[0].Hash, transactions[0].Hash)
+		assertCorrectValue(t, mined[0].Status, hdwallet.StatusCompleted)
+	})
+
+	t.Run("Review flags transfers to the wallet and invalid addresses", func(t *testing.T) {
+		review, err := wallet.ReviewTransaction("ETH", account1, "1", 0)
+		if err != nil {
+			t.Fatalf("Failed to review transaction: %v", err)
+		}
+		assertCorrectValue(t, len(review.Errors), 0)
+		assertCorrectValue(t, review.Warnings, []eth.ReviewIssue{{
+			Code:    eth.IssueOwnAccount,
+			Message: "The recipient is account 1 of this wallet.",
+		}})
+
+		invalid := "0x70997970c51812dc3a010c7d01b50e0d17dc79C8"
+		review, err = wallet.ReviewTransaction("ETH", invalid, "1", 0)
+		if err != nil {
+			t.Fatalf("Failed to review transaction: %v", err)
+		}
+		if !review.HasIssue(eth.IssueInvalidAddress) {
+			t.Errorf("Expected the bad checksum to be reported, got %+v", review)
+		}
+
+		_, err = wallet.SendTransaction("ETH", testPassword, invalid, "1", 0)
+		if err == nil || !strings.Contains(err.Error(), "invalid ETH address") {
+			t.Errorf("Expected the invalid address to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Wrong password does not send anything", func(t *testing.T) {
+		_, err := wallet.SendTransaction("ETH", "wrong password", account1, "1", 0)
+		if err == nil {
+			t.Fatalf("Expected an error sending with a wrong password")
+		}
+
+		transactions, err := wallet.GetTransactions()
+		if err != nil {
+			t.Fatalf("Failed to get transactions: %v", err)
+		}
+		assertCorrectValue(t, len(transactions), 1)
+	})
+
+	t.Run("Watch reports mined transactions and new balances", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		balances := make(chan hdwallet.BalanceUpdate, 10)
+		mined := make(chan []hdwallet.WalletTransaction, 10)
+		err := wallet.Watch(ctx, "ETH", hdwallet.WatchHandlers{
+			OnBalances:     func(update hdwallet.BalanceUpdate) { balances <- update },
+			OnTransactions: func(transactions []hdwallet.WalletTransaction) { mined <- transactions },
+		})
+		if err != nil {
+			t.Fatalf("Failed to watch the chain: %v", err)
+		}
+
+		assertCorrectValue(t, receive(t, balances).Balances[1], "1.5")
+
+		_, err = wallet.SendTransaction("ETH", testPassword, account1, "2", 0)
+		if err != nil {
+			t.Fatalf("Failed to send transaction: %v", err)
+		}
+		backend.Commit()
+
+		transactions := receive(t, mined)
+		assertCorrectValue(t, len(transactions), 1)
+		assertCorrectValue(t, transactions[0].Status, hdwallet.StatusCompleted)
+
+		update := receive(t, balances)
+		assertCorrectValue(t, update.Block, uint64(2))
+		assertCorrectValue(t, update.Balances[1], "3.5")
+	})
+
+	t.Run("Transactions sent in a row are all mined", func(t *testing.T) {
+		for _, value := range []string{"0.1", "0.2", "0.3"} {
+			_, err := wallet.SendTransaction("ETH", testPassword, account1, value, 0)
+			if err != nil {
+				t.Fatalf("Failed to send %s: %v", value, err)
+			}
+		}
+		backend.Commit()
+
+		mined, err := wallet.UpdatePendingTransactions("ETH")
+		if err != nil {
+			t.Fatalf("Failed to update pending transactions: %v", err)
+		}
+
+		assertCorrectValue(t, len(mined), 3)
+		for _, transaction := range mined {
+			assertCorrectValue(t, transaction.Status, hdwallet.StatusCompleted)
+		}
+		assertWalletBalance(t, wallet, 1, "4.1")
+
+		gaps, err := wallet.GetNonceGaps("ETH")
+		if err != nil {
+			t.Fatalf("Failed to check nonce gaps: %v", err)
+		}
+		assertCorrectValue(t, len(gaps), 0)
+	})
+
+	t.Run("Sped up and cancelled transactions are linked to their replacement", func(t *testing.T) {
+		_, err := wallet.SendTransaction("ETH", testPassword, account1, "1", 0)
+		if err != nil {
+			t.Fatalf("Failed to send transaction: %v", err)
+		}
+
+		original := findTransaction(t, wallet, func(tx hdwallet.WalletTransaction) bool {
+			return tx.Status == hdwallet.StatusPending
+		})
+		speedUp, err := wallet.SpeedUpTransaction(testPassword, original.Hash)
+		if err != nil {
+			t.Fatalf("Failed to speed up transaction: %v", err)
+		}
+
+		cancellation, err := wallet.CancelTransaction(testPassword, speedUp)
+		if err != nil {
+			t.Fatalf("Failed to cancel transaction: %v", err)
+		}
+		backend.Commit()
+
+		mined, err := wallet.UpdatePendingTransactions("ETH")
+		if err != nil {
+			t.Fatalf("Failed to update pending transactions: %v", err)
+		}
+		assertCorrectValue(t, len(mined), 3)
+
+		statuses := map[string]string{
+			original.Hash: hdwallet.StatusReplaced,
+			speedUp:       hdwallet.StatusReplaced,
+			cancellation:  hdwallet.StatusCompleted,
+		}
+		links := map[string][2]string{
+			original.Hash: {"", speedUp},
+			speedUp:       {original.Hash, cancellation},
+			cancellation:  {speedUp, ""},
+		}
+		for hash, status := range statuses {
+			transaction := findTransaction(t, wallet, func(tx hdwallet.WalletTransaction) bool {
+				return tx.Hash == hash
+			})
+			assertCorrectValue(t, transaction.Status, status)
+			assertCorrectValue(t, [2]string{transaction.Replaces, transaction.ReplacedBy}, links[hash])
+		}
+
+		// The cancellation was mined instead of the transfer.
+		assertWalletBalance(t, wallet, 1, "4.1")
+
+		_, err = wallet.SpeedUpTransaction(testPassword, cancellation)
+		if err == nil || !strings.Contains(err.Error(), "only pending transactions can be replaced") {
+			t.Errorf("Expected mined transactions not to be replaced, got %v", err)
+		}
+	})
+
+	t.Run("Increment contract through the generated bindings", func(t *testing.T) {
+		contract := deployIncrement(t, backend, 5)
+		counter, err := wallet.GetCounter("ETH", contract)
+		if err != nil {
+			t.Fatalf("Failed to read counter: %v", err)
+		}
+		assertCorrectValue(t, counter, "5")
+
+		_, err = wallet.IncrementCounter("ETH", testPassword, contract, 0)
+		if err != nil {
+			t.Fatalf("Failed to increment counter: %v", err)
+		}
+		backend.Commit()
+
+		counter, err = wallet.GetCounter("ETH", contract)
+		if err != nil {
+			t.Fatalf("Failed to read counter: %v", err)
+		}
+		assertCorrectValue(t, counter, "6")
+
+		_, err = wallet.IncrementCounter("ETH", testPassword, contract, 1)
+		if err == nil || !strings.Contains(err.Error(), "Only owner can call this function") {
+			t.Errorf("Expected the owner check to revert, got %v", err)
+		}
+	})
+}
+
+func newSimulatedWallet(t testing.TB, backend eth.Backend) *hdwallet.Wallet {
+	t.Helper()
+	ctx := context.Background()
+	ws, err := hdwallet.NewWalletStorage(ctx, ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create wallet storage: %v", err)
+	}
+	t.Cleanup(func() { ws.Close() })
+
+	wallet, err := hdwallet.RestoreWallet(ctx, testPassword, testMnemonic, ws)
+	if err != nil {
+		t.Fatalf("Failed to restore wallet: %v", err)
+	}
+
+	err = wallet.Initialize([]string{"ETH"}, testPassword)
+	if err != nil {
+		t.Fatalf("Failed to initialize wallet: %v", err)
+	}
+
+	err = wallet.SetBackend("ETH", backend)
+	if err != nil {
+		t.Fatalf("Failed to set backend: %v", err)
+	}
+
+	return wallet
+}
+
+func deployIncrement(t testing.TB, backend *ethsim.Backend, initialValue int64) string {
+	t.Helper()
+	privateKey, err := crypto.HexToECDSA(account0Key)
+	if err != nil {
+		t.Fatalf("Failed to parse private key: %v", err)
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(ethsim.ChainID))
+	if err != nil {
+		t.Fatalf("Failed to create transactor: %v", err)
+	}
+
+	contractBackend := eth.NewContractBackend(eth.NewClientWithBackend(backend))
+	address, _, _, err := contracts.DeployIncrement(opts, contractBackend, big.NewInt(initialValue), "test counter")
+	if err != nil {
+		t.Fatalf("Failed to deploy Increment: %v", err)
+	}
+	backend.Commit()
+
+	return address.Hex()
+}
+
+func assertWalletBalance(t testing.TB, wallet *hdwallet.Wallet, accountIndex int, want string) {
+	t.Helper()
+	balance, err := wallet.GetBalance("ETH", accountIndex)
+	if err != nil {
+		t.Fatalf("Failed to get balance: %v", err)
+	}
+
+	assertCorrectValue(t, balance, want)
+}
+
+func receive[T any](t testing.TB, ch <-chan T) T {
+	t.Helper()
+	select {
+	case value := <-ch:
+		return value
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for an update")
+		var zero T
+		return zero
+	}
+}
+
+func assertCorrectValue[T any](t testing.TB, got, want T) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func findTransaction(
+	t testing.TB,
+	wallet *hdwallet.Wallet,
+	match func(hdwallet.WalletTransaction) bool,
+) hdwallet.WalletTransaction {
+	t.Helper()
+	transactions, err := wallet.GetTransactions()
+	if err != nil {
+		t.Fatalf("Failed to get transactions: %v", err)
+	}
+
+	for _, transaction := range transactions {
+		if match(transaction) {
+			return transaction
+		}
+	}
+
+	t.Fatalf("Transaction not found in the history")
+	return hdwallet.WalletTransaction{}
+}
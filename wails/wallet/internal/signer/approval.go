@@ -0,0 +1,207 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"wallet/internal/currencies/eth"
+)
+
+// ApprovalRequest describes a signer request that is waiting for the user's decision. Call
+// decodes the data of Transaction when it calls a contract, and RecipientWarnings flag a
+// recipient that is a lookalike of a known address or was never sent to.
+type ApprovalRequest struct {
+	Method            string                  `json:"method"`
+	Origin            string                  `json:"origin"`
+	Account           string                  `json:"account,omitempty"`
+	Transaction       *eth.TransactionRequest `json:"transaction,omitempty"`
+	Call              *eth.DecodedCall        `json:"call,omitempty"`
+	RecipientWarnings []eth.ReviewIssue       `json:"recipientWarnings,omitempty"`
+	Message           string                  `json:"message,omitempty"`
+	TypedData         *eth.TypedDataReview    `json:"typedData,omitempty"`
+}
+
+// ApprovalFunc decides whether a request can be served. It is backed by a CLI prompt,
+// a GUI dialog or a rules file.
+type ApprovalFunc func(ctx context.Context, req *ApprovalRequest) (bool, error)
+
+func (r *ApprovalRequest) String() string {
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "%s request from %s", r.Method, r.Origin)
+	if r.Account != "" {
+		fmt.Fprintf(&summary, "\naccount: %s", r.Account)
+	}
+
+	if r.Transaction != nil {
+		to := "contract creation"
+		if r.Transaction.To != nil {
+			to = r.Transaction.To.Hex()
+		}
+		fmt.Fprintf(&summary, "\nto: %s", to)
+		for _, warning := range r.RecipientWarnings {
+			fmt.Fprintf(&summary, "\nWARNING: %s", warning.Message)
+		}
+
+		value := "0"
+		if r.Transaction.Value != nil {
+			value = r.Transaction.Value.ToInt().String()
+		}
+		fmt.Fprintf(&summary, "\nvalue: %s wei", value)
+
+		gas := "estimated by the node"
+		if r.Transaction.Gas != nil {
+			gas = fmt.Sprintf("%d", uint64(*r.Transaction.Gas))
+		}
+		fmt.Fprintf(&summary, "\ngas: %s", gas)
+
+		gasPrice := r.Transaction.GasPrice
+		if gasPrice == nil {
+			gasPrice = r.Transaction.MaxFeePerGas
+		}
+		if gasPrice != nil {
+			fmt.Fprintf(&summary, "\ngas price: %s gwei", eth.NewAmount(gasPrice.ToInt(), eth.GweiDecimals).String())
+		} else {
+			fmt.Fprintf(&summary, "\ngas price: set by the node")
+		}
+
+		if maxFee := r.Transaction.MaxFee(); maxFee != nil {
+			fmt.Fprintf(&summary, "\nmax fee: %s ether", eth.Ether(maxFee).String())
+		}
+
+		nonce := "next of the account"
+		if r.Transaction.Nonce != nil {
+			nonce = fmt.Sprintf("%d", uint64(*r.Transaction.Nonce))
+		}
+		fmt.Fprintf(&summary, "\nnonce: %s", nonce)
+
+		if r.Call != nil {
+			fmt.Fprintf(&summary, "\ncall: %s", r.Call.String())
+		} else if data := r.Transaction.CallData(); len(data) > 0 {
+			fmt.Fprintf(&summary, "\ndata: %s", data.String())
+		}
+	}
+
+	if r.Message != "" {
+		fmt.Fprintf(&summary, "\nmessage: %s", r.Message)
+	}
+
+	if r.TypedData != nil {
+		fmt.Fprintf(&summary, "\ntyped data: %s", r.TypedData.String())
+	}
+
+	return summary.String()
+}
+
+// Rules is an approval policy loaded from a JSON file. Only the listed methods are
+// approved, and empty account or recipient lists do not restrict anything. Calls granting
+// unlimited or collection-wide approvals are always left to the user, as are recipients
+// with warnings unless allowedRecipients lists them. With a max fee, only
+// transactions setting their gas and gas price can be approved, so the node cannot raise it.
+type Rules struct {
+	AllowedMethods    []string `json:"allowedMethods"`
+	AllowedAccounts   []string `json:"allowedAccounts"`
+	AllowedRecipients []string `json:"allowedRecipients"`
+	MaxValue          string   `json:"maxValue"`
+	MaxFee            string   `json:"maxFee"`
+}
+
+func LoadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rules file: %w", err)
+	}
+
+	var rules Rules
+	err = json.Unmarshal(data, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rules file: %w", err)
+	}
+
+	if rules.MaxValue != "" {
+		_, err = eth.EtherToWei(rules.MaxValue)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing max value: %w", err)
+		}
+	}
+
+	if rules.MaxFee != "" {
+		_, err = eth.EtherToWei(rules.MaxFee)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing max fee: %w", err)
+		}
+	}
+
+	return &rules, nil
+}
+
+func (r *Rules) Approve(_ context.Context, req *ApprovalRequest) (bool, error) {
+	if !slices.Contains(r.AllowedMethods, req.Method) {
+		return false, nil
+	}
+
+	if req.Account != "" && !containsAddress(r.AllowedAccounts, req.Account) {
+		return false, nil
+	}
+
+	if req.TypedData != nil && req.TypedData.ChainIDMismatch {
+		return false, nil
+	}
+
+	if req.Transaction == nil {
+		return true, nil
+	}
+
+	if req.Call == nil && len(req.Transaction.CallData()) > 0 {
+		return false, nil
+	}
+
+	if req.Call != nil && (req.Call.HasRisk(eth.RiskUnlimitedApproval) || req.Call.HasRisk(eth.RiskApprovalForAll)) {
+		return false, nil
+	}
+
+	if req.Transaction.To == nil || !containsAddress(r.AllowedRecipients, req.Transaction.To.Hex()) {
+		return false, nil
+	}
+
+	if len(req.RecipientWarnings) > 0 && len(r.AllowedRecipients) == 0 {
+		return false, nil
+	}
+
+	if r.MaxValue != "" && req.Transaction.Value != nil {
+		maxValue, err := eth.EtherToWei(r.MaxValue)
+		if err != nil {
+			return false, fmt.Errorf("error parsing max value: %w", err)
+		}
+
+		if req.Transaction.Value.ToInt().Cmp(maxValue) > 0 {
+			return false, nil
+		}
+	}
+
+	if r.MaxFee != "" {
+		maxFee, err := eth.EtherToWei(r.MaxFee)
+		if err != nil {
+			return false, fmt.Errorf("error parsing max fee: %w", err)
+		}
+
+		fee := req.Transaction.MaxFee()
+		if fee == nil || fee.Cmp(maxFee) > 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func containsAddress(addresses []string, address string) bool {
+	if len(addresses) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(addresses, func(allowed string) bool {
+		return strings.EqualFold(allowed, address)
+	})
+}
@@ -0,0 +1,472 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/hdwallet"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/labstack/gommon/log"
+)
+
+const (
+	token = "ETH"
+
+	errCodeParse          = -32700
+	errCodeInvalidRequest = -32600
+	errCodeMethodNotFound = -32601
+	errCodeInvalidParams  = -32602
+	errCodeInternal       = -32000
+	errCodeUserRejected   = 4001
+)
+
+// Server exposes the wallet accounts to dApp tooling through a local JSON-RPC endpoint,
+// asking the approval callback before serving any request.
+type Server struct {
+	wallet   *hdwallet.Wallet
+	password string
+	approve  ApprovalFunc
+}
+
+type rpcRequest struct {
+	Jsonrpc string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type rpcResponse struct {
+	Jsonrpc string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func NewServer(wallet *hdwallet.Wallet, password string, approve ApprovalFunc) *Server {
+	return &Server{
+		wallet:   wallet,
+		password: password,
+		approve:  approve,
+	}
+}
+
+// Listen binds addr for the HTTP endpoint. The signer has no authentication of its own,
+// so only loopback addresses are accepted.
+func Listen(ctx context.Context, addr string) (net.Listener, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signer address %q: %w", addr, err)
+	}
+
+	if !isLoopbackHost(host) {
+		return nil, fmt.Errorf("signer address %q is not a loopback address", addr)
+	}
+
+	var listenConfig net.ListenConfig
+	listener, err := listenConfig.Listen(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("error listening on signer HTTP endpoint: %w", err)
+	}
+
+	return listener, nil
+}
+
+// ListenAndServe serves HTTP requests on the loopback address addr until ctx is cancelled.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	listener, err := Listen(ctx, addr)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(ctx, listener)
+}
+
+// Serve serves HTTP requests on listener until ctx is cancelled. Callers binding the
+// listener themselves get address errors before the server starts.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	httpServer := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := httpServer.Shutdown(shutdownCtx)
+		if err != nil {
+			log.Errorf("error shutting down signer server: %v", err)
+		}
+	}()
+
+	err := httpServer.Serve(listener)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("error serving signer HTTP endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// ServeIPC serves newline delimited JSON-RPC requests on a unix socket until ctx is cancelled.
+func (s *Server) ServeIPC(ctx context.Context, path string) error {
+	err := os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error removing stale IPC socket: %w", err)
+	}
+
+	var listenConfig net.ListenConfig
+	listener, err := listenConfig.Listen(ctx, "unix", path)
+	if err != nil {
+		return fmt.Errorf("error listening on IPC socket: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("error accepting IPC connection: %w", err)
+		}
+
+		go s.serveConn(ctx, conn)
+	}
+}
+
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	decoder := json.NewDecoder(conn)
+	encoder := json.NewEncoder(conn)
+	for {
+		var req rpcRequest
+		err := decoder.Decode(&req)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				log.Errorf("error decoding IPC request: %v", err)
+			}
+			return
+		}
+
+		err = encoder.Encode(s.handle(ctx, "ipc", &req))
+		if err != nil {
+			log.Errorf("error writing IPC response: %v", err)
+			return
+		}
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// A web page can point its own domain at 127.0.0.1 to talk to the signer as same
+	// origin, so the Host header has to name the loopback interface.
+	if !isLoopbackHost(requestHost(r)) {
+		http.Error(w, "invalid host specified", http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Requiring a JSON content type makes browsers send a CORS preflight first. It gets
+	// a method not allowed answer without CORS headers, so cross-origin pages never get
+	// to send the request.
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var req rpcRequest
+	var response *rpcResponse
+	err = json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req)
+	if err != nil {
+		response = &rpcResponse{Jsonrpc: "2.0", Error: &rpcError{Code: errCodeParse, Message: err.Error()}}
+	} else {
+		response = s.handle(r.Context(), r.RemoteAddr, &req)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		log.Errorf("error writing signer response: %v", err)
+	}
+}
+
+func requestHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		return strings.TrimSuffix(strings.TrimPrefix(r.Host, "["), "]")
+	}
+
+	return host
+}
+
+func isLoopbackHost(host string) bool {
+	return strings.EqualFold(host, "localhost") || host == "127.0.0.1" || host == "::1"
+}
+
+func (s *Server) handle(ctx context.Context, origin string, req *rpcRequest) *rpcResponse {
+	response := &rpcResponse{Jsonrpc: "2.0", ID: req.ID}
+	if req.Jsonrpc != "2.0" || req.Method == "" {
+		response.Error = &rpcError{Code: errCodeInvalidRequest, Message: "invalid JSON-RPC request"}
+		return response
+	}
+
+	var result interface{}
+	var err *rpcError
+	switch req.Method {
+	case "eth_accounts":
+		result, err = s.accounts(ctx, origin)
+	case "eth_sendTransaction":
+		result, err = s.transaction(ctx, origin, req, true)
+	case "eth_signTransaction":
+		result, err = s.transaction(ctx, origin, req, false)
+	case "personal_sign":
+		result, err = s.personalSign(ctx, origin, req)
+	case "eth_signTypedData_v4":
+		result, err = s.signTypedData(ctx, origin, req)
+	default:
+		err = &rpcError{Code: errCodeMethodNotFound, Message: fmt.Sprintf("method %s is not supported", req.Method)}
+	}
+
+	response.Result = result
+	response.Error = err
+	return response
+}
+
+func (s *Server) accounts(ctx context.Context, origin string) (interface{}, *rpcError) {
+	rpcErr := s.requestApproval(ctx, &ApprovalRequest{Method: "eth_accounts", Origin: origin})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	accounts, err := s.wallet.GetAllAccounts(token)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	indexes := make([]int, 0, len(accounts))
+	for index := range accounts {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	addresses := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		addresses = append(addresses, accounts[index])
+	}
+
+	return addresses, nil
+}
+
+func (s *Server) transaction(
+	ctx context.Context, origin string, req *rpcRequest, broadcast bool,
+) (interface{}, *rpcError) {
+	if len(req.Params) < 1 {
+		return nil, &rpcError{Code: errCodeInvalidParams, Message: "missing transaction object"}
+	}
+
+	var txRequest eth.TransactionRequest
+	err := json.Unmarshal(req.Params[0], &txRequest)
+	if err != nil {
+		return nil, &rpcError{Code: errCodeInvalidParams, Message: err.Error()}
+	}
+
+	_, err = s.wallet.GetAccountIndex(token, txRequest.From.Hex())
+	if err != nil {
+		return nil, &rpcError{Code: errCodeInvalidParams, Message: err.Error()}
+	}
+
+	// The approval has to show the calldata that gets signed.
+	if len(txRequest.Data) > 0 && len(txRequest.Input) > 0 && !bytes.Equal(txRequest.Data, txRequest.Input) {
+		return nil, &rpcError{Code: errCodeInvalidParams, Message: "data and input differ"}
+	}
+
+	var call *eth.DecodedCall
+	if txRequest.To != nil && len(txRequest.CallData()) > 0 {
+		call, err = s.wallet.DecodeCall(token, txRequest.To.Hex(), txRequest.CallData())
+		if err != nil {
+			return nil, internalError(err)
+		}
+	}
+
+	var recipientWarnings []eth.ReviewIssue
+	if txRequest.To != nil {
+		recipientWarnings, err = s.wallet.CheckRecipient(token, txRequest.To.Hex())
+		if err != nil {
+			return nil, internalError(err)
+		}
+	}
+
+	rpcErr := s.requestApproval(ctx, &ApprovalRequest{
+		Method:            req.Method,
+		Origin:            origin,
+		Account:           txRequest.From.Hex(),
+		Transaction:       &txRequest,
+		Call:              call,
+		RecipientWarnings: recipientWarnings,
+	})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	// The approval shows the recipient warnings, so it confirms the recipient.
+	if len(recipientWarnings) > 0 {
+		s.wallet.ConfirmRecipient(token, txRequest.To.Hex())
+	}
+
+	if broadcast {
+		txHash, err := s.wallet.SendTransactionRequest(token, s.password, &txRequest)
+		if err != nil {
+			return nil, internalError(err)
+		}
+		return txHash, nil
+	}
+
+	rawTx, err := s.wallet.SignTransactionRequest(token, s.password, &txRequest)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	return rawTx, nil
+}
+
+func (s *Server) personalSign(ctx context.Context, origin string, req *rpcRequest) (interface{}, *rpcError) {
+	var data, address string
+	rpcErr := unmarshalParams(req.Params, &data, &address)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	message, err := hexutil.Decode(data)
+	if err != nil {
+		message = []byte(data)
+	}
+
+	accountIndex, err := s.wallet.GetAccountIndex(token, address)
+	if err != nil {
+		return nil, &rpcError{Code: errCodeInvalidParams, Message: err.Error()}
+	}
+
+	rpcErr = s.requestApproval(ctx, &ApprovalRequest{
+		Method:  req.Method,
+		Origin:  origin,
+		Account: common.HexToAddress(address).Hex(),
+		Message: string(message),
+	})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	signature, err := s.wallet.SignMessage(token, s.password, accountIndex, message)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	return signature, nil
+}
+
+func (s *Server) signTypedData(ctx context.Context, origin string, req *rpcRequest) (interface{}, *rpcError) {
+	if len(req.Params) != 2 {
+		return nil, &rpcError{Code: errCodeInvalidParams, Message: "expected address and typed data"}
+	}
+
+	var address string
+	err := json.Unmarshal(req.Params[0], &address)
+	if err != nil {
+		return nil, &rpcError{Code: errCodeInvalidParams, Message: err.Error()}
+	}
+
+	// Some tools send the typed data as a JSON encoded string instead of an object.
+	typedData := []byte(req.Params[1])
+	var typedDataString string
+	if json.Unmarshal(req.Params[1], &typedDataString) == nil {
+		typedData = []byte(typedDataString)
+	}
+
+	accountIndex, err := s.wallet.GetAccountIndex(token, address)
+	if err != nil {
+		return nil, &rpcError{Code: errCodeInvalidParams, Message: err.Error()}
+	}
+
+	review, err := s.wallet.ReviewTypedData(token, typedData)
+	if err != nil {
+		return nil, &rpcError{Code: errCodeInvalidParams, Message: err.Error()}
+	}
+
+	rpcErr := s.requestApproval(ctx, &ApprovalRequest{
+		Method:    req.Method,
+		Origin:    origin,
+		Account:   common.HexToAddress(address).Hex(),
+		TypedData: review,
+	})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	signature, err := s.wallet.SignTypedData(token, s.password, accountIndex, typedData)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	return signature, nil
+}
+
+func (s *Server) requestApproval(ctx context.Context, req *ApprovalRequest) *rpcError {
+	approved, err := s.approve(ctx, req)
+	if err != nil {
+		return internalError(fmt.Errorf("error requesting approval: %w", err))
+	}
+
+	if !approved {
+		return &rpcError{Code: errCodeUserRejected, Message: "request rejected by the user"}
+	}
+
+	return nil
+}
+
+func unmarshalParams(params []json.RawMessage, values ...interface{}) *rpcError {
+	if len(params) != len(values) {
+		return &rpcError{
+			Code:    errCodeInvalidParams,
+			Message: fmt.Sprintf("expected %d params, got %d", len(values), len(params)),
+		}
+	}
+
+	for i, value := range values {
+		err := json.Unmarshal(params[i], value)
+		if err != nil {
+			return &rpcError{Code: errCodeInvalidParams, Message: err.Error()}
+		}
+	}
+
+	return nil
+}
+
+func internalError(err error) *rpcError {
+	return &rpcError{Code: errCodeInternal, Message: err.Error()}
+}
@@ -0,0 +1,503 @@
+package signer_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"wallet/internal/currencies/eth"
+	"wallet/internal/hdwallet"
+	"wallet/internal/signer"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	mnemonic = "test test test test test test test test test test test junk"
+	password = "password"
+	account0 = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+)
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func TestSignerServer(t *testing.T) {
+	wallet := newTestWallet(t)
+	var mu sync.Mutex
+	approved := true
+	var requests []*signer.ApprovalRequest
+	approve := func(_ context.Context, req *signer.ApprovalRequest) (bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, req)
+		return approved, nil
+	}
+	setApproved := func(value bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		approved = value
+	}
+	requestCount := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(requests)
+	}
+	lastRequest := func() *signer.ApprovalRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests[len(requests)-1]
+	}
+
+	server := httptest.NewServer(signer.NewServer(wallet, password, approve))
+	defer server.Close()
+
+	t.Run("eth_accounts lists the wallet addresses in order", func(t *testing.T) {
+		response := callSigner(t, server.URL, "eth_accounts")
+		var addresses []string
+		err := json.Unmarshal(response.Result, &addresses)
+		if err != nil {
+			t.Fatalf("Failed to decode accounts: %v", err)
+		}
+
+		assertCorrectValue(t, len(addresses), 21)
+		assertCorrectValue(t, addresses[0], account0)
+	})
+
+	t.Run("personal_sign signature recovers the account", func(t *testing.T) {
+		message := "login nonce 42"
+		response := callSigner(t, server.URL, "personal_sign", hexutil.Encode([]byte(message)), account0)
+		var signature string
+		err := json.Unmarshal(response.Result, &signature)
+		if err != nil {
+			t.Fatalf("Failed to decode signature: %v", err)
+		}
+
+		sig := hexutil.MustDecode(signature)
+		sig[crypto.RecoveryIDOffset] -= 27
+		pubKey, err := crypto.SigToPub(accounts.TextHash([]byte(message)), sig)
+		if err != nil {
+			t.Fatalf("Failed to recover public key: %v", err)
+		}
+
+		assertCorrectValue(t, crypto.PubkeyToAddress(*pubKey).Hex(), account0)
+		assertCorrectValue(t, lastRequest().Message, message)
+	})
+
+	t.Run("accounts past the first ten can sign", func(t *testing.T) {
+		address, err := wallet.GetAccountAddress("ETH", 15)
+		if err != nil {
+			t.Fatalf("Failed to get account address: %v", err)
+		}
+
+		response := callSigner(t, server.URL, "personal_sign", hexutil.Encode([]byte("hello")), address)
+		if response.Error != nil {
+			t.Fatalf("Unexpected error: %s", response.Error.Message)
+		}
+
+		assertCorrectValue(t, lastRequest().Account, address)
+	})
+
+	t.Run("rejected requests return a user rejection error", func(t *testing.T) {
+		setApproved(false)
+		defer setApproved(true)
+		response := callSigner(t, server.URL, "personal_sign", "hello", account0)
+		if response.Error == nil {
+			t.Fatalf("Expected a rejection error")
+		}
+
+		assertCorrectValue(t, response.Error.Code, 4001)
+	})
+
+	t.Run("unknown accounts are refused before asking for approval", func(t *testing.T) {
+		count := requestCount()
+		response := callSigner(t, server.URL, "personal_sign", "hello", "0x0000000000000000000000000000000000000001")
+		if response.Error == nil {
+			t.Fatalf("Expected an invalid params error")
+		}
+
+		assertCorrectValue(t, response.Error.Code, -32602)
+		assertCorrectValue(t, requestCount(), count)
+	})
+
+	t.Run("transaction data is decoded for the approval", func(t *testing.T) {
+		setApproved(false)
+		defer setApproved(true)
+		spender := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+		data := append(hexutil.MustDecode("0x095ea7b3"), common.LeftPadBytes(spender.Bytes(), 32)...)
+		data = append(data, math.MaxBig256.FillBytes(make([]byte, 32))...)
+		response := callSigner(t, server.URL, "eth_sendTransaction", map[string]string{
+			"from": account0,
+			"to":   "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+			"data": hexutil.Encode(data),
+		})
+		if response.Error == nil {
+			t.Fatalf("Expected a rejection error")
+		}
+
+		call := lastRequest().Call
+		if call == nil {
+			t.Fatalf("Expected the call data to be decoded")
+		}
+		assertCorrectValue(t, call.Signature, "approve(address,uint256)")
+		assertCorrectValue(t, call.HasRisk(eth.RiskUnlimitedApproval), true)
+	})
+
+	t.Run("transaction input is decoded for the approval", func(t *testing.T) {
+		setApproved(false)
+		defer setApproved(true)
+		spender := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+		data := append(hexutil.MustDecode("0x095ea7b3"), common.LeftPadBytes(spender.Bytes(), 32)...)
+		data = append(data, math.MaxBig256.FillBytes(make([]byte, 32))...)
+		response := callSigner(t, server.URL, "eth_sendTransaction", map[string]string{
+			"from":  account0,
+			"to":    "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+			"input": hexutil.Encode(data),
+		})
+		if response.Error == nil {
+			t.Fatalf("Expected a rejection error")
+		}
+
+		call := lastRequest().Call
+		if call == nil {
+			t.Fatalf("Expected the input to be decoded")
+		}
+		assertCorrectValue(t, call.Signature, "approve(address,uint256)")
+		assertCorrectValue(t, call.HasRisk(eth.RiskUnlimitedApproval), true)
+	})
+
+	t.Run("differing data and input are refused", func(t *testing.T) {
+		before := requestCount()
+		response := callSigner(t, server.URL, "eth_sendTransaction", map[string]string{
+			"from":  account0,
+			"to":    "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+			"data":  "0xa9059cbb",
+			"input": "0x095ea7b3",
+		})
+		if response.Error == nil {
+			t.Fatalf("Expected an invalid params error")
+		}
+
+		assertCorrectValue(t, response.Error.Code, -32602)
+		assertCorrectValue(t, requestCount(), before)
+	})
+
+	t.Run("first-time recipients are flagged for the approval", func(t *testing.T) {
+		setApproved(false)
+		defer setApproved(true)
+		callSigner(t, server.URL, "eth_sendTransaction", map[string]string{
+			"from":  account0,
+			"to":    "0x000000000000000000000000000000000000dEaD",
+			"value": "0x1",
+		})
+
+		warnings := lastRequest().RecipientWarnings
+		if len(warnings) != 1 || warnings[0].Code != eth.IssueFirstTimeRecipient {
+			t.Fatalf("Expected a first-time recipient warning, got %+v", warnings)
+		}
+	})
+
+	t.Run("unsupported methods are reported", func(t *testing.T) {
+		response := callSigner(t, server.URL, "eth_sign", account0, "0x00")
+		if response.Error == nil {
+			t.Fatalf("Expected a method not found error")
+		}
+
+		assertCorrectValue(t, response.Error.Code, -32601)
+	})
+
+	t.Run("non JSON requests are refused", func(t *testing.T) {
+		resp, err := http.Post(server.URL, "text/plain", bytes.NewBufferString(`{}`))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		assertCorrectValue(t, resp.StatusCode, http.StatusUnsupportedMediaType)
+	})
+
+	t.Run("requests for other hosts are refused", func(t *testing.T) {
+		for host, want := range map[string]int{
+			"attacker.example:8550": http.StatusForbidden,
+			"127.0.0.1.nip.io":      http.StatusForbidden,
+			"localhost:8550":        http.StatusOK,
+			"[::1]:8550":            http.StatusOK,
+			"127.0.0.1":             http.StatusOK,
+		} {
+			body := bytes.NewBufferString(`{"jsonrpc":"2.0","id":1,"method":"eth_accounts","params":[]}`)
+			req, err := http.NewRequest(http.MethodPost, server.URL, body)
+			if err != nil {
+				t.Fatalf("Failed to build request: %v", err)
+			}
+			req.Host = host
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != want {
+				t.Errorf("Expected status %d for host %s, got %d", want, host, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("only loopback addresses are served", func(t *testing.T) {
+		for _, addr := range []string{":0", "0.0.0.0:0", "[::]:0", "192.168.1.10:0", "8550"} {
+			exposed := signer.NewServer(wallet, password, approve)
+			err := exposed.ListenAndServe(context.Background(), addr)
+			if err == nil || !strings.Contains(err.Error(), "signer address") {
+				t.Errorf("Expected %s to be refused, got %v", addr, err)
+			}
+		}
+
+		for _, addr := range []string{"127.0.0.1:0", "localhost:0"} {
+			listener, err := signer.Listen(context.Background(), addr)
+			if err != nil {
+				t.Fatalf("Failed to listen on %s: %v", addr, err)
+			}
+			listener.Close()
+		}
+	})
+
+	t.Run("addresses already in use are reported", func(t *testing.T) {
+		busy := signer.NewServer(wallet, password, approve)
+		err := busy.ListenAndServe(context.Background(), server.Listener.Addr().String())
+		if err == nil || !strings.Contains(err.Error(), "error listening on signer HTTP endpoint") {
+			t.Fatalf("Expected a listen error, got %v", err)
+		}
+	})
+}
+
+func TestRulesApproval(t *testing.T) {
+	recipient := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	other := common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")
+	rules := &signer.Rules{
+		AllowedMethods:    []string{"eth_accounts", "eth_sendTransaction"},
+		AllowedRecipients: []string{recipient.Hex()},
+		MaxValue:          "1.5",
+		MaxFee:            "0.001",
+	}
+
+	cases := []struct {
+		name string
+		req  *signer.ApprovalRequest
+		want bool
+	}{
+		{
+			name: "Allowed method without transaction",
+			req:  &signer.ApprovalRequest{Method: "eth_accounts"},
+			want: true,
+		},
+		{
+			name: "Method not listed",
+			req:  &signer.ApprovalRequest{Method: "personal_sign", Account: account0},
+			want: false,
+		},
+		{
+			name: "Transfer below the limit to an allowed recipient",
+			req:  transferRequest(recipient, "1"),
+			want: true,
+		},
+		{
+			name: "Transfer above the limit",
+			req:  transferRequest(recipient, "2"),
+			want: false,
+		},
+		{
+			name: "Transfer to a recipient not listed",
+			req:  transferRequest(other, "1"),
+			want: false,
+		},
+		{
+			name: "Unlimited approval to an allowed recipient",
+			req: func() *signer.ApprovalRequest {
+				req := transferRequest(recipient, "0")
+				req.Call = &eth.DecodedCall{
+					Function: "approve",
+					Risks:    []eth.ReviewIssue{{Code: eth.RiskUnlimitedApproval}},
+				}
+				return req
+			}(),
+			want: false,
+		},
+		{
+			name: "Contract call that was not decoded",
+			req: func() *signer.ApprovalRequest {
+				req := transferRequest(recipient, "0")
+				req.Transaction.Input = hexutil.MustDecode("0x095ea7b3")
+				return req
+			}(),
+			want: false,
+		},
+		{
+			name: "Transfer with a fee above the limit",
+			req: func() *signer.ApprovalRequest {
+				req := transferRequest(recipient, "1")
+				gasPrice, _ := eth.EtherToWei("0.0001")
+				req.Transaction.GasPrice = (*hexutil.Big)(gasPrice)
+				return req
+			}(),
+			want: false,
+		},
+		{
+			name: "Transfer leaving the fee to the node",
+			req: func() *signer.ApprovalRequest {
+				req := transferRequest(recipient, "1")
+				req.Transaction.GasPrice = nil
+				return req
+			}(),
+			want: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := rules.Approve(context.Background(), tc.req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			assertCorrectValue(t, got, tc.want)
+		})
+	}
+
+	t.Run("Flagged recipients need to be listed", func(t *testing.T) {
+		req := transferRequest(other, "1")
+		req.RecipientWarnings = []eth.ReviewIssue{{Code: eth.IssueFirstTimeRecipient}}
+		anyRecipient := &signer.Rules{AllowedMethods: []string{"eth_sendTransaction"}}
+		got, err := anyRecipient.Approve(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		assertCorrectValue(t, got, false)
+
+		req = transferRequest(recipient, "1")
+		req.RecipientWarnings = []eth.ReviewIssue{{Code: eth.IssueFirstTimeRecipient}}
+		got, err = rules.Approve(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		assertCorrectValue(t, got, true)
+	})
+}
+
+func transferRequest(to common.Address, ether string) *signer.ApprovalRequest {
+	value, _ := eth.EtherToWei(ether)
+	gas := hexutil.Uint64(21000)
+	return &signer.ApprovalRequest{
+		Method:  "eth_sendTransaction",
+		Account: account0,
+		Transaction: &eth.TransactionRequest{
+			From:     common.HexToAddress(account0),
+			To:       &to,
+			Value:    (*hexutil.Big)(new(big.Int).Set(value)),
+			Gas:      &gas,
+			GasPrice: (*hexutil.Big)(big.NewInt(1_000_000_000)),
+		},
+	}
+}
+
+func TestApprovalRequestString(t *testing.T) {
+	req := transferRequest(common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), "1")
+	req.Origin = "http://localhost:3000"
+	nonce := hexutil.Uint64(7)
+	req.Transaction.Nonce = &nonce
+
+	assertCorrectValue(t, req.String(), "eth_sendTransaction request from http://localhost:3000"+
+		"\naccount: "+account0+
+		"\nto: 0x70997970C51812dc3A010C7d01b50e0d17dc79C8"+
+		"\nvalue: 1000000000000000000 wei"+
+		"\ngas: 21000"+
+		"\ngas price: 1 gwei"+
+		"\nmax fee: 0.000021 ether"+
+		"\nnonce: 7")
+
+	req.Transaction.Gas = nil
+	req.Transaction.GasPrice = nil
+	req.Transaction.Nonce = nil
+	assertCorrectValue(t, req.String(), "eth_sendTransaction request from http://localhost:3000"+
+		"\naccount: "+account0+
+		"\nto: 0x70997970C51812dc3A010C7d01b50e0d17dc79C8"+
+		"\nvalue: 1000000000000000000 wei"+
+		"\ngas: estimated by the node"+
+		"\ngas price: set by the node"+
+		"\nnonce: next of the account")
+}
+
+func newTestWallet(t testing.TB) *hdwallet.Wallet {
+	t.Helper()
+	ctx := context.Background()
+	ws, err := hdwallet.NewWalletStorage(ctx, ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create wallet storage: %v", err)
+	}
+	t.Cleanup(func() { ws.Close() })
+
+	wallet, err := hdwallet.RestoreWallet(ctx, password, mnemonic, ws)
+	if err != nil {
+		t.Fatalf("Failed to restore wallet: %v", err)
+	}
+
+	err = wallet.Initialize([]string{"ETH"}, password)
+	if err != nil {
+		t.Fatalf("Failed to initialize wallet: %v", err)
+	}
+
+	return wallet
+}
+
+func callSigner(t testing.TB, url, method string, params ...interface{}) *rpcResponse {
+	t.Helper()
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var response rpcResponse
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	return &response
+}
+
+func assertCorrectValue[T any](t testing.TB, got, want T) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
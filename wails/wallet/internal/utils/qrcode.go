@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"rsc.io/qr"
+)
+
+// qrCodeScale is the size in pixels of a module, one square of a QR code.
+const qrCodeScale = 8
+
+// QRCodePNG encodes text as a QR code PNG image, with the medium error correction level
+// that survives a slightly damaged or blurry code.
+func QRCodePNG(text string) ([]byte, error) {
+	code, err := qr.Encode(text, qr.M)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding QR code: %w", err)
+	}
+
+	code.Scale = qrCodeScale
+	return code.PNG(), nil
+}
+
+// QRCodeDataURL encodes text as a QR code PNG image in a data URL, to be shown in an img
+// element.
+func QRCodeDataURL(text string) (string, error) {
+	png, err := QRCodePNG(text)
+	if err != nil {
+		return "", err
+	}
+
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(png), nil
+}
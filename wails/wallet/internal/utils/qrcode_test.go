@@ -0,0 +1,34 @@
+package utils_test
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+	"wallet/internal/utils"
+)
+
+func TestQRCode(t *testing.T) {
+	uri := "ethereum:0x70997970C51812dc3A010C7d01b50e0d17dc79C8@1337?value=1500000000000000000"
+	data, err := utils.QRCodePNG(uri)
+	if err != nil {
+		t.Fatalf("Failed to encode QR code: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed to decode QR code PNG: %v", err)
+	}
+
+	bounds := img.Bounds()
+	assertCorrectValue(t, bounds.Dx(), bounds.Dy())
+	if bounds.Dx() < 200 {
+		t.Errorf("Expected a QR code large enough to scan, got %dx%d pixels", bounds.Dx(), bounds.Dy())
+	}
+
+	dataURL, err := utils.QRCodeDataURL(uri)
+	if err != nil {
+		t.Fatalf("Failed to encode QR code: %v", err)
+	}
+	assertCorrectValue(t, strings.HasPrefix(dataURL, "data:image/png;base64,"), true)
+}